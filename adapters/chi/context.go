@@ -0,0 +1,101 @@
+package chi
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/techmaster-vietnam/goerrorkit"
+)
+
+// ChiContext wrap http.ResponseWriter và *http.Request để implement goerrorkit.HTTPContext
+// (kèm các interface optional HeaderSetter, HeaderGetter, BodySender, QueryGetter, ClientIPGetter,
+// RequestContextGetter)
+type ChiContext struct {
+	w      http.ResponseWriter
+	r      *http.Request
+	status int
+}
+
+// NewChiContext tạo ChiContext từ http.ResponseWriter và *http.Request
+func NewChiContext(w http.ResponseWriter, r *http.Request) *ChiContext {
+	return &ChiContext{w: w, r: r, status: http.StatusOK}
+}
+
+// Method implements HTTPContext
+func (c *ChiContext) Method() string {
+	return c.r.Method
+}
+
+// Path implements HTTPContext
+func (c *ChiContext) Path() string {
+	return c.r.URL.Path
+}
+
+// GetLocal implements HTTPContext
+// Key "requestid" trả về request ID của chi middleware.RequestID,
+// các key khác được đọc từ request context
+func (c *ChiContext) GetLocal(key string) interface{} {
+	if key == requestIDLocal {
+		if rid := chimiddleware.GetReqID(c.r.Context()); rid != "" {
+			return rid
+		}
+		return nil
+	}
+	return c.r.Context().Value(key)
+}
+
+// Status implements HTTPContext
+func (c *ChiContext) Status(code int) goerrorkit.HTTPContext {
+	c.status = code
+	return c
+}
+
+// JSON implements HTTPContext
+func (c *ChiContext) JSON(data interface{}) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return c.SendBody("application/json", body)
+}
+
+// SetHeader implements HeaderSetter
+func (c *ChiContext) SetHeader(key, value string) {
+	c.w.Header().Set(key, value)
+}
+
+// GetHeader implements HeaderGetter
+func (c *ChiContext) GetHeader(key string) string {
+	return c.r.Header.Get(key)
+}
+
+// Query implements QueryGetter
+func (c *ChiContext) Query(name string) string {
+	return c.r.URL.Query().Get(name)
+}
+
+// ClientIP implements ClientIPGetter
+// Trả về r.RemoteAddr (không có port); dùng chi middleware.RealIP để lấy IP thật sau proxy
+func (c *ChiContext) ClientIP() string {
+	if host, _, err := net.SplitHostPort(c.r.RemoteAddr); err == nil {
+		return host
+	}
+	return c.r.RemoteAddr
+}
+
+// RequestContext implements RequestContextGetter
+// net/http cancel r.Context() khi client đóng connection
+func (c *ChiContext) RequestContext() context.Context {
+	return c.r.Context()
+}
+
+// SendBody implements BodySender
+func (c *ChiContext) SendBody(contentType string, body []byte) error {
+	c.w.Header().Set("Content-Type", contentType)
+	c.w.WriteHeader(c.status)
+	_, err := c.w.Write(body)
+	return err
+}
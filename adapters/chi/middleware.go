@@ -0,0 +1,122 @@
+package chi
+
+import (
+	"net/http"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/techmaster-vietnam/goerrorkit"
+)
+
+// requestIDLocal là key GetLocal trả về request ID (giống key mặc định của Fiber adapter)
+const requestIDLocal = "requestid"
+
+// Middleware là chi middleware (func(http.Handler) http.Handler) recover panic
+// Panic được convert sang AppError với chính xác panic location, log và response
+// theo ResponseWriter hiện tại (mặc định JSON của FormatErrorResponse)
+// Request ID được lấy từ chi middleware.RequestID và gắn vào request context
+// (goerrorkit.RequestIDFromContext) để service layer dùng WrapCtx, NewBusinessErrorCtx, ...
+//
+// http.ErrAbortHandler được panic lại để giữ semantics của net/http
+//
+// Example:
+//
+//	r := chi.NewRouter()
+//	r.Use(middleware.RequestID) // PHẢI đứng trước goerrorkitchi.Middleware
+//	r.Use(goerrorkitchi.Middleware)
+//
+//	r.Get("/panic", func(w http.ResponseWriter, r *http.Request) {
+//	    panic("something went wrong")
+//	})
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := requestIDFromRequest(r)
+		if requestID != "unknown" {
+			r = r.WithContext(goerrorkit.ContextWithRequestID(r.Context(), requestID))
+		}
+
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+			panicErr := goerrorkit.HandlePanic(rec, requestID)
+			goerrorkit.LogAndRespond(NewChiContext(w, r), panicErr, requestPath(r))
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// E chuyển handler trả về error thành http.HandlerFunc
+// Error được convert sang AppError (ConvertToAppErrorCtx), log và response giống Fiber middleware
+// Request bị client hủy (r.Context() bị cancel) → 499 level info, không log (xem SetLogClientDisconnects)
+// Nếu handler đã ghi response trước khi trả về error, error chỉ được log
+//
+// Example:
+//
+//	r.Get("/products/{id}", goerrorkitchi.E(func(w http.ResponseWriter, r *http.Request) error {
+//	    product, err := findProduct(chi.URLParam(r, "id"))
+//	    if err != nil {
+//	        return err
+//	    }
+//	    return json.NewEncoder(w).Encode(product)
+//	}))
+func E(fn func(w http.ResponseWriter, r *http.Request) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tw := &trackingWriter{ResponseWriter: w}
+		err := fn(tw, r)
+		if err == nil {
+			return
+		}
+
+		appErr := goerrorkit.ConvertToAppErrorCtx(r.Context(), err, requestIDFromRequest(r))
+		if tw.written {
+			goerrorkit.LogRequestError(NewChiContext(w, r), appErr, requestPath(r))
+			return
+		}
+		goerrorkit.LogAndRespond(NewChiContext(w, r), appErr, requestPath(r))
+	}
+}
+
+// requestIDFromRequest lấy request ID của chi middleware.RequestID
+// hoặc từ goerrorkit.ContextWithRequestID, mặc định "unknown" (giống Fiber adapter)
+func requestIDFromRequest(r *http.Request) string {
+	if rid := chimiddleware.GetReqID(r.Context()); rid != "" {
+		return rid
+	}
+	if rid := goerrorkit.RequestIDFromContext(r.Context()); rid != "" {
+		return rid
+	}
+	return "unknown"
+}
+
+// requestPath tạo path dạng "METHOD /path" giống Fiber adapter để log output đồng nhất
+func requestPath(r *http.Request) string {
+	return r.Method + " " + r.URL.Path
+}
+
+// trackingWriter ghi nhận handler đã bắt đầu ghi response chưa
+type trackingWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+// WriteHeader implements http.ResponseWriter
+func (w *trackingWriter) WriteHeader(code int) {
+	w.written = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write implements http.ResponseWriter
+func (w *trackingWriter) Write(b []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap cho phép http.ResponseController truy cập ResponseWriter gốc (Flush, Hijack, ...)
+func (w *trackingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
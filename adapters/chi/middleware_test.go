@@ -0,0 +1,193 @@
+package chi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	fiberv2 "github.com/gofiber/fiber/v2"
+	"github.com/techmaster-vietnam/goerrorkit"
+	goerrorkitfiber "github.com/techmaster-vietnam/goerrorkit/adapters/fiber"
+)
+
+func TestEClientDisconnect(t *testing.T) {
+	mem := goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	handler := E(func(w http.ResponseWriter, r *http.Request) error {
+		<-r.Context().Done()
+		return r.Context().Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // client đã đóng connection
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/export", nil).WithContext(ctx))
+
+	if rec.Code != goerrorkit.StatusClientClosedRequest {
+		t.Errorf("status = %d, want 499", rec.Code)
+	}
+	if entries := mem.Entries(); len(entries) != 0 {
+		t.Errorf("client disconnect logged by default: %+v", entries)
+	}
+}
+
+func TestECanceledWithLiveRequestIsServerError(t *testing.T) {
+	mem := goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	// context.Canceled của một operation nội bộ, request vẫn còn sống
+	handler := E(func(w http.ResponseWriter, r *http.Request) error {
+		return context.Canceled
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/export", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", rec.Code)
+	}
+	if entries := mem.Entries(); len(entries) != 1 {
+		t.Errorf("entries = %+v, want one error entry", entries)
+	}
+}
+
+// adapterResponse là response của một adapter đã decode để so sánh giữa các adapter
+type adapterResponse struct {
+	status      int
+	contentType string
+	body        map[string]interface{}
+}
+
+func decodeAdapterResponse(t *testing.T, status int, contentType string, body io.Reader) adapterResponse {
+	t.Helper()
+	resp := adapterResponse{status: status, contentType: contentType}
+	if err := json.NewDecoder(body).Decode(&resp.body); err != nil {
+		t.Fatalf("body is not JSON: %v", err)
+	}
+	// ref là ID ngẫu nhiên của từng response, không so sánh
+	delete(resp.body, "ref")
+	if nested, ok := resp.body["error"].(map[string]interface{}); ok {
+		delete(nested, "ref")
+	}
+	return resp
+}
+
+// respondViaChi và respondViaFiber trả về response của cùng một error qua từng adapter
+func respondViaChi(t *testing.T, appErr *goerrorkit.AppError) adapterResponse {
+	handler := E(func(w http.ResponseWriter, r *http.Request) error { return appErr })
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/orders/42", nil))
+	return decodeAdapterResponse(t, rec.Code, rec.Header().Get("Content-Type"), rec.Body)
+}
+
+func respondViaFiber(t *testing.T, appErr *goerrorkit.AppError) adapterResponse {
+	app := fiberv2.New()
+	app.Use(goerrorkitfiber.ErrorHandler())
+	app.Get("/orders/:id", func(c *fiberv2.Ctx) error { return appErr })
+	resp, err := app.Test(httptest.NewRequest("GET", "/orders/42", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	return decodeAdapterResponse(t, resp.StatusCode, resp.Header.Get("Content-Type"), resp.Body)
+}
+
+func TestResponseWriterConsistentAcrossAdapters(t *testing.T) {
+	goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+	defer goerrorkit.SetResponseWriter(goerrorkit.ResponseWriterJSON)
+
+	goerrorkit.RegisterResponseWriter("test-envelope", goerrorkit.ResponseWriterFunc(
+		func(ctx goerrorkit.HTTPContext, appErr *goerrorkit.AppError) error {
+			return ctx.Status(appErr.Code).JSON(map[string]interface{}{
+				"success": false,
+				"error":   goerrorkit.FormatErrorResponse(appErr),
+			})
+		}))
+
+	tests := []struct {
+		writer      string
+		contentType string
+		field       string
+	}{
+		{goerrorkit.ResponseWriterJSON, "application/json", "error"},
+		{goerrorkit.ResponseWriterProblem, "application/problem+json", "detail"},
+		{"test-envelope", "application/json", "success"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.writer, func(t *testing.T) {
+			if err := goerrorkit.SetResponseWriter(tt.writer); err != nil {
+				t.Fatal(err)
+			}
+			chiResp := respondViaChi(t, goerrorkit.NewBusinessError(404, "Order not found"))
+			fiberResp := respondViaFiber(t, goerrorkit.NewBusinessError(404, "Order not found"))
+
+			if chiResp.status != 404 || fiberResp.status != 404 {
+				t.Errorf("status chi = %d, fiber = %d, want 404", chiResp.status, fiberResp.status)
+			}
+			if _, ok := chiResp.body[tt.field]; !ok {
+				t.Errorf("chi body = %v, want %q from writer %s", chiResp.body, tt.field, tt.writer)
+			}
+			if !reflect.DeepEqual(chiResp.body, fiberResp.body) {
+				t.Errorf("bodies differ:\nchi:   %v\nfiber: %v", chiResp.body, fiberResp.body)
+			}
+			for name, resp := range map[string]adapterResponse{"chi": chiResp, "fiber": fiberResp} {
+				if !strings.HasPrefix(resp.contentType, tt.contentType) {
+					t.Errorf("%s Content-Type = %q, want %s", name, resp.contentType, tt.contentType)
+				}
+			}
+		})
+	}
+
+	if err := goerrorkit.SetResponseWriter("missing"); err == nil {
+		t.Error("SetResponseWriter(missing) = nil, want error")
+	}
+}
+
+func TestChiContextQueryAndClientIP(t *testing.T) {
+	req := httptest.NewRequest("GET", "/orders?page=2", nil)
+	req.RemoteAddr = "203.0.113.7:51234"
+	ctx := NewChiContext(httptest.NewRecorder(), req)
+
+	if got := ctx.Query("page"); got != "2" {
+		t.Errorf("Query(page) = %q, want 2", got)
+	}
+	if got := ctx.Query("missing"); got != "" {
+		t.Errorf("Query(missing) = %q, want empty", got)
+	}
+	if got := ctx.ClientIP(); got != "203.0.113.7" {
+		t.Errorf("ClientIP = %q, want host without port", got)
+	}
+
+	// RemoteAddr không có port (ví dụ sau middleware.RealIP) được giữ nguyên
+	req.RemoteAddr = "198.51.100.1"
+	if got := ctx.ClientIP(); got != "198.51.100.1" {
+		t.Errorf("ClientIP = %q, want 198.51.100.1", got)
+	}
+}
+
+func TestEIncludeClientInfo(t *testing.T) {
+	mem := goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+	goerrorkit.SetIncludeClientInfo(true)
+	defer goerrorkit.SetIncludeClientInfo(false)
+
+	handler := E(func(w http.ResponseWriter, r *http.Request) error {
+		return goerrorkit.NewBusinessError(404, "Order not found")
+	})
+	req := httptest.NewRequest("GET", "/orders/1", nil)
+	req.RemoteAddr = "203.0.113.7:51234"
+	req.Header.Set("User-Agent", "curl/8.0")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	entry, ok := mem.Find("", "Order not found")
+	if !ok || entry.Fields["client_ip"] != "203.0.113.7" || entry.Fields["user_agent"] != "curl/8.0" {
+		t.Errorf("entries = %+v, want client_ip and user_agent", mem.Entries())
+	}
+}
@@ -0,0 +1,181 @@
+package connect
+
+import (
+	"errors"
+	"net/http"
+
+	connectgo "connectrpc.com/connect"
+	"github.com/techmaster-vietnam/goerrorkit"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// CodeOf map ErrorType/HTTP code của AppError sang connect code
+//
+//	ValidationError                 → InvalidArgument
+//	AuthError 401 / 403             → Unauthenticated / PermissionDenied
+//	BusinessError 404 / 409 / 429   → NotFound / AlreadyExists / ResourceExhausted
+//	BusinessError 499               → Canceled
+//	BusinessError 4xx khác / 5xx    → FailedPrecondition / Internal
+//	ExternalError 504 / khác        → DeadlineExceeded / Unavailable
+//	SystemError, PanicError         → Internal
+func CodeOf(appErr *goerrorkit.AppError) connectgo.Code {
+	switch appErr.Type {
+	case goerrorkit.ValidationError:
+		return connectgo.CodeInvalidArgument
+	case goerrorkit.AuthError:
+		if appErr.Code == http.StatusForbidden {
+			return connectgo.CodePermissionDenied
+		}
+		return connectgo.CodeUnauthenticated
+	case goerrorkit.BusinessError:
+		switch {
+		case appErr.Code == http.StatusNotFound:
+			return connectgo.CodeNotFound
+		case appErr.Code == http.StatusConflict:
+			return connectgo.CodeAlreadyExists
+		case appErr.Code == http.StatusTooManyRequests:
+			return connectgo.CodeResourceExhausted
+		case appErr.Code == goerrorkit.StatusClientClosedRequest:
+			return connectgo.CodeCanceled
+		case appErr.Code >= 400 && appErr.Code < 500:
+			return connectgo.CodeFailedPrecondition
+		default:
+			return connectgo.CodeInternal
+		}
+	case goerrorkit.ExternalError:
+		if appErr.Code == http.StatusGatewayTimeout {
+			return connectgo.CodeDeadlineExceeded
+		}
+		return connectgo.CodeUnavailable
+	default:
+		return connectgo.CodeInternal
+	}
+}
+
+// ToConnectError chuyển AppError thành *connect.Error
+// AppError được giữ trong chain (errors.As hoạt động ở phía server), message là appErr.Message
+// AppError.Headers được copy vào Meta() (response header/trailer của connect)
+// Error detail là google.protobuf.Struct chứa type, error_code, ref, request_id và data
+// (data đã được redact theo goerrorkit.SetRedactor)
+func ToConnectError(appErr *goerrorkit.AppError) *connectgo.Error {
+	connectErr := connectgo.NewError(CodeOf(appErr), appErr)
+	// AppError.Headers (Retry-After, ...) được gửi dưới dạng response metadata
+	for k, v := range appErr.Headers {
+		connectErr.Meta().Set(k, v)
+	}
+	if detail, err := connectgo.NewErrorDetail(errorDetail(appErr)); err == nil {
+		connectErr.AddDetail(detail)
+	}
+	return connectErr
+}
+
+// FromConnectError chuyển error nhận được từ connect client thành AppError
+// Connect code được map ngược về ErrorType/HTTP code; error_code, data và ref (Data["remote_ref"])
+// trong error detail (do ToConnectError của service bên kia gắn) được khôi phục nếu có
+// Error không phải *connect.Error được đóng gói bằng goerrorkit.Wrap. Trả về nil nếu err == nil
+//
+// Example:
+//
+//	res, err := client.GetUser(ctx, connect.NewRequest(&userv1.GetUserRequest{Id: id}))
+//	if err != nil {
+//	    return goerrorkitconnect.FromConnectError(err) // NotFound → BusinessError 404
+//	}
+func FromConnectError(err error) *goerrorkit.AppError {
+	if err == nil {
+		return nil
+	}
+	var connectErr *connectgo.Error
+	if !errors.As(err, &connectErr) {
+		return goerrorkit.Wrap(err).WithCaller(1)
+	}
+
+	errType, status := typeAndStatusOf(connectErr.Code())
+	appErr := goerrorkit.WrapWithMessage(err, connectErr.Message()).WithCaller(1)
+	appErr.Type = errType
+	appErr.Code = status
+
+	for _, detail := range connectErr.Details() {
+		msg, derr := detail.Value()
+		if derr != nil {
+			continue
+		}
+		if st, ok := msg.(*structpb.Struct); ok {
+			applyErrorDetail(appErr, st.AsMap())
+			break
+		}
+	}
+	return appErr
+}
+
+// typeAndStatusOf map ngược connect code sang ErrorType và HTTP code
+// Lỗi internal/unknown của service khác là ExternalError 502 từ góc nhìn của client
+func typeAndStatusOf(code connectgo.Code) (goerrorkit.ErrorType, int) {
+	switch code {
+	case connectgo.CodeInvalidArgument:
+		return goerrorkit.ValidationError, http.StatusBadRequest
+	case connectgo.CodeUnauthenticated:
+		return goerrorkit.AuthError, http.StatusUnauthorized
+	case connectgo.CodePermissionDenied:
+		return goerrorkit.AuthError, http.StatusForbidden
+	case connectgo.CodeNotFound:
+		return goerrorkit.BusinessError, http.StatusNotFound
+	case connectgo.CodeAlreadyExists, connectgo.CodeAborted:
+		return goerrorkit.BusinessError, http.StatusConflict
+	case connectgo.CodeFailedPrecondition, connectgo.CodeOutOfRange:
+		return goerrorkit.BusinessError, http.StatusUnprocessableEntity
+	case connectgo.CodeResourceExhausted:
+		return goerrorkit.BusinessError, http.StatusTooManyRequests
+	case connectgo.CodeCanceled:
+		return goerrorkit.BusinessError, goerrorkit.StatusClientClosedRequest
+	case connectgo.CodeDeadlineExceeded:
+		return goerrorkit.ExternalError, http.StatusGatewayTimeout
+	case connectgo.CodeUnavailable:
+		return goerrorkit.ExternalError, http.StatusServiceUnavailable
+	default:
+		return goerrorkit.ExternalError, http.StatusBadGateway
+	}
+}
+
+// errorDetail tạo Struct detail từ AppError, bỏ qua các key data không convert được sang protobuf
+func errorDetail(appErr *goerrorkit.AppError) *structpb.Struct {
+	fields := map[string]interface{}{
+		"type": string(appErr.Type),
+		"ref":  appErr.Ref(),
+	}
+	if appErr.ErrCode != "" {
+		fields["error_code"] = appErr.ErrCode
+	}
+	if appErr.RequestID != "" {
+		fields["request_id"] = appErr.RequestID
+	}
+	if appErr.HasData() {
+		data := make(map[string]interface{}, len(appErr.Data))
+		for k, v := range appErr.Data {
+			v = goerrorkit.RedactValue(k, v)
+			if _, err := structpb.NewValue(v); err == nil {
+				data[k] = v
+			}
+		}
+		fields["data"] = data
+	}
+
+	st, err := structpb.NewStruct(fields)
+	if err != nil {
+		st = &structpb.Struct{}
+	}
+	return st
+}
+
+// applyErrorDetail khôi phục error_code và data từ detail (type được giữ theo connect code
+// vì ErrorType của service bên kia không nhất thiết đúng với góc nhìn của client)
+func applyErrorDetail(appErr *goerrorkit.AppError, detail map[string]interface{}) {
+	if code, ok := detail["error_code"].(string); ok {
+		appErr.ErrCode = code
+	}
+	if data, ok := detail["data"].(map[string]interface{}); ok {
+		appErr.Data = data
+	}
+	if ref, ok := detail["ref"].(string); ok {
+		appErr.WithField("remote_ref", ref)
+	}
+}
@@ -0,0 +1,140 @@
+package connect
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	connectgo "connectrpc.com/connect"
+	"github.com/techmaster-vietnam/goerrorkit"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		errType goerrorkit.ErrorType
+		code    int
+		want    connectgo.Code
+	}{
+		{goerrorkit.ValidationError, 400, connectgo.CodeInvalidArgument},
+		{goerrorkit.ValidationError, 422, connectgo.CodeInvalidArgument},
+		{goerrorkit.AuthError, 401, connectgo.CodeUnauthenticated},
+		{goerrorkit.AuthError, 403, connectgo.CodePermissionDenied},
+		{goerrorkit.BusinessError, 404, connectgo.CodeNotFound},
+		{goerrorkit.BusinessError, 409, connectgo.CodeAlreadyExists},
+		{goerrorkit.BusinessError, 429, connectgo.CodeResourceExhausted},
+		{goerrorkit.BusinessError, goerrorkit.StatusClientClosedRequest, connectgo.CodeCanceled},
+		{goerrorkit.BusinessError, 422, connectgo.CodeFailedPrecondition},
+		{goerrorkit.BusinessError, 500, connectgo.CodeInternal},
+		{goerrorkit.ExternalError, 502, connectgo.CodeUnavailable},
+		{goerrorkit.ExternalError, 503, connectgo.CodeUnavailable},
+		{goerrorkit.ExternalError, 504, connectgo.CodeDeadlineExceeded},
+		{goerrorkit.SystemError, 500, connectgo.CodeInternal},
+		{goerrorkit.PanicError, 500, connectgo.CodeInternal},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s_%d", tt.errType, tt.code), func(t *testing.T) {
+			appErr := &goerrorkit.AppError{Type: tt.errType, Code: tt.code, Message: "test"}
+			if got := CodeOf(appErr); got != tt.want {
+				t.Errorf("CodeOf(%s %d) = %s, want %s", tt.errType, tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromConnectErrorCodes(t *testing.T) {
+	tests := []struct {
+		code    connectgo.Code
+		errType goerrorkit.ErrorType
+		status  int
+	}{
+		{connectgo.CodeInvalidArgument, goerrorkit.ValidationError, 400},
+		{connectgo.CodeUnauthenticated, goerrorkit.AuthError, 401},
+		{connectgo.CodePermissionDenied, goerrorkit.AuthError, 403},
+		{connectgo.CodeNotFound, goerrorkit.BusinessError, 404},
+		{connectgo.CodeAlreadyExists, goerrorkit.BusinessError, 409},
+		{connectgo.CodeAborted, goerrorkit.BusinessError, 409},
+		{connectgo.CodeFailedPrecondition, goerrorkit.BusinessError, 422},
+		{connectgo.CodeOutOfRange, goerrorkit.BusinessError, 422},
+		{connectgo.CodeResourceExhausted, goerrorkit.BusinessError, 429},
+		{connectgo.CodeCanceled, goerrorkit.BusinessError, goerrorkit.StatusClientClosedRequest},
+		{connectgo.CodeDeadlineExceeded, goerrorkit.ExternalError, 504},
+		{connectgo.CodeUnavailable, goerrorkit.ExternalError, 503},
+		{connectgo.CodeInternal, goerrorkit.ExternalError, 502},
+		{connectgo.CodeUnknown, goerrorkit.ExternalError, 502},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			connectErr := connectgo.NewError(tt.code, errors.New("remote failure"))
+			appErr := FromConnectError(fmt.Errorf("call user service: %w", connectErr))
+
+			if appErr.Type != tt.errType || appErr.Code != tt.status {
+				t.Errorf("got %s %d, want %s %d", appErr.Type, appErr.Code, tt.errType, tt.status)
+			}
+			if appErr.Message != "remote failure" {
+				t.Errorf("message = %q, want connect message", appErr.Message)
+			}
+			var got *connectgo.Error
+			if !errors.As(appErr, &got) || got != connectErr {
+				t.Error("connect error not kept as Cause")
+			}
+		})
+	}
+}
+
+func TestToConnectErrorDetailsRoundTrip(t *testing.T) {
+	appErr := goerrorkit.NewBusinessError(409, "Email already registered").
+		WithErrorCode("USR-1001").
+		WithRequestID("req-7").
+		WithData(map[string]interface{}{"email": "an@example.com", "password": "secret", "attempts": 3}).
+		WithHeader("Retry-After", "30")
+
+	connectErr := ToConnectError(appErr)
+
+	if connectErr.Code() != connectgo.CodeAlreadyExists || connectErr.Message() != "Email already registered" {
+		t.Errorf("got %s %q", connectErr.Code(), connectErr.Message())
+	}
+	if connectErr.Meta().Get("Retry-After") != "30" {
+		t.Errorf("meta = %v, want Retry-After", connectErr.Meta())
+	}
+	var inner *goerrorkit.AppError
+	if !errors.As(connectErr, &inner) || inner != appErr {
+		t.Error("AppError not kept in connect error chain")
+	}
+
+	details := connectErr.Details()
+	if len(details) != 1 {
+		t.Fatalf("details = %d, want 1", len(details))
+	}
+	msg, err := details[0].Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	detail := msg.(*structpb.Struct).AsMap()
+	if detail["type"] != "BUSINESS" || detail["error_code"] != "USR-1001" || detail["request_id"] != "req-7" || detail["ref"] != appErr.Ref() {
+		t.Errorf("detail = %v", detail)
+	}
+	data, _ := detail["data"].(map[string]interface{})
+	if data["email"] != "an@example.com" || data["attempts"] != float64(3) || data["password"] == "secret" {
+		t.Errorf("detail data = %v, want sensitive keys redacted", data)
+	}
+
+	// Client nhận lại error_code, data và ref của service bên kia
+	remote := connectgo.NewError(connectErr.Code(), errors.New(connectErr.Message()))
+	remote.AddDetail(details[0])
+	got := FromConnectError(remote)
+	if got.Code != 409 || got.ErrCode != "USR-1001" || got.Data["email"] != "an@example.com" || got.Data["remote_ref"] != appErr.Ref() {
+		t.Errorf("FromConnectError = %d %q data=%v", got.Code, got.ErrCode, got.Data)
+	}
+}
+
+func TestFromConnectErrorNonConnectError(t *testing.T) {
+	if FromConnectError(nil) != nil {
+		t.Error("FromConnectError(nil) != nil")
+	}
+	cause := errors.New("dial tcp: connection refused")
+	appErr := FromConnectError(cause)
+	if appErr.Type != goerrorkit.SystemError || appErr.Code != 500 || !errors.Is(appErr, cause) {
+		t.Errorf("got %s %d, want wrapped SystemError", appErr.Type, appErr.Code)
+	}
+}
@@ -0,0 +1,117 @@
+package connect
+
+import (
+	"context"
+	"errors"
+
+	connectgo "connectrpc.com/connect"
+	"github.com/techmaster-vietnam/goerrorkit"
+)
+
+// RequestIDHeader là request header chứa request ID (giống header của Fiber requestid middleware)
+const RequestIDHeader = "X-Request-Id"
+
+// interceptor implement connect.Interceptor cho phía server (handler)
+type interceptor struct{}
+
+// NewInterceptor tạo connect interceptor cho handler:
+//   - Recover panic → PanicError (chính xác panic location), log, trả về connect.CodeInternal
+//   - Error do handler trả về → ConvertToAppError, log, trả về connect error theo CodeOf
+//   - *connect.Error do handler tự tạo được log nhưng trả về nguyên vẹn
+//
+// Path trong log là tên procedure (ví dụ "/user.v1.UserService/GetUser")
+// Request ID lấy từ header X-Request-Id (hoặc context) và được gắn vào context của handler
+// Interceptor không làm gì với client (dùng FromConnectError ở phía client)
+//
+// Example:
+//
+//	path, handler := userv1connect.NewUserServiceHandler(
+//	    &userServer{},
+//	    connect.WithInterceptors(goerrorkitconnect.NewInterceptor()),
+//	)
+//	mux.Handle(path, handler)
+func NewInterceptor() connectgo.Interceptor {
+	return &interceptor{}
+}
+
+// WrapUnary implements connect.Interceptor
+func (i *interceptor) WrapUnary(next connectgo.UnaryFunc) connectgo.UnaryFunc {
+	return func(ctx context.Context, req connectgo.AnyRequest) (res connectgo.AnyResponse, err error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+
+		ctx, requestID := withRequestID(ctx, req.Header().Get(RequestIDHeader))
+		procedure := req.Spec().Procedure
+		defer func() {
+			if r := recover(); r != nil {
+				res, err = nil, handlePanic(r, requestID, procedure)
+			}
+		}()
+
+		res, err = next(ctx, req)
+		if err != nil {
+			return nil, handleError(ctx, err, requestID, procedure)
+		}
+		return res, nil
+	}
+}
+
+// WrapStreamingClient implements connect.Interceptor (không xử lý phía client)
+func (i *interceptor) WrapStreamingClient(next connectgo.StreamingClientFunc) connectgo.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler implements connect.Interceptor
+func (i *interceptor) WrapStreamingHandler(next connectgo.StreamingHandlerFunc) connectgo.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connectgo.StreamingHandlerConn) (err error) {
+		ctx, requestID := withRequestID(ctx, conn.RequestHeader().Get(RequestIDHeader))
+		procedure := conn.Spec().Procedure
+		defer func() {
+			if r := recover(); r != nil {
+				err = handlePanic(r, requestID, procedure)
+			}
+		}()
+
+		if err := next(ctx, conn); err != nil {
+			return handleError(ctx, err, requestID, procedure)
+		}
+		return nil
+	}
+}
+
+// withRequestID gắn request ID vào context (ưu tiên header), mặc định "unknown" giống Fiber adapter
+func withRequestID(ctx context.Context, headerID string) (context.Context, string) {
+	if headerID != "" {
+		return goerrorkit.ContextWithRequestID(ctx, headerID), headerID
+	}
+	if rid := goerrorkit.RequestIDFromContext(ctx); rid != "" {
+		return ctx, rid
+	}
+	return ctx, "unknown"
+}
+
+// handlePanic convert panic sang PanicError, log và trả về connect error
+func handlePanic(r interface{}, requestID, procedure string) error {
+	appErr := goerrorkit.HandlePanic(r, requestID)
+	goerrorkit.LogError(appErr, procedure)
+	return ToConnectError(appErr)
+}
+
+// handleError log error của handler và trả về connect error tương ứng
+// ctx là context của request: client hủy request → 499 (CodeCanceled) thay vì Internal
+func handleError(ctx context.Context, err error, requestID, procedure string) error {
+	var connectErr *connectgo.Error
+	var appErr *goerrorkit.AppError
+	if errors.As(err, &connectErr) && !errors.As(err, &appErr) {
+		// Handler đã chủ động chọn connect code - chỉ log
+		logged := FromConnectError(err)
+		logged.RequestID = requestID
+		goerrorkit.LogError(logged, procedure)
+		return err
+	}
+
+	appErr = goerrorkit.ConvertToAppErrorCtx(ctx, err, requestID)
+	goerrorkit.LogError(appErr, procedure)
+	return ToConnectError(appErr)
+}
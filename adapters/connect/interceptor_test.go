@@ -0,0 +1,152 @@
+package connect
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	connectgo "connectrpc.com/connect"
+	"github.com/techmaster-vietnam/goerrorkit"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	procedureGetUser = "/user.v1.UserService/GetUser"
+	procedureWatch   = "/user.v1.UserService/Watch"
+)
+
+// newTestServer tạo connect server với interceptor; unary handler của procedureGetUser trả về
+// kết quả của handle, procedureWatch (server streaming) panic
+func newTestServer(t *testing.T, handle func(ctx context.Context) error) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	opts := connectgo.WithInterceptors(NewInterceptor())
+	mux.Handle(procedureGetUser, connectgo.NewUnaryHandler(procedureGetUser,
+		func(ctx context.Context, req *connectgo.Request[emptypb.Empty]) (*connectgo.Response[emptypb.Empty], error) {
+			if err := handle(ctx); err != nil {
+				return nil, err
+			}
+			return connectgo.NewResponse(&emptypb.Empty{}), nil
+		}, opts))
+	mux.Handle(procedureWatch, connectgo.NewServerStreamHandler(procedureWatch,
+		func(ctx context.Context, req *connectgo.Request[emptypb.Empty], stream *connectgo.ServerStream[emptypb.Empty]) error {
+			panic("stream crashed")
+		}, opts))
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// callGetUser gọi procedureGetUser với request ID và trả về error của client
+func callGetUser(t *testing.T, srv *httptest.Server) error {
+	t.Helper()
+	client := connectgo.NewClient[emptypb.Empty, emptypb.Empty](srv.Client(), srv.URL+procedureGetUser)
+	req := connectgo.NewRequest(&emptypb.Empty{})
+	req.Header().Set(RequestIDHeader, "req-7")
+	_, err := client.CallUnary(context.Background(), req)
+	return err
+}
+
+func TestInterceptorMapsAppErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		want  connectgo.Code
+		level string
+	}{
+		{"validation", goerrorkit.NewValidationError("Email is required", nil), connectgo.CodeInvalidArgument, "warn"},
+		{"unauthenticated", goerrorkit.NewAuthError(401, "Missing token"), connectgo.CodeUnauthenticated, "warn"},
+		{"not found", goerrorkit.NewBusinessError(404, "User not found"), connectgo.CodeNotFound, "error"},
+		{"external", goerrorkit.NewExternalError(502, "Payment gateway down", errors.New("timeout")), connectgo.CodeUnavailable, "error"},
+		{"plain error", errors.New("db down"), connectgo.CodeInternal, "error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mem := goerrorkit.UseMemoryLogger()
+			defer goerrorkit.SetLogger(nil)
+
+			var handlerRequestID string
+			srv := newTestServer(t, func(ctx context.Context) error {
+				handlerRequestID = goerrorkit.RequestIDFromContext(ctx)
+				return tt.err
+			})
+
+			err := callGetUser(t, srv)
+			if got := connectgo.CodeOf(err); got != tt.want {
+				t.Errorf("code = %s, want %s (err %v)", got, tt.want, err)
+			}
+			if handlerRequestID != "req-7" {
+				t.Errorf("handler context request ID = %q, want req-7", handlerRequestID)
+			}
+
+			entries := mem.Entries()
+			if len(entries) != 1 {
+				t.Fatalf("entries = %+v, want one", entries)
+			}
+			if entries[0].Level != tt.level || entries[0].Fields["path"] != procedureGetUser || entries[0].Fields["request_id"] != "req-7" {
+				t.Errorf("entry = %+v, want level %s with procedure path", entries[0], tt.level)
+			}
+		})
+	}
+}
+
+func TestInterceptorRecoversPanic(t *testing.T) {
+	mem := goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	srv := newTestServer(t, func(ctx context.Context) error {
+		var m map[string]int
+		m["boom"]++
+		return nil
+	})
+
+	if got := connectgo.CodeOf(callGetUser(t, srv)); got != connectgo.CodeInternal {
+		t.Errorf("code = %s, want internal", got)
+	}
+	entry, ok := mem.Find("", "Panic recovered")
+	if !ok || entry.Fields["path"] != procedureGetUser {
+		t.Errorf("entries = %+v, want panic logged with procedure path", mem.Entries())
+	}
+}
+
+func TestInterceptorRecoversStreamingPanic(t *testing.T) {
+	mem := goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	srv := newTestServer(t, func(ctx context.Context) error { return nil })
+	client := connectgo.NewClient[emptypb.Empty, emptypb.Empty](srv.Client(), srv.URL+procedureWatch)
+	stream, err := client.CallServerStream(context.Background(), connectgo.NewRequest(&emptypb.Empty{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for stream.Receive() {
+	}
+	if got := connectgo.CodeOf(stream.Err()); got != connectgo.CodeInternal {
+		t.Errorf("code = %s, want internal (err %v)", got, stream.Err())
+	}
+	entry, ok := mem.Find("", "Panic recovered: stream crashed")
+	if !ok || entry.Fields["path"] != procedureWatch || entry.Fields["request_id"] != "unknown" {
+		t.Errorf("entries = %+v, want streaming panic logged", mem.Entries())
+	}
+}
+
+func TestInterceptorKeepsHandlerConnectError(t *testing.T) {
+	mem := goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	srv := newTestServer(t, func(ctx context.Context) error {
+		return connectgo.NewError(connectgo.CodeAborted, errors.New("version mismatch"))
+	})
+
+	err := callGetUser(t, srv)
+	if got := connectgo.CodeOf(err); got != connectgo.CodeAborted {
+		t.Errorf("code = %s, want aborted chosen by handler", got)
+	}
+	entry, ok := mem.Find("", "version mismatch")
+	if !ok || entry.Fields["request_id"] != "req-7" {
+		t.Errorf("entries = %+v, want handler connect error logged", mem.Entries())
+	}
+}
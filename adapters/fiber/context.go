@@ -1,13 +1,18 @@
 package fiber
 
 import (
+	"context"
+
 	fiberv2 "github.com/gofiber/fiber/v2"
 	"github.com/techmaster-vietnam/goerrorkit"
 )
 
-// FiberContext wrap Fiber's context để implement goerrorkit.HTTPContext interface
+// FiberContext wrap Fiber's context để implement goerrorkit.HTTPContext
+// (kèm các interface optional HeaderSetter, HeaderGetter, BodySender, QueryGetter, ClientIPGetter,
+// RequestContextGetter, UpgradeStateGetter, LogFieldsProvider cho field request do ErrorHandler ghi nhận)
 type FiberContext struct {
-	ctx *fiberv2.Ctx
+	ctx       *fiberv2.Ctx
+	logFields map[string]interface{} // raw_path, latency_ms, headers - do ErrorHandler gắn
 }
 
 // NewFiberContext tạo FiberContext từ fiber.Ctx
@@ -15,28 +20,91 @@ func NewFiberContext(c *fiberv2.Ctx) *FiberContext {
 	return &FiberContext{ctx: c}
 }
 
-// Method implements goerrorkit.HTTPContext
+// Method implements HTTPContext
 func (f *FiberContext) Method() string {
 	return f.ctx.Method()
 }
 
-// Path implements goerrorkit.HTTPContext
+// Path implements HTTPContext
 func (f *FiberContext) Path() string {
 	return f.ctx.Path()
 }
 
-// GetLocal implements goerrorkit.HTTPContext
+// GetLocal implements HTTPContext
 func (f *FiberContext) GetLocal(key string) interface{} {
 	return f.ctx.Locals(key)
 }
 
-// Status implements goerrorkit.HTTPContext
+// Status implements HTTPContext
 func (f *FiberContext) Status(code int) goerrorkit.HTTPContext {
 	f.ctx.Status(code)
 	return f
 }
 
-// JSON implements goerrorkit.HTTPContext
+// JSON implements HTTPContext
 func (f *FiberContext) JSON(data interface{}) error {
 	return f.ctx.JSON(data)
 }
+
+// SetHeader implements HeaderSetter
+func (f *FiberContext) SetHeader(key, value string) {
+	f.ctx.Set(key, value)
+}
+
+// GetHeader implements HeaderGetter
+func (f *FiberContext) GetHeader(key string) string {
+	return f.ctx.Get(key)
+}
+
+// Query implements QueryGetter
+func (f *FiberContext) Query(name string) string {
+	return f.ctx.Query(name)
+}
+
+// ClientIP implements ClientIPGetter
+// Tôn trọng fiber.Config.ProxyHeader khi app chạy sau reverse proxy
+func (f *FiberContext) ClientIP() string {
+	return f.ctx.IP()
+}
+
+// RequestContext implements RequestContextGetter
+// Trả về c.UserContext(): fasthttp không báo khi client đóng connection, nên request chỉ được coi
+// là bị client hủy khi middleware (timeout, ...) cancel user context
+func (f *FiberContext) RequestContext() context.Context {
+	return f.ctx.UserContext()
+}
+
+// Upgraded implements UpgradeStateGetter
+// Connection WebSocket (gofiber/contrib/websocket) đã trả về 101 và bị hijack sau khi upgrade
+func (f *FiberContext) Upgraded() bool {
+	return f.ctx.Response().StatusCode() == fiberv2.StatusSwitchingProtocols || f.ctx.Context().Hijacked()
+}
+
+// SendBody implements BodySender
+func (f *FiberContext) SendBody(contentType string, body []byte) error {
+	f.ctx.Set(fiberv2.HeaderContentType, contentType)
+	return f.ctx.Send(body)
+}
+
+// LogFields implements LogFieldsProvider
+// Trả về field request do ErrorHandler ghi nhận kèm status của response tại thời điểm log
+// (nil với FiberContext tạo bằng NewFiberContext bên ngoài middleware)
+func (f *FiberContext) LogFields() map[string]interface{} {
+	if f.logFields == nil {
+		return nil
+	}
+	fields := make(map[string]interface{}, len(f.logFields)+1)
+	for k, v := range f.logFields {
+		fields[k] = v
+	}
+	fields["status"] = f.ctx.Response().StatusCode()
+	return fields
+}
+
+// setLogField gắn field request vào error log của context
+func (f *FiberContext) setLogField(key string, value interface{}) {
+	if f.logFields == nil {
+		f.logFields = make(map[string]interface{})
+	}
+	f.logFields[key] = value
+}
@@ -1,54 +1,301 @@
 package fiber
 
 import (
+	"errors"
+	"strings"
+	"time"
+
 	fiberv2 "github.com/gofiber/fiber/v2"
 	"github.com/techmaster-vietnam/goerrorkit"
 )
 
+// Config cấu hình cho ErrorHandlerWithConfig
+// Zero value giữ nguyên hành vi mặc định của ErrorHandler()
+type Config struct {
+	// SkipPaths - Danh sách path bỏ qua hoàn toàn middleware (health check, metrics, ...)
+	SkipPaths []string
+
+	// Skip - Function quyết định có bỏ qua middleware cho request này không
+	Skip func(c *fiberv2.Ctx) bool
+
+	// OnError - Callback được gọi SAU khi log, dùng cho custom side effects (metrics, alert, ...)
+	OnError func(c *fiberv2.Ctx, appErr *goerrorkit.AppError)
+
+	// RequestIDKey - Key trong c.Locals() chứa request ID (mặc định "requestid")
+	RequestIDKey string
+
+	// Formatter - Custom response body (mặc định goerrorkit.FormatErrorResponse)
+	Formatter func(appErr *goerrorkit.AppError) interface{}
+
+	// PassThroughErrors - Sau khi log và gửi response, vẫn return error lên chain
+	// để Fiber's logger middleware ghi nhận đúng status code.
+	// BẮT BUỘC dùng kèm fiber.Config{ErrorHandler: goerrorkitfiber.AppErrorHandler()}
+	// để response không bị ghi đè bởi DefaultErrorHandler của Fiber.
+	PassThroughErrors bool
+
+	// DisableRecoverPaths - Danh sách path KHÔNG recover panic (panic được propagate lên layer khác
+	// như profiling/debugging middleware). Error được return vẫn được xử lý bình thường.
+	DisableRecoverPaths []string
+
+	// DisableRecover - Function quyết định có tắt panic recovery cho request này không
+	DisableRecover func(c *fiberv2.Ctx) bool
+
+	// LogClientDisconnects - Ghi log (level info) cho request bị client hủy (user context bị cancel
+	// và handler trả về context.Canceled, broken pipe, ...). Mặc định false: các request này được
+	// convert thành 499 và không log (trừ khi bật goerrorkit.SetLogClientDisconnects(true))
+	LogClientDisconnects bool
+
+	// SkipClientDisconnectResponse - Không gửi response cho request bị client hủy (client đã đi)
+	SkipClientDisconnectResponse bool
+
+	// RePanic - Quyết định có panic lại sau khi đã log PanicError và flush log không
+	// (out of memory, global state hỏng, ... → để process crash và được restart)
+	// nil → dùng policy của goerrorkit.SetRePanic (mặc định không bao giờ re-panic)
+	RePanic func(recovered interface{}) bool
+
+	// LogHeaders - Allowlist request header được ghi vào field "headers" của error log
+	// (giá trị đi qua goerrorkit.SetRedactor). Mặc định không log header nào
+	// Được gộp với allowlist toàn cục của goerrorkit.SetLoggedRequestHeaders
+	LogHeaders []string
+}
+
+// handledKey là key trong c.Locals() đánh dấu error đã được log và response
+const handledKey = "goerrorkit_handled"
+
 // ErrorHandler là Fiber middleware để xử lý panic và errors
 // Tự động recover panic và convert errors sang AppError với stack trace chi tiết
+// Error log có path là route pattern ("GET /users/:id"), kèm raw_path, status và latency_ms
 //
 // Example:
 //
+//	import goerrorkitfiber "github.com/techmaster-vietnam/goerrorkit/adapters/fiber"
+//
 //	app := fiber.New()
-//	app.Use(fiber.ErrorHandler())
+//	app.Use(goerrorkitfiber.ErrorHandler())
 //
 //	app.Get("/test", func(c *fiber.Ctx) error {
 //	    // Panic sẽ được tự động catch và log với chính xác location
 //	    panic("something went wrong")
 //	})
 func ErrorHandler() fiberv2.Handler {
-	return func(c *fiberv2.Ctx) error {
+	return ErrorHandlerWithConfig(Config{})
+}
+
+// ErrorHandlerWithConfig giống ErrorHandler nhưng cho phép tùy chỉnh
+//
+// Example:
+//
+//	app.Use(goerrorkitfiber.ErrorHandlerWithConfig(goerrorkitfiber.Config{
+//	    SkipPaths:           []string{"/health", "/metrics"},
+//	    RequestIDKey:        "request_id",
+//	    DisableRecoverPaths: []string{"/debug/crash"}, // panic propagate lên profiling layer
+//	    OnError: func(c *fiber.Ctx, appErr *goerrorkit.AppError) {
+//	        errorCounter.WithLabelValues(string(appErr.Type)).Inc()
+//	    },
+//	}))
+func ErrorHandlerWithConfig(cfg Config) fiberv2.Handler {
+	requestIDKey := cfg.RequestIDKey
+	if requestIDKey == "" {
+		requestIDKey = "requestid"
+	}
+
+	skipPaths := make(map[string]struct{}, len(cfg.SkipPaths))
+	for _, p := range cfg.SkipPaths {
+		skipPaths[p] = struct{}{}
+	}
+
+	disableRecoverPaths := make(map[string]struct{}, len(cfg.DisableRecoverPaths))
+	for _, p := range cfg.DisableRecoverPaths {
+		disableRecoverPaths[p] = struct{}{}
+	}
+
+	return func(c *fiberv2.Ctx) (handlerErr error) {
+		// Bỏ qua middleware cho các path/request được cấu hình
+		if _, ok := skipPaths[c.Path()]; ok {
+			return c.Next()
+		}
+		if cfg.Skip != nil && cfg.Skip(c) {
+			return c.Next()
+		}
+
 		// Wrap Fiber context
 		ctx := NewFiberContext(c)
+		entryRoute := c.Route()
+		start := time.Now()
+
+		// Ghi nhận thông tin request khi có error: path là route pattern (cardinality thấp),
+		// raw_path, latency quanh c.Next(), header trong allowlist; status được thêm lúc log
+		capture := func() string {
+			ctx.setLogField("raw_path", strings.Clone(c.Path()))
+			ctx.setLogField("latency_ms", time.Since(start).Milliseconds())
+			if headers := allowedHeaders(c, cfg.LogHeaders); headers != nil {
+				ctx.setLogField("headers", headers)
+			}
+			return ctx.Method() + " " + routePattern(c, entryRoute)
+		}
 
-		requestPath := ctx.Method() + " " + ctx.Path()
 		requestID := "unknown"
-		if rid, ok := ctx.GetLocal("requestid").(string); ok {
+		if rid, ok := ctx.GetLocal(requestIDKey).(string); ok {
 			requestID = rid
+			// Gắn request ID vào user context để service layer (WrapCtx, NewBusinessErrorCtx, ...) dùng được
+			c.SetUserContext(goerrorkit.ContextWithRequestID(c.UserContext(), rid))
 		}
 
-		// Panic recovery với chính xác panic location
-		defer func() {
-			r := recover()
-			if r != nil {
-				// Xử lý panic bằng core logic - capture chính xác dòng gây panic
-				panicErr := goerrorkit.HandlePanic(r, requestID)
-				goerrorkit.LogAndRespond(ctx, panicErr, requestPath)
+		handle := func(appErr *goerrorkit.AppError) {
+			goerrorkit.LogAndRespondWith(ctx, appErr, capture(), cfg.Formatter)
+			if cfg.OnError != nil {
+				cfg.OnError(c, appErr)
 			}
-		}()
+		}
+
+		// Panic recovery với chính xác panic location (trừ khi bị tắt cho route này)
+		_, noRecover := disableRecoverPaths[c.Path()]
+		if !noRecover && cfg.DisableRecover != nil {
+			noRecover = cfg.DisableRecover(c)
+		}
+		if !noRecover {
+			defer func() {
+				r := recover()
+				if r != nil {
+					// Xử lý panic bằng core logic - capture chính xác dòng gây panic
+					panicErr := goerrorkit.HandlePanic(r, requestID)
+					handle(panicErr)
+					goerrorkit.RePanicIfFatal(cfg.RePanic, r)
+					if cfg.PassThroughErrors {
+						c.Locals(handledKey, true)
+						handlerErr = panicErr
+					}
+				}
+			}()
+		}
 
 		// Thực thi handler
 		err := c.Next()
 
 		// Xử lý error nếu có
 		if err != nil {
+			// Client hủy request (user context bị cancel): 499 level info thay vì 500 SystemError
+			if goerrorkit.IsClientDisconnect(c.UserContext(), err) {
+				logged := cfg.LogClientDisconnects || goerrorkit.IsLogClientDisconnectsEnabled()
+				appErr := goerrorkit.NewClientDisconnectError(err, requestID, logged)
+				if cfg.SkipClientDisconnectResponse {
+					goerrorkit.LogRequestError(ctx, appErr, capture())
+					return nil
+				}
+				handle(appErr)
+				return nil
+			}
+
 			// Convert sang AppError bằng core logic
-			appErr := goerrorkit.ConvertToAppError(err, requestID)
-			goerrorkit.LogAndRespond(ctx, appErr, requestPath)
+			appErr := convertFiberError(err, requestID)
+			handle(appErr)
+			if cfg.PassThroughErrors {
+				c.Locals(handledKey, true)
+				return appErr
+			}
 			return nil
 		}
 
 		return nil
 	}
 }
+
+// routePattern trả về route pattern của handler được match (ví dụ "/users/:id", giống ${route}
+// của Fiber logger). Fallback về raw path khi không có route nào sau middleware được match (404)
+func routePattern(c *fiberv2.Ctx, entryRoute *fiberv2.Route) string {
+	if route := c.Route(); route != entryRoute && route.Path != "" {
+		return route.Path
+	}
+	return c.Path()
+}
+
+// allowedHeaders đọc các request header trong allowlist (bỏ qua header rỗng), nil nếu không có
+func allowedHeaders(c *fiberv2.Ctx, names []string) map[string]interface{} {
+	var headers map[string]interface{}
+	for _, name := range names {
+		v := c.Get(name)
+		if v == "" {
+			continue
+		}
+		if headers == nil {
+			headers = make(map[string]interface{}, len(names))
+		}
+		headers[name] = goerrorkit.RedactValue(name, strings.Clone(v))
+	}
+	return headers
+}
+
+// AppErrorHandler trả về handler dùng cho fiber.Config{ErrorHandler: ...}
+// - Nếu error đã được ErrorHandler (PassThroughErrors) xử lý: không ghi response lần nữa
+// - Ngược lại: convert sang AppError, log và gửi response (có thể dùng thay cho middleware)
+// *fiber.Error (ví dụ route không tồn tại) được giữ nguyên status code.
+// Request ID đọc từ c.Locals("requestid"); dùng AppErrorHandlerWithConfig nếu lưu ở key khác
+//
+// Example:
+//
+//	app := fiber.New(fiber.Config{
+//	    ErrorHandler: goerrorkitfiber.AppErrorHandler(),
+//	})
+//	app.Use(logger.New()) // logger ghi nhận đúng status (404, 422, ...)
+//	app.Use(goerrorkitfiber.ErrorHandlerWithConfig(goerrorkitfiber.Config{
+//	    PassThroughErrors: true,
+//	}))
+func AppErrorHandler() fiberv2.ErrorHandler {
+	return AppErrorHandlerWithConfig(Config{})
+}
+
+// AppErrorHandlerWithConfig giống AppErrorHandler nhưng dùng RequestIDKey, Formatter và OnError của cfg
+// Nên truyền cùng Config với ErrorHandlerWithConfig để hai handler đọc request ID ở cùng key
+//
+// Example:
+//
+//	cfg := goerrorkitfiber.Config{RequestIDKey: "request_id", PassThroughErrors: true}
+//	app := fiber.New(fiber.Config{
+//	    ErrorHandler: goerrorkitfiber.AppErrorHandlerWithConfig(cfg),
+//	})
+//	app.Use(goerrorkitfiber.ErrorHandlerWithConfig(cfg))
+func AppErrorHandlerWithConfig(cfg Config) fiberv2.ErrorHandler {
+	requestIDKey := cfg.RequestIDKey
+	if requestIDKey == "" {
+		requestIDKey = "requestid"
+	}
+
+	return func(c *fiberv2.Ctx, err error) error {
+		if handled, ok := c.Locals(handledKey).(bool); ok && handled {
+			return nil
+		}
+
+		ctx := NewFiberContext(c)
+		requestPath := ctx.Method() + " " + ctx.Path()
+		requestID := "unknown"
+		if rid, ok := ctx.GetLocal(requestIDKey).(string); ok {
+			requestID = rid
+		}
+
+		appErr := convertFiberError(err, requestID)
+		goerrorkit.LogAndRespondWith(ctx, appErr, requestPath, cfg.Formatter)
+		c.Locals(handledKey, true)
+		if cfg.OnError != nil {
+			cfg.OnError(c, appErr)
+		}
+		return nil
+	}
+}
+
+// convertFiberError giống goerrorkit.ConvertToAppError nhưng giữ nguyên status code của *fiber.Error
+// (ví dụ fiber.ErrNotFound khi route không tồn tại) thay vì convert thành 500
+func convertFiberError(err error, requestID string) *goerrorkit.AppError {
+	var appErr *goerrorkit.AppError
+	var fiberErr *fiberv2.Error
+	if !errors.As(err, &appErr) && errors.As(err, &fiberErr) {
+		return &goerrorkit.AppError{
+			Type:      goerrorkit.BusinessError,
+			Code:      fiberErr.Code,
+			Message:   fiberErr.Message,
+			Cause:     err,
+			RequestID: requestID,
+			CreatedAt: time.Now(),
+		}
+	}
+	return goerrorkit.ConvertToAppError(err, requestID)
+}
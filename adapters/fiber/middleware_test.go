@@ -0,0 +1,872 @@
+package fiber
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	fiberv2 "github.com/gofiber/fiber/v2"
+	fiberlogger "github.com/gofiber/fiber/v2/middleware/logger"
+	"github.com/techmaster-vietnam/goerrorkit"
+)
+
+// newConfigTestApp tạo app với middleware gắn request ID, ErrorHandlerWithConfig(cfg) và các route test
+func newConfigTestApp(cfg Config, localKey string) *fiberv2.App {
+	app := fiberv2.New()
+	app.Use(func(c *fiberv2.Ctx) error {
+		c.Locals(localKey, "req-7")
+		return c.Next()
+	})
+	app.Use(ErrorHandlerWithConfig(cfg))
+	app.Get("/health", func(c *fiberv2.Ctx) error {
+		return goerrorkit.NewSystemError(fmt.Errorf("db ping failed"))
+	})
+	app.Get("/orders/:id", func(c *fiberv2.Ctx) error {
+		return goerrorkit.NewBusinessError(404, "Order not found")
+	})
+	return app
+}
+
+func doRequest(t *testing.T, app *fiberv2.App, path string) (int, string) {
+	t.Helper()
+	resp, err := app.Test(httptest.NewRequest("GET", path, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestErrorHandlerWithConfigZeroValueIsDefault(t *testing.T) {
+	mem := goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	status, body := doRequest(t, newConfigTestApp(Config{}, "requestid"), "/orders/1")
+
+	if status != 404 {
+		t.Errorf("status = %d, want 404", status)
+	}
+	for _, want := range []string{`"error":"Order not found"`, `"type":"BUSINESS"`, `"request_id":"req-7"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body = %s, want %s", body, want)
+		}
+	}
+	if _, ok := mem.Find("", "Order not found"); !ok {
+		t.Errorf("error not logged: %v", mem.Entries())
+	}
+}
+
+func TestErrorHandlerWithConfigSkip(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{"SkipPaths", Config{SkipPaths: []string{"/health"}}},
+		{"Skip", Config{Skip: func(c *fiberv2.Ctx) bool { return strings.HasPrefix(c.Path(), "/health") }}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mem := goerrorkit.UseMemoryLogger()
+			defer goerrorkit.SetLogger(nil)
+			app := newConfigTestApp(tt.cfg, "requestid")
+
+			// Path bị skip: error đi thẳng tới Fiber's DefaultErrorHandler, không log
+			if status, body := doRequest(t, app, "/health"); status != 500 || strings.Contains(body, `"type"`) {
+				t.Errorf("skipped path got %d %s, want Fiber default 500", status, body)
+			}
+			if entries := mem.Entries(); len(entries) != 0 {
+				t.Errorf("skipped path was logged: %v", entries)
+			}
+
+			// Path khác vẫn được xử lý
+			if status, _ := doRequest(t, app, "/orders/1"); status != 404 {
+				t.Errorf("status = %d, want 404", status)
+			}
+			if _, ok := mem.Find("", "Order not found"); !ok {
+				t.Errorf("non-skipped error not logged: %v", mem.Entries())
+			}
+		})
+	}
+}
+
+func TestErrorHandlerWithConfigOnError(t *testing.T) {
+	mem := goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	var (
+		called     int
+		loggedWhen int
+		got        *goerrorkit.AppError
+	)
+	app := newConfigTestApp(Config{OnError: func(c *fiberv2.Ctx, appErr *goerrorkit.AppError) {
+		called++
+		loggedWhen = len(mem.Entries())
+		got = appErr
+	}}, "requestid")
+
+	doRequest(t, app, "/orders/1")
+
+	if called != 1 || got == nil || got.Code != 404 || got.RequestID != "req-7" {
+		t.Fatalf("OnError called %d times with %+v", called, got)
+	}
+	if loggedWhen != 1 {
+		t.Errorf("OnError ran with %d log entries, want it to run after logging", loggedWhen)
+	}
+}
+
+func TestErrorHandlerWithConfigRequestIDKey(t *testing.T) {
+	goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	_, body := doRequest(t, newConfigTestApp(Config{RequestIDKey: "request_id"}, "request_id"), "/orders/1")
+	if !strings.Contains(body, `"request_id":"req-7"`) {
+		t.Errorf("body = %s, want request_id from custom key", body)
+	}
+
+	// Key mặc định không đọc local "request_id"
+	_, body = doRequest(t, newConfigTestApp(Config{}, "request_id"), "/orders/1")
+	if !strings.Contains(body, `"request_id":"unknown"`) {
+		t.Errorf("body = %s, want unknown request_id with default key", body)
+	}
+}
+
+func TestErrorHandlerWithConfigFormatter(t *testing.T) {
+	goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	app := newConfigTestApp(Config{Formatter: func(appErr *goerrorkit.AppError) interface{} {
+		return map[string]interface{}{"ok": false, "message": appErr.Message, "rid": appErr.RequestID}
+	}}, "requestid")
+
+	status, body := doRequest(t, app, "/orders/1")
+	if status != 404 {
+		t.Errorf("status = %d, want 404 (Formatter only changes the body)", status)
+	}
+	if body != `{"message":"Order not found","ok":false,"rid":"req-7"}` {
+		t.Errorf("body = %s", body)
+	}
+}
+
+// newPassThroughApp tạo app theo setup khuyến nghị: AppErrorHandler + logger middleware + PassThroughErrors
+func newPassThroughApp(logOutput io.Writer) *fiberv2.App {
+	cfg := Config{PassThroughErrors: true}
+	app := fiberv2.New(fiberv2.Config{ErrorHandler: AppErrorHandlerWithConfig(cfg)})
+	app.Use(fiberlogger.New(fiberlogger.Config{Format: "${status} ${path}\n", Output: logOutput}))
+	app.Use(ErrorHandlerWithConfig(cfg))
+	app.Get("/orders/:id", func(c *fiberv2.Ctx) error {
+		return goerrorkit.NewBusinessError(404, "Order not found")
+	})
+	app.Get("/crash", func(c *fiberv2.Ctx) error {
+		panic("boom")
+	})
+	return app
+}
+
+func TestPassThroughErrorsLoggerSeesStatus(t *testing.T) {
+	mem := goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	var accessLog strings.Builder
+	app := newPassThroughApp(&accessLog)
+
+	status, body := doRequest(t, app, "/orders/1")
+	if status != 404 {
+		t.Errorf("status = %d, want 404", status)
+	}
+	if !strings.Contains(accessLog.String(), "404 /orders/1") {
+		t.Errorf("access log = %q, want 404 for BusinessError", accessLog.String())
+	}
+
+	// Response chỉ được ghi một lần (AppErrorHandler bỏ qua error đã xử lý)
+	if strings.Count(body, `"error"`) != 1 || !strings.HasPrefix(body, "{") || !strings.HasSuffix(body, "}") {
+		t.Errorf("body = %s, want a single JSON object", body)
+	}
+	if got := len(findMemoryEntries(mem, "Order not found")); got != 1 {
+		t.Errorf("error logged %d times, want 1", got)
+	}
+}
+
+func TestPassThroughErrorsPanicStatus(t *testing.T) {
+	mem := goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	var accessLog strings.Builder
+	status, body := doRequest(t, newPassThroughApp(&accessLog), "/crash")
+
+	if status != 500 || !strings.Contains(accessLog.String(), "500 /crash") {
+		t.Errorf("status = %d, access log = %q, want 500", status, accessLog.String())
+	}
+	if strings.Count(body, `"error"`) != 1 {
+		t.Errorf("body = %s, want a single JSON object", body)
+	}
+	if got := len(findMemoryEntries(mem, "Panic recovered")); got != 1 {
+		t.Errorf("panic logged %d times, want 1", got)
+	}
+}
+
+func TestAppErrorHandlerAloneHandlesRouterErrors(t *testing.T) {
+	goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	var accessLog strings.Builder
+	status, body := doRequest(t, newPassThroughApp(&accessLog), "/missing")
+
+	if status != 404 || !strings.Contains(accessLog.String(), "404 /missing") {
+		t.Errorf("status = %d, access log = %q, want 404", status, accessLog.String())
+	}
+	if !strings.Contains(body, `"error":"Cannot GET /missing"`) {
+		t.Errorf("body = %s", body)
+	}
+}
+
+// findMemoryEntries trả về các entry có message chứa substr
+func findMemoryEntries(mem *goerrorkit.MemoryLogger, substr string) []goerrorkit.LogEntry {
+	var found []goerrorkit.LogEntry
+	for _, e := range mem.Entries() {
+		if strings.Contains(e.Message, substr) {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+// newRecoverTestApp tạo app có outer middleware (như profiling layer) recover panic thoát khỏi ErrorHandler
+func newRecoverTestApp(cfg Config, propagated *interface{}) *fiberv2.App {
+	app := fiberv2.New()
+	app.Use(func(c *fiberv2.Ctx) error {
+		defer func() {
+			if r := recover(); r != nil {
+				*propagated = r
+				_ = c.Status(fiberv2.StatusTeapot).SendString("outer")
+			}
+		}()
+		return c.Next()
+	})
+	app.Use(ErrorHandlerWithConfig(cfg))
+	app.Get("/debug/crash", func(c *fiberv2.Ctx) error { panic("debug crash") })
+	app.Get("/debug/fail", func(c *fiberv2.Ctx) error {
+		return goerrorkit.NewBusinessError(404, "Profile not found")
+	})
+	app.Get("/orders/crash", func(c *fiberv2.Ctx) error { panic("order crash") })
+	return app
+}
+
+func TestErrorHandlerDisableRecover(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{"DisableRecoverPaths", Config{DisableRecoverPaths: []string{"/debug/crash", "/debug/fail"}}},
+		{"DisableRecover", Config{DisableRecover: func(c *fiberv2.Ctx) bool { return strings.HasPrefix(c.Path(), "/debug/") }}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mem := goerrorkit.UseMemoryLogger()
+			defer goerrorkit.SetLogger(nil)
+
+			// Route tắt recovery: panic propagate lên outer middleware, không bị log
+			var propagated interface{}
+			app := newRecoverTestApp(tt.cfg, &propagated)
+			status, body := doRequest(t, app, "/debug/crash")
+			if propagated != "debug crash" || status != fiberv2.StatusTeapot || body != "outer" {
+				t.Errorf("propagated = %v, status = %d, body = %q, want panic to reach outer layer", propagated, status, body)
+			}
+			if got := findMemoryEntries(mem, "Panic recovered"); len(got) != 0 {
+				t.Errorf("propagated panic was logged: %v", got)
+			}
+
+			// Error được return trên route đó vẫn được xử lý
+			status, body = doRequest(t, app, "/debug/fail")
+			if status != 404 || !strings.Contains(body, `"error":"Profile not found"`) {
+				t.Errorf("returned error: status = %d, body = %s", status, body)
+			}
+
+			// Route khác vẫn được recover
+			propagated = nil
+			status, _ = doRequest(t, app, "/orders/crash")
+			if propagated != nil || status != 500 {
+				t.Errorf("other route: propagated = %v, status = %d, want recovered 500", propagated, status)
+			}
+			if got := findMemoryEntries(mem, "Panic recovered: order crash"); len(got) != 1 {
+				t.Errorf("recovered panic logged %d times, want 1", len(got))
+			}
+		})
+	}
+}
+
+func TestErrorHandlerAuthErrorHeaders(t *testing.T) {
+	goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	app := fiberv2.New()
+	app.Use(ErrorHandler())
+	app.Get("/me", func(c *fiberv2.Ctx) error {
+		return goerrorkit.NewUnauthenticatedError("Missing authorization token")
+	})
+	app.Get("/admin", func(c *fiberv2.Ctx) error {
+		return goerrorkit.NewForbiddenError("Admin permission required")
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/me", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != 401 || resp.Header.Get("WWW-Authenticate") != "Bearer" || !strings.Contains(string(body), `"auth_action":"login"`) {
+		t.Errorf("/me: status = %d, WWW-Authenticate = %q, body = %s", resp.StatusCode, resp.Header.Get("WWW-Authenticate"), body)
+	}
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/admin", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ = io.ReadAll(resp.Body)
+	if resp.StatusCode != 403 || resp.Header.Get("WWW-Authenticate") != "" || !strings.Contains(string(body), `"auth_action":"contact_admin"`) {
+		t.Errorf("/admin: status = %d, WWW-Authenticate = %q, body = %s", resp.StatusCode, resp.Header.Get("WWW-Authenticate"), body)
+	}
+}
+
+func TestErrorHandlerExcludedPaths(t *testing.T) {
+	mem := goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+	goerrorkit.SetExcludedPaths("/healthz")
+	defer goerrorkit.SetExcludedPaths()
+
+	app := fiberv2.New()
+	app.Use(ErrorHandler())
+	app.Get("/healthz", func(c *fiberv2.Ctx) error {
+		return goerrorkit.NewSystemError(fmt.Errorf("db ping failed"))
+	})
+
+	status, body := doRequest(t, app, "/healthz")
+	if status != 500 || !strings.Contains(body, `"error"`) {
+		t.Errorf("status = %d, body = %s, want error response", status, body)
+	}
+	if entries := mem.Entries(); len(entries) != 0 {
+		t.Errorf("/healthz error logged: %v", entries)
+	}
+}
+
+func TestErrorHandlerUsesRegisteredResponseWriter(t *testing.T) {
+	goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+	defer goerrorkit.SetResponseWriter(goerrorkit.ResponseWriterJSON)
+
+	goerrorkit.RegisterResponseWriter("test-envelope", goerrorkit.ResponseWriterFunc(
+		func(ctx goerrorkit.HTTPContext, appErr *goerrorkit.AppError) error {
+			return ctx.Status(appErr.Code).JSON(map[string]interface{}{
+				"success": false,
+				"error":   goerrorkit.FormatErrorResponse(appErr),
+			})
+		}))
+
+	app := fiberv2.New()
+	app.Use(ErrorHandler())
+	app.Get("/orders/:id", func(c *fiberv2.Ctx) error {
+		return goerrorkit.NewBusinessError(404, "Order not found")
+	})
+
+	tests := []struct {
+		writer      string
+		contentType string
+		field       string
+	}{
+		{goerrorkit.ResponseWriterJSON, "application/json", `"error":"Order not found"`},
+		{goerrorkit.ResponseWriterProblem, "application/problem+json", `"detail":"Order not found"`},
+		{"test-envelope", "application/json", `"success":false`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.writer, func(t *testing.T) {
+			if err := goerrorkit.SetResponseWriter(tt.writer); err != nil {
+				t.Fatal(err)
+			}
+			resp, err := app.Test(httptest.NewRequest("GET", "/orders/42", nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != 404 || !strings.Contains(string(body), tt.field) {
+				t.Errorf("status = %d, body = %s, want 404 with %s", resp.StatusCode, body, tt.field)
+			}
+			if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, tt.contentType) {
+				t.Errorf("Content-Type = %q, want %s", ct, tt.contentType)
+			}
+		})
+	}
+
+	if err := goerrorkit.SetResponseWriter("missing"); err == nil {
+		t.Error("SetResponseWriter(missing) = nil, want error")
+	}
+}
+
+// cancelUserContext giả lập middleware cancel user context khi client đã hủy request
+func cancelUserContext(c *fiberv2.Ctx) error {
+	ctx, cancel := context.WithCancel(c.UserContext())
+	cancel()
+	c.SetUserContext(ctx)
+	return c.Next()
+}
+
+func TestErrorHandlerClientDisconnect(t *testing.T) {
+	mem := goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	app := fiberv2.New()
+	app.Use(ErrorHandler())
+	app.Use(cancelUserContext)
+	app.Get("/slow", func(c *fiberv2.Ctx) error {
+		return c.UserContext().Err()
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/slow", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != goerrorkit.StatusClientClosedRequest {
+		t.Errorf("status = %d, want 499", resp.StatusCode)
+	}
+	if entries := mem.Entries(); len(entries) != 0 {
+		t.Errorf("client disconnect logged by default: %+v", entries)
+	}
+}
+
+func TestErrorHandlerLogClientDisconnects(t *testing.T) {
+	mem := goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	app := fiberv2.New()
+	app.Use(ErrorHandlerWithConfig(Config{LogClientDisconnects: true}))
+	app.Use(cancelUserContext)
+	app.Get("/slow", func(c *fiberv2.Ctx) error {
+		return fmt.Errorf("stream: %w", context.Canceled)
+	})
+
+	if _, err := app.Test(httptest.NewRequest("GET", "/slow", nil)); err != nil {
+		t.Fatal(err)
+	}
+	entries := mem.Entries()
+	if len(entries) != 1 || entries[0].Level != "info" {
+		t.Errorf("entries = %+v, want one info entry", entries)
+	}
+}
+
+func TestErrorHandlerUpstreamResetIsServerError(t *testing.T) {
+	mem := goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	app := fiberv2.New()
+	app.Use(ErrorHandler())
+	app.Get("/orders", func(c *fiberv2.Ctx) error {
+		// Database reset connection trong khi client vẫn chờ response
+		return &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/orders", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != 500 {
+		t.Errorf("status = %d, want 500", resp.StatusCode)
+	}
+	if entries := mem.Entries(); len(entries) != 1 || entries[0].Level != "error" {
+		t.Errorf("entries = %+v, want one error entry", entries)
+	}
+}
+
+func TestErrorHandlerResponseEncoder(t *testing.T) {
+	goerrorkit.SetResponseEncoder(func(appErr *goerrorkit.AppError, ctx goerrorkit.HTTPContext) (int, interface{}) {
+		status, body := goerrorkit.DefaultResponseEncoder(appErr, ctx)
+		return status, map[string]interface{}{"success": false, "error": body}
+	})
+	defer goerrorkit.SetResponseEncoder(nil)
+
+	app := fiberv2.New()
+	app.Use(ErrorHandler())
+	app.Get("/orders/:id", func(c *fiberv2.Ctx) error {
+		return goerrorkit.NewBusinessError(404, "Order not found")
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/orders/1", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != 404 || resp.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("got %d Content-Type=%q, want 404 application/json", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	if !strings.HasPrefix(string(body), `{"error":{"error":"Order not found"`) || !strings.Contains(string(body), `"success":false`) {
+		t.Errorf("body = %s, want envelope from the encoder", body)
+	}
+}
+
+func TestAppErrorHandlerWithConfigRequestIDKey(t *testing.T) {
+	goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	cfg := Config{RequestIDKey: "request_id", PassThroughErrors: true}
+	app := fiberv2.New(fiberv2.Config{ErrorHandler: AppErrorHandlerWithConfig(cfg)})
+	app.Use(func(c *fiberv2.Ctx) error {
+		c.Locals("request_id", "req-7")
+		return c.Next()
+	})
+	app.Use(ErrorHandlerWithConfig(cfg))
+
+	// Route không tồn tại: error do router trả về, chỉ AppErrorHandler xử lý
+	resp, err := app.Test(httptest.NewRequest("GET", "/missing", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != 404 || !strings.Contains(string(body), `"request_id":"req-7"`) {
+		t.Errorf("got %d %s, want 404 with request_id req-7", resp.StatusCode, body)
+	}
+}
+
+func TestErrorHandlerKeepsErrorRequestID(t *testing.T) {
+	goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	app := fiberv2.New()
+	app.Use(func(c *fiberv2.Ctx) error {
+		c.Locals("requestid", "req-7")
+		return c.Next()
+	})
+	app.Use(ErrorHandler())
+	app.Get("/jobs", func(c *fiberv2.Ctx) error {
+		return goerrorkit.NewBusinessError(409, "Job running").WithRequestID("job-42")
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/jobs", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), `"request_id":"job-42"`) {
+		t.Errorf("body = %s, want request_id job-42", body)
+	}
+}
+
+func TestErrorHandlerPanickedAppErrorKeepsStatus(t *testing.T) {
+	mem := goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	app := fiberv2.New()
+	app.Use(ErrorHandler())
+	app.Post("/orders/:id/pay", func(c *fiberv2.Ctx) error {
+		panic(goerrorkit.NewBusinessError(409, "Order already paid"))
+	})
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/orders/1/pay", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != 409 || !strings.Contains(string(body), `"type":"BUSINESS"`) {
+		t.Errorf("got %d %s, want 409 BusinessError", resp.StatusCode, body)
+	}
+	if got := findMemoryEntries(mem, "Order already paid"); len(got) != 1 || got[0].Fields["panic"] != true {
+		t.Errorf("entries = %+v, want panicked AppError logged once", mem.Entries())
+	}
+}
+
+// flushRecordingLogger là MemoryLogger ghi lại số entry đã log tại thời điểm Flush được gọi
+type flushRecordingLogger struct {
+	*goerrorkit.MemoryLogger
+	flushedEntries int // -1: chưa flush
+}
+
+func (l *flushRecordingLogger) Flush(ctx context.Context) error {
+	l.flushedEntries = len(l.Entries())
+	return nil
+}
+
+func TestErrorHandlerRePanic(t *testing.T) {
+	logger := &flushRecordingLogger{MemoryLogger: &goerrorkit.MemoryLogger{}, flushedEntries: -1}
+	goerrorkit.SetLogger(logger)
+	defer goerrorkit.SetLogger(nil)
+
+	var propagated interface{}
+	app := newRecoverTestApp(Config{RePanic: func(r interface{}) bool { return r == "debug crash" }}, &propagated)
+
+	// Panic fatal: được log, flush, rồi propagate lên outer middleware với giá trị gốc
+	status, _ := doRequest(t, app, "/debug/crash")
+	if propagated != "debug crash" || status != fiberv2.StatusTeapot {
+		t.Errorf("propagated = %v, status = %d, want panic re-raised", propagated, status)
+	}
+	if got := findMemoryEntries(logger.MemoryLogger, "Panic recovered: debug crash"); len(got) != 1 {
+		t.Errorf("fatal panic logged %d times, want 1", len(got))
+	}
+	if logger.flushedEntries != 1 {
+		t.Errorf("flushed with %d entries, want log entry written before re-panic", logger.flushedEntries)
+	}
+
+	// Panic khác vẫn được recover như mặc định
+	propagated = nil
+	if status, _ := doRequest(t, app, "/orders/crash"); propagated != nil || status != 500 {
+		t.Errorf("propagated = %v, status = %d, want recovered 500", propagated, status)
+	}
+}
+
+func TestErrorHandlerUpgradeRequestBeforeUpgrade(t *testing.T) {
+	goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	app := fiberv2.New()
+	app.Use(ErrorHandler())
+	app.Get("/ws", func(c *fiberv2.Ctx) error {
+		return goerrorkit.NewAuthError(401, "Unauthorized")
+	})
+
+	req := httptest.NewRequest("GET", "/ws", nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != 401 || !strings.Contains(string(body), "Unauthorized") {
+		t.Errorf("got %d %s, want 401 JSON error", resp.StatusCode, body)
+	}
+}
+
+func TestErrorHandlerUpgradedConnection(t *testing.T) {
+	mem := goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	app := fiberv2.New()
+	app.Use(ErrorHandler())
+	app.Get("/ws", func(c *fiberv2.Ctx) error {
+		c.Locals(goerrorkit.UpgradedLocal, true)
+		return goerrorkit.NewBusinessError(400, "Bad frame")
+	})
+
+	req := httptest.NewRequest("GET", "/ws", nil)
+	req.Header.Set("Connection", "Upgrade")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if len(body) != 0 || resp.StatusCode == 400 {
+		t.Errorf("wrote to upgraded connection: %d %s", resp.StatusCode, body)
+	}
+	if len(mem.Entries()) != 1 {
+		t.Errorf("entries = %+v, want error still logged", mem.Entries())
+	}
+}
+
+func TestFiberContextUpgraded(t *testing.T) {
+	app := fiberv2.New()
+	app.Get("/ws", func(c *fiberv2.Ctx) error {
+		ctx := NewFiberContext(c)
+		if ctx.Upgraded() {
+			t.Error("Upgraded() = true before switching protocols")
+		}
+		c.Status(fiberv2.StatusSwitchingProtocols)
+		if !ctx.Upgraded() {
+			t.Error("Upgraded() = false after 101")
+		}
+		return nil
+	})
+	if _, err := app.Test(httptest.NewRequest("GET", "/ws", nil)); err != nil {
+		t.Fatal(err)
+	}
+}
+func TestFiberContextQueryAndClientIP(t *testing.T) {
+	app := fiberv2.New()
+	app.Get("/orders", func(c *fiberv2.Ctx) error {
+		ctx := NewFiberContext(c)
+		if got := ctx.Query("page"); got != "2" {
+			t.Errorf("Query(page) = %q, want 2", got)
+		}
+		if got := ctx.Query("missing"); got != "" {
+			t.Errorf("Query(missing) = %q, want empty", got)
+		}
+		if got := ctx.ClientIP(); got != c.IP() || got == "" {
+			t.Errorf("ClientIP = %q, want %q", got, c.IP())
+		}
+		return nil
+	})
+	if _, err := app.Test(httptest.NewRequest("GET", "/orders?page=2", nil)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestErrorHandlerIncludeClientInfo(t *testing.T) {
+	mem := goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+	goerrorkit.SetIncludeClientInfo(true)
+	defer goerrorkit.SetIncludeClientInfo(false)
+
+	app := fiberv2.New(fiberv2.Config{ProxyHeader: fiberv2.HeaderXForwardedFor})
+	app.Use(ErrorHandler())
+	app.Get("/orders/:id", func(c *fiberv2.Ctx) error {
+		return goerrorkit.NewBusinessError(404, "Order not found")
+	})
+
+	req := httptest.NewRequest("GET", "/orders/1", nil)
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	req.Header.Set("User-Agent", "curl/8.0")
+	if _, err := app.Test(req); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, ok := mem.Find("", "Order not found")
+	if !ok || entry.Fields["client_ip"] != "203.0.113.7" || entry.Fields["user_agent"] != "curl/8.0" {
+		t.Errorf("entries = %+v, want client_ip from proxy header and user_agent", mem.Entries())
+	}
+}
+
+func TestErrorHandlerLogsRoutePattern(t *testing.T) {
+	mem := goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	app := fiberv2.New()
+	app.Use(ErrorHandlerWithConfig(Config{LogHeaders: []string{"X-Tenant", "Authorization"}}))
+	app.Get("/users/:id", func(c *fiberv2.Ctx) error {
+		return goerrorkit.NewBusinessError(404, "User not found")
+	})
+
+	req := httptest.NewRequest("GET", "/users/42", nil)
+	req.Header.Set("X-Tenant", "acme")
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Cookie", "session=abc")
+	if _, err := app.Test(req); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, ok := mem.Find("", "User not found")
+	if !ok {
+		t.Fatalf("error not logged: %+v", mem.Entries())
+	}
+	if entry.Fields["path"] != "GET /users/:id" || entry.Fields["raw_path"] != "/users/42" {
+		t.Errorf("path = %v, raw_path = %v, want route pattern and raw path", entry.Fields["path"], entry.Fields["raw_path"])
+	}
+	if entry.Fields["status"] != 404 {
+		t.Errorf("status = %v, want 404", entry.Fields["status"])
+	}
+	if latency, ok := entry.Fields["latency_ms"].(int64); !ok || latency < 0 {
+		t.Errorf("latency_ms = %#v, want non-negative int64", entry.Fields["latency_ms"])
+	}
+
+	// Chỉ header trong allowlist được log, giá trị nhạy cảm bị che
+	headers, _ := entry.Fields["headers"].(map[string]interface{})
+	if headers == nil {
+		t.Fatalf("headers = %#v, want map", entry.Fields["headers"])
+	}
+	if headers["X-Tenant"] != "acme" || headers["Authorization"] == "Bearer secret" || headers["Cookie"] != nil {
+		t.Errorf("headers = %v, want allowlisted headers with Authorization redacted", headers)
+	}
+}
+
+func TestErrorHandlerUnmatchedRouteUsesRawPath(t *testing.T) {
+	mem := goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	app := fiberv2.New()
+	app.Use(ErrorHandler())
+	app.Get("/users/:id", func(c *fiberv2.Ctx) error { return nil })
+
+	// Không route nào match: Fiber trả về 404 từ c.Next()
+	if status, _ := doRequest(t, app, "/does/not/exist"); status != 404 {
+		t.Errorf("status = %d, want 404", status)
+	}
+	entry, ok := mem.Find("", "Cannot GET")
+	if !ok || entry.Fields["path"] != "GET /does/not/exist" || entry.Fields["raw_path"] != "/does/not/exist" {
+		t.Errorf("entries = %+v, want raw path when no route matched", mem.Entries())
+	}
+}
+
+// checkoutHandler trả về AppError đầy đủ fluent option, bị wrap thêm một lớp
+func checkoutHandler(c *fiberv2.Ctx) error {
+	appErr := goerrorkit.NewBusinessError(409, "Order already paid").
+		WithData(map[string]interface{}{"order_id": 42, "amount": 199000}).
+		Level("info").
+		WithCallChain()
+	return fmt.Errorf("checkout: %w", appErr)
+}
+
+func TestErrorHandlerKeepsAppErrorOptions(t *testing.T) {
+	mem := goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	app := fiberv2.New()
+	app.Use(ErrorHandler())
+	app.Post("/orders/:id/checkout", checkoutHandler)
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/orders/42/checkout", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != 409 {
+		t.Errorf("status = %d, want 409", resp.StatusCode)
+	}
+
+	entry, ok := mem.Find("", "Order already paid")
+	if !ok {
+		t.Fatalf("error not logged: %+v", mem.Entries())
+	}
+	if entry.Level != "info" {
+		t.Errorf("level = %s, want info from .Level()", entry.Level)
+	}
+	data, _ := entry.Fields["data"].(map[string]interface{})
+	if data["order_id"] != 42 || data["amount"] != 199000 {
+		t.Errorf("data = %#v, want WithData values", entry.Fields["data"])
+	}
+	chain := fmt.Sprint(entry.Fields["call_chain"])
+	if !strings.Contains(chain, "fiber.checkoutHandler") {
+		t.Errorf("call_chain = %s, want the handler frame from WithCallChain", chain)
+	}
+}
+
+func TestErrorHandlerHTMLForBrowsers(t *testing.T) {
+	goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	app := fiberv2.New()
+	app.Use(ErrorHandler())
+	app.Get("/orders/:id", func(c *fiberv2.Ctx) error {
+		return goerrorkit.NewBusinessError(404, "Order <b>42</b> not found")
+	})
+
+	req := httptest.NewRequest("GET", "/orders/42", nil)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,*/*;q=0.8")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != 404 || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+		t.Errorf("got %d %q, want 404 HTML", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	if !strings.Contains(string(body), "Order &lt;b&gt;42&lt;/b&gt; not found") {
+		t.Errorf("body = %s, want escaped message", body)
+	}
+}
+
+func TestErrorHandlerRetryAfterHeader(t *testing.T) {
+	goerrorkit.UseMemoryLogger()
+	defer goerrorkit.SetLogger(nil)
+
+	app := fiberv2.New()
+	app.Use(ErrorHandler())
+	app.Get("/api/orders", func(c *fiberv2.Ctx) error {
+		return goerrorkit.NewTooManyRequestsError("Too many requests", 1500*time.Millisecond).
+			WithHeader("Cache-Control", "no-store")
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/orders", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != 429 || resp.Header.Get("Retry-After") != "2" || resp.Header.Get("Cache-Control") != "no-store" {
+		t.Errorf("got %d Retry-After=%q Cache-Control=%q", resp.StatusCode, resp.Header.Get("Retry-After"), resp.Header.Get("Cache-Control"))
+	}
+}
@@ -0,0 +1,21 @@
+module github.com/techmaster-vietnam/goerrorkit/adapters/otellog
+
+go 1.22
+
+require (
+	github.com/techmaster-vietnam/goerrorkit v0.0.0
+	go.opentelemetry.io/otel/log v0.6.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/sirupsen/logrus v1.9.3 // indirect
+	go.opentelemetry.io/otel v1.30.0 // indirect
+	go.opentelemetry.io/otel/metric v1.30.0 // indirect
+	go.opentelemetry.io/otel/trace v1.30.0 // indirect
+	golang.org/x/sys v0.28.0 // indirect
+	gopkg.in/natefinch/lumberjack.v2 v2.2.1 // indirect
+)
+
+replace github.com/techmaster-vietnam/goerrorkit => ../..
@@ -0,0 +1,236 @@
+// Package otellog gửi log của goerrorkit thành OpenTelemetry LogRecord
+//
+//	import goerrorkitotellog "github.com/techmaster-vietnam/goerrorkit/adapters/otellog"
+//
+//	goerrorkitotellog.InitOtelLogger(provider)
+package otellog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/techmaster-vietnam/goerrorkit"
+	otlog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+)
+
+// instrumentationName là tên instrumentation scope của các LogRecord do adapter emit
+const instrumentationName = "github.com/techmaster-vietnam/goerrorkit"
+
+// Logger implement goerrorkit.Logger và goerrorkit.MonitorLogger:
+// mỗi entry được emit thành một OTel LogRecord (body là message, fields là attributes)
+type Logger struct {
+	logger otlog.Logger
+}
+
+// NewLogger tạo Logger từ OTel LoggerProvider (nil → global.GetLoggerProvider())
+//
+// Example:
+//
+//	logger := goerrorkitotellog.NewLogger(provider)
+//	goerrorkit.SetLogger(logger)
+func NewLogger(provider otlog.LoggerProvider) *Logger {
+	if provider == nil {
+		provider = global.GetLoggerProvider()
+	}
+	return &Logger{logger: provider.Logger(instrumentationName)}
+}
+
+// InitOtelLogger tạo Logger từ provider và set làm logger của goerrorkit (thay cho InitLogger)
+// Output của goerrorkit đi qua OTel collector pipeline cùng với traces/metrics
+//
+// Example:
+//
+//	exporter, _ := otlploghttp.New(ctx)
+//	provider := sdklog.NewLoggerProvider(
+//	    sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)),
+//	)
+//	defer provider.Shutdown(ctx)
+//
+//	goerrorkitotellog.InitOtelLogger(provider)
+func InitOtelLogger(provider otlog.LoggerProvider) *Logger {
+	logger := NewLogger(provider)
+	goerrorkit.SetLogger(logger)
+	return logger
+}
+
+// Error implements goerrorkit.Logger
+func (l *Logger) Error(msg string, fields map[string]interface{}) {
+	l.emit(otlog.SeverityError, "error", msg, fields)
+}
+
+// Info implements goerrorkit.Logger
+func (l *Logger) Info(msg string, fields map[string]interface{}) {
+	l.emit(otlog.SeverityInfo, "info", msg, fields)
+}
+
+// Debug implements goerrorkit.Logger
+// Khác LogrusLogger, không phụ thuộc build tag debug: lọc severity ở processor/collector
+func (l *Logger) Debug(msg string, fields map[string]interface{}) {
+	l.emit(otlog.SeverityDebug, "debug", msg, fields)
+}
+
+// Trace implements goerrorkit.Logger
+func (l *Logger) Trace(msg string, fields map[string]interface{}) {
+	l.emit(otlog.SeverityTrace, "trace", msg, fields)
+}
+
+// Warn implements goerrorkit.Logger
+func (l *Logger) Warn(msg string, fields map[string]interface{}) {
+	l.emit(otlog.SeverityWarn, "warn", msg, fields)
+}
+
+// Panic implements goerrorkit.Logger
+// Panic đã được recover nên không có gì bị dừng, nhưng vẫn là mức nghiêm trọng nhất → SeverityFatal
+func (l *Logger) Panic(msg string, fields map[string]interface{}) {
+	l.emit(otlog.SeverityFatal, "panic", msg, fields)
+}
+
+// Monitor implements goerrorkit.MonitorLogger (entry MonitorOnly: ghi với level bất kỳ)
+func (l *Logger) Monitor(level string, msg string, fields map[string]interface{}) {
+	name, err := goerrorkit.ParseLevel(level)
+	if err != nil {
+		name = "error"
+	}
+	l.emit(severityOf(name), name, msg, fields)
+}
+
+// severityOf map level của goerrorkit sang OTel Severity
+func severityOf(level string) otlog.Severity {
+	switch level {
+	case "trace":
+		return otlog.SeverityTrace
+	case "debug":
+		return otlog.SeverityDebug
+	case "info":
+		return otlog.SeverityInfo
+	case "warn":
+		return otlog.SeverityWarn
+	case "panic":
+		return otlog.SeverityFatal
+	default:
+		return otlog.SeverityError
+	}
+}
+
+// emit tạo LogRecord và emit qua OTel Logger
+// Logger của goerrorkit không nhận context nên record không tự gắn span; trace_id/span_id
+// (nếu AppError có) nằm trong attributes
+func (l *Logger) emit(severity otlog.Severity, severityText, msg string, fields map[string]interface{}) {
+	now := time.Now()
+
+	var record otlog.Record
+	record.SetTimestamp(now)
+	record.SetObservedTimestamp(now)
+	record.SetSeverity(severity)
+	record.SetSeverityText(severityText)
+	record.SetBody(otlog.StringValue(msg))
+	record.AddAttributes(attributes(fields)...)
+
+	l.logger.Emit(context.Background(), record)
+}
+
+// attributes convert fields sang OTel attributes (sort theo key để thứ tự ổn định)
+func attributes(fields map[string]interface{}) []otlog.KeyValue {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]otlog.KeyValue, 0, len(keys))
+	for _, k := range keys {
+		attrs = append(attrs, otlog.KeyValue{Key: k, Value: toValue(fields[k])})
+	}
+	return attrs
+}
+
+// toValue convert giá trị field sang otlog.Value
+// Map và slice được giữ cấu trúc (data.product_id query được ở backend), kiểu lạ encode JSON
+func toValue(v interface{}) otlog.Value {
+	switch val := v.(type) {
+	case nil:
+		return otlog.Value{}
+	case string:
+		return otlog.StringValue(val)
+	case bool:
+		return otlog.BoolValue(val)
+	case int:
+		return otlog.IntValue(val)
+	case int8:
+		return otlog.Int64Value(int64(val))
+	case int16:
+		return otlog.Int64Value(int64(val))
+	case int32:
+		return otlog.Int64Value(int64(val))
+	case int64:
+		return otlog.Int64Value(val)
+	case uint8:
+		return otlog.Int64Value(int64(val))
+	case uint16:
+		return otlog.Int64Value(int64(val))
+	case uint32:
+		return otlog.Int64Value(int64(val))
+	case uint:
+		return uintValue(uint64(val))
+	case uint64:
+		return uintValue(val)
+	case float32:
+		return otlog.Float64Value(float64(val))
+	case float64:
+		return otlog.Float64Value(val)
+	case []byte:
+		return otlog.BytesValue(val)
+	case time.Time:
+		return otlog.StringValue(val.Format(time.RFC3339Nano))
+	case time.Duration:
+		return otlog.StringValue(val.String())
+	case error:
+		return otlog.StringValue(val.Error())
+	case fmt.Stringer:
+		return otlog.StringValue(val.String())
+	case []string:
+		values := make([]otlog.Value, len(val))
+		for i, s := range val {
+			values[i] = otlog.StringValue(s)
+		}
+		return otlog.SliceValue(values...)
+	case []interface{}:
+		values := make([]otlog.Value, len(val))
+		for i, item := range val {
+			values[i] = toValue(item)
+		}
+		return otlog.SliceValue(values...)
+	case []map[string]interface{}:
+		values := make([]otlog.Value, len(val))
+		for i, item := range val {
+			values[i] = otlog.MapValue(attributes(item)...)
+		}
+		return otlog.SliceValue(values...)
+	case map[string]interface{}:
+		return otlog.MapValue(attributes(val)...)
+	case map[string]string:
+		fields := make(map[string]interface{}, len(val))
+		for k, s := range val {
+			fields[k] = s
+		}
+		return otlog.MapValue(attributes(fields)...)
+	default:
+		if encoded, err := json.Marshal(val); err == nil {
+			return otlog.StringValue(string(encoded))
+		}
+		return otlog.StringValue(fmt.Sprint(val))
+	}
+}
+
+// uintValue giữ số nguyên khi vừa int64, ngược lại ghi dạng string để không bị tràn
+func uintValue(v uint64) otlog.Value {
+	if v > math.MaxInt64 {
+		return otlog.StringValue(fmt.Sprint(v))
+	}
+	return otlog.Int64Value(int64(v))
+}
@@ -0,0 +1,179 @@
+package otellog
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/techmaster-vietnam/goerrorkit"
+	otlog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/embedded"
+)
+
+// memoryProvider là LoggerProvider in-memory, ghi lại mọi record được emit
+type memoryProvider struct {
+	embedded.LoggerProvider
+
+	mu      sync.Mutex
+	scopes  []string
+	records []otlog.Record
+}
+
+func (p *memoryProvider) Logger(name string, _ ...otlog.LoggerOption) otlog.Logger {
+	p.mu.Lock()
+	p.scopes = append(p.scopes, name)
+	p.mu.Unlock()
+	return &memoryLogger{provider: p}
+}
+
+type memoryLogger struct {
+	embedded.Logger
+	provider *memoryProvider
+}
+
+func (l *memoryLogger) Emit(_ context.Context, record otlog.Record) {
+	l.provider.mu.Lock()
+	l.provider.records = append(l.provider.records, record)
+	l.provider.mu.Unlock()
+}
+
+func (l *memoryLogger) Enabled(context.Context, otlog.Record) bool { return true }
+
+// attrs trả về attributes của record theo key
+func attrs(r otlog.Record) map[string]otlog.Value {
+	out := make(map[string]otlog.Value, r.AttributesLen())
+	r.WalkAttributes(func(kv otlog.KeyValue) bool {
+		out[kv.Key] = kv.Value
+		return true
+	})
+	return out
+}
+
+func TestSeverityMapping(t *testing.T) {
+	provider := &memoryProvider{}
+	logger := NewLogger(provider)
+
+	logger.Trace("t", nil)
+	logger.Debug("d", nil)
+	logger.Info("i", nil)
+	logger.Warn("w", nil)
+	logger.Error("e", nil)
+	logger.Panic("p", nil)
+	logger.Monitor("WARNING", "m", nil)
+	logger.Monitor("bogus", "b", nil)
+
+	want := []struct {
+		severity otlog.Severity
+		text     string
+		body     string
+	}{
+		{otlog.SeverityTrace, "trace", "t"},
+		{otlog.SeverityDebug, "debug", "d"},
+		{otlog.SeverityInfo, "info", "i"},
+		{otlog.SeverityWarn, "warn", "w"},
+		{otlog.SeverityError, "error", "e"},
+		{otlog.SeverityFatal, "panic", "p"},
+		{otlog.SeverityWarn, "warn", "m"},
+		{otlog.SeverityError, "error", "b"},
+	}
+	if len(provider.records) != len(want) {
+		t.Fatalf("got %d records, want %d", len(provider.records), len(want))
+	}
+	for i, w := range want {
+		r := provider.records[i]
+		if r.Severity() != w.severity || r.SeverityText() != w.text || r.Body().AsString() != w.body {
+			t.Errorf("record %d = (%v, %q, %q), want (%v, %q, %q)",
+				i, r.Severity(), r.SeverityText(), r.Body().AsString(), w.severity, w.text, w.body)
+		}
+		if r.Timestamp().IsZero() {
+			t.Errorf("record %d has zero timestamp", i)
+		}
+	}
+	if len(provider.scopes) != 1 || provider.scopes[0] != instrumentationName {
+		t.Errorf("scopes = %v, want [%s]", provider.scopes, instrumentationName)
+	}
+}
+
+func TestAttributeConversion(t *testing.T) {
+	provider := &memoryProvider{}
+	logger := NewLogger(provider)
+
+	logger.Error("Internal server error", map[string]interface{}{
+		"error_type": "SYSTEM",
+		"pid":        42,
+		"ratio":      0.5,
+		"retry":      true,
+		"big":        uint64(1) << 63,
+		"cause":      errors.New("db down"),
+		"call_chain": []string{"main.a (a.go:1)", "main.b (b.go:2)"},
+		"data": map[string]interface{}{
+			"product_id": 123,
+			"tags":       []interface{}{"x", 1},
+		},
+		"headers": map[string]string{"X-Request-Id": "req-1"},
+		"custom":  struct{ A int }{A: 7},
+	})
+
+	got := attrs(provider.records[0])
+	checks := map[string]func(otlog.Value) bool{
+		"error_type": func(v otlog.Value) bool { return v.Kind() == otlog.KindString && v.AsString() == "SYSTEM" },
+		"pid":        func(v otlog.Value) bool { return v.Kind() == otlog.KindInt64 && v.AsInt64() == 42 },
+		"ratio":      func(v otlog.Value) bool { return v.Kind() == otlog.KindFloat64 && v.AsFloat64() == 0.5 },
+		"retry":      func(v otlog.Value) bool { return v.Kind() == otlog.KindBool && v.AsBool() },
+		"big":        func(v otlog.Value) bool { return v.Kind() == otlog.KindString && v.AsString() == "9223372036854775808" },
+		"cause":      func(v otlog.Value) bool { return v.AsString() == "db down" },
+		"call_chain": func(v otlog.Value) bool {
+			s := v.AsSlice()
+			return v.Kind() == otlog.KindSlice && len(s) == 2 && s[1].AsString() == "main.b (b.go:2)"
+		},
+		"data": func(v otlog.Value) bool {
+			if v.Kind() != otlog.KindMap {
+				return false
+			}
+			m := v.AsMap()
+			return len(m) == 2 && m[0].Key == "product_id" && m[0].Value.AsInt64() == 123 &&
+				m[1].Key == "tags" && len(m[1].Value.AsSlice()) == 2
+		},
+		"headers": func(v otlog.Value) bool {
+			m := v.AsMap()
+			return len(m) == 1 && m[0].Key == "X-Request-Id" && m[0].Value.AsString() == "req-1"
+		},
+		"custom": func(v otlog.Value) bool { return v.AsString() == `{"A":7}` },
+	}
+	for key, check := range checks {
+		v, ok := got[key]
+		if !ok {
+			t.Errorf("missing attribute %q", key)
+			continue
+		}
+		if !check(v) {
+			t.Errorf("attribute %q = %v", key, v)
+		}
+	}
+}
+
+func TestInitOtelLoggerWiresLogError(t *testing.T) {
+	provider := &memoryProvider{}
+	InitOtelLogger(provider)
+	defer goerrorkit.SetLogger(nil)
+
+	appErr := goerrorkit.NewBusinessError(404, "Product not found").
+		WithData(map[string]interface{}{"product_id": 7})
+	goerrorkit.LogError(appErr, "GET /products/7")
+
+	if len(provider.records) != 1 {
+		t.Fatalf("got %d records, want 1", len(provider.records))
+	}
+	r := provider.records[0]
+	if r.Body().AsString() != "Product not found" {
+		t.Errorf("body = %q", r.Body().AsString())
+	}
+	a := attrs(r)
+	if a["error_type"].AsString() != "BUSINESS" || a["path"].AsString() != "GET /products/7" {
+		t.Errorf("attributes = %v", a)
+	}
+	if data := a["data"].AsMap(); len(data) != 1 || data[0].Value.AsInt64() != 7 {
+		t.Errorf("data = %v", a["data"])
+	}
+}
@@ -0,0 +1,140 @@
+package goerrorkit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// defaultAsyncBufferSize là số log entry tối đa trong buffer khi AsyncBufferSize = 0
+const defaultAsyncBufferSize = 1024
+
+// errAsyncWriterClosed được trả về khi ghi vào asyncWriter đã đóng
+var errAsyncWriterClosed = errors.New("goerrorkit: async log writer is closed")
+
+// asyncWriter ghi log qua bounded channel, được drain bởi một background goroutine
+// Khi buffer đầy, entry CŨ NHẤT bị bỏ (drop-oldest) để LogError không bao giờ bị block
+type asyncWriter struct {
+	out io.WriteCloser
+	ch  chan []byte
+
+	mu      sync.Mutex
+	pending int             // Số entry đã nhận nhưng chưa ghi xong (hoặc chưa bị drop)
+	idle    []chan struct{} // Flush đang chờ, được đóng khi pending về 0
+	closed  bool            // Đã gọi Close
+
+	dropped uint64        // Số entry bị drop do buffer đầy
+	done    chan struct{} // Đóng khi background goroutine kết thúc
+}
+
+// newAsyncWriter tạo asyncWriter và khởi động background goroutine
+func newAsyncWriter(out io.WriteCloser, bufferSize int) *asyncWriter {
+	if bufferSize <= 0 {
+		bufferSize = defaultAsyncBufferSize
+	}
+	w := &asyncWriter{
+		out:  out,
+		ch:   make(chan []byte, bufferSize),
+		done: make(chan struct{}),
+	}
+	go w.drain()
+	return w
+}
+
+// Write implements io.Writer - không bao giờ block
+func (w *asyncWriter) Write(p []byte) (int, error) {
+	// logrus tái sử dụng buffer nên phải copy
+	buf := make([]byte, len(p))
+	copy(buf, p)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, errAsyncWriterClosed
+	}
+
+	w.pending++
+	for {
+		select {
+		case w.ch <- buf:
+			return len(p), nil
+		default:
+			// Buffer đầy: drop entry cũ nhất để nhường chỗ cho entry mới
+			select {
+			case <-w.ch:
+				w.pending--
+				atomic.AddUint64(&w.dropped, 1)
+			default:
+			}
+		}
+	}
+}
+
+// drain ghi các entry trong channel ra writer thật
+func (w *asyncWriter) drain() {
+	defer close(w.done)
+	for buf := range w.ch {
+		w.out.Write(buf)
+
+		w.mu.Lock()
+		w.pending--
+		if w.pending == 0 {
+			for _, idle := range w.idle {
+				close(idle)
+			}
+			w.idle = nil
+		}
+		w.mu.Unlock()
+	}
+}
+
+// Flush chờ đến khi mọi entry đã nhận trước đó được ghi ra writer thật
+// Trả về ctx.Err() nếu ctx hết hạn trước khi flush xong; khi đó không còn gì chờ lại (không leak goroutine)
+func (w *asyncWriter) Flush(ctx context.Context) error {
+	w.mu.Lock()
+	if w.pending == 0 {
+		w.mu.Unlock()
+		return nil
+	}
+	idle := make(chan struct{})
+	w.idle = append(w.idle, idle)
+	w.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		w.mu.Lock()
+		for i, ch := range w.idle {
+			if ch == idle {
+				w.idle = append(w.idle[:i], w.idle[i+1:]...)
+				break
+			}
+		}
+		w.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Close drain toàn bộ buffer, dừng background goroutine và đóng writer thật
+func (w *asyncWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.ch)
+	w.mu.Unlock()
+
+	<-w.done
+	return w.out.Close()
+}
+
+// Dropped trả về số entry đã bị drop do buffer đầy
+func (w *asyncWriter) Dropped() uint64 {
+	return atomic.LoadUint64(&w.dropped)
+}
@@ -0,0 +1,197 @@
+package goerrorkit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// gatedWriter chặn mọi Write cho tới khi gate được mở (mô phỏng disk chậm/lumberjack đang rotate)
+type gatedWriter struct {
+	gate chan struct{}
+
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func newGatedWriter() *gatedWriter {
+	return &gatedWriter{gate: make(chan struct{})}
+}
+
+func (g *gatedWriter) Write(p []byte) (int, error) {
+	<-g.gate
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.buf.Write(p)
+}
+
+func (g *gatedWriter) Close() error { return nil }
+
+func (g *gatedWriter) String() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.buf.String()
+}
+
+func TestAsyncWriterFlushTimeoutReleasesWaiter(t *testing.T) {
+	out := newGatedWriter()
+	w := newAsyncWriter(out, 4)
+	defer w.Close()
+
+	_, _ = w.Write([]byte("entry\n"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := w.Flush(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Flush = %v, want DeadlineExceeded", err)
+	}
+
+	// Flush hết hạn không để lại waiter (trước đây là goroutine chờ cond mãi mãi)
+	w.mu.Lock()
+	waiting := len(w.idle)
+	w.mu.Unlock()
+	if waiting != 0 {
+		t.Errorf("%d waiters left after Flush timed out", waiting)
+	}
+
+	close(out.gate)
+	if err := w.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush after writer unblocked = %v", err)
+	}
+	if got := out.String(); got != "entry\n" {
+		t.Errorf("written = %q", got)
+	}
+}
+
+func TestAsyncWriterConcurrentFlush(t *testing.T) {
+	out := newGatedWriter()
+	w := newAsyncWriter(out, 16)
+	defer w.Close()
+
+	for i := 0; i < 10; i++ {
+		fmt.Fprintf(w, "entry-%d\n", i)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- w.Flush(context.Background())
+		}()
+	}
+	close(out.gate)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Flush = %v", err)
+		}
+	}
+	if got := strings.Count(out.String(), "\n"); got != 10 {
+		t.Errorf("wrote %d entries, want 10", got)
+	}
+}
+
+func TestAsyncWriterDropOldest(t *testing.T) {
+	out := newGatedWriter()
+	w := newAsyncWriter(out, 2)
+
+	for i := 0; i < 10; i++ {
+		fmt.Fprintf(w, "entry-%d\n", i)
+	}
+	close(out.gate)
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// drain có thể đã lấy entry đầu tiên trước khi buffer đầy; entry mới nhất luôn được giữ
+	got := out.String()
+	if !strings.HasSuffix(got, "entry-8\nentry-9\n") {
+		t.Errorf("written = %q, want newest entries kept", got)
+	}
+	if written := uint64(strings.Count(got, "\n")); written+w.Dropped() != 10 {
+		t.Errorf("written %d + dropped %d != 10", written, w.Dropped())
+	}
+}
+
+func TestFlushLogsWritesPriorEntriesToDisk(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.log")
+	if err := InitLoggerE(LoggerOptions{
+		FileOutput:      true,
+		FilePath:        path,
+		JSONFormat:      true,
+		FileLogLevel:    "error",
+		AsyncFile:       true,
+		AsyncBufferSize: 1000,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = CloseLogger()
+		SetLogger(nil)
+	}()
+
+	const entries = 500
+	for i := 0; i < entries; i++ {
+		LogError(NewSystemError(fmt.Errorf("db down %d", i)), "GET /orders")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := FlushLogs(ctx); err != nil {
+		t.Fatalf("FlushLogs = %v", err)
+	}
+
+	// Đọc file khi logger vẫn mở: FlushLogs (không phải CloseLogger) phải đủ để entry nằm trên disk
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(string(content), "\n"); got != entries {
+		t.Errorf("file has %d entries after FlushLogs, want %d", got, entries)
+	}
+	if !strings.Contains(string(content), "db down 499") {
+		t.Error("last entry missing from file")
+	}
+}
+
+func benchmarkFileLogging(b *testing.B, async bool) {
+	path := filepath.Join(b.TempDir(), "errors.log")
+	logger, err := newLogrusLogger(LoggerOptions{
+		FileOutput:   true,
+		FilePath:     path,
+		JSONFormat:   true,
+		FileLogLevel: "error",
+		AsyncFile:    async,
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer logger.Close()
+
+	fields := map[string]interface{}{
+		"error_type": "SYSTEM",
+		"request_id": "req-1",
+		"call_chain": []string{"main.handler (main.go:42)", "main.service (service.go:10)"},
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.Error("Internal server error", fields)
+	}
+	b.StopTimer()
+	_ = logger.Flush(context.Background())
+}
+
+func BenchmarkFileLoggingSync(b *testing.B)  { benchmarkFileLogging(b, false) }
+func BenchmarkFileLoggingAsync(b *testing.B) { benchmarkFileLogging(b, true) }
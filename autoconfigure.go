@@ -0,0 +1,90 @@
+package goerrorkit
+
+import (
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// AutoConfigure giống ConfigureForApplication nhưng tự phát hiện package của application:
+//   - Ưu tiên main module path trong build info (debug.ReadBuildInfo), kèm regex cho mọi
+//     sub-package của module (github.com/yourname/myapp/handlers, .../services, ...)
+//   - Fallback: package của function gọi AutoConfigure (runtime.Caller), ví dụ khi chạy
+//     `go run main.go` (module là "command-line-arguments") hoặc binary không có build info
+//
+// Package "main" luôn được include vì frame của main package có tên "main.*" bất kể module path
+// Trả về package đã phát hiện (chuỗi rỗng nếu không phát hiện được, config giữ nguyên)
+//
+// Example:
+//
+//	func main() {
+//	    goerrorkit.InitDefaultLogger()
+//	    pkg := goerrorkit.AutoConfigure()
+//	    log.Printf("goerrorkit: stack trace filter cho %s", pkg)
+//	}
+func AutoConfigure() string {
+	appPackage := mainModulePath()
+	if appPackage == "" {
+		appPackage = callerPackage(2)
+	}
+	if appPackage == "" {
+		return ""
+	}
+
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	if appPackage == "main" {
+		defaultConfig = withApplicationPackages(defaultConfig.clone(), []string{"main"})
+		return appPackage
+	}
+
+	cfg := withApplicationPackages(defaultConfig.clone(), []string{appPackage, "main"})
+	subPackages := regexp.MustCompile("^" + regexp.QuoteMeta(appPackage) + "/")
+	if !containsRegex(cfg.IncludeRegexes, subPackages) {
+		cfg.IncludeRegexes = append(cfg.IncludeRegexes, subPackages)
+	}
+	defaultConfig = cfg
+	return appPackage
+}
+
+// mainModulePath trả về main module path từ build info (rỗng nếu không có hoặc là "go run file.go")
+func mainModulePath() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok || info.Main.Path == "" || info.Main.Path == "command-line-arguments" {
+		return ""
+	}
+	return info.Main.Path
+}
+
+// callerPackage trả về import path của package chứa function ở stack frame skip
+// "github.com/yourname/myapp/cmd/server.main" → "github.com/yourname/myapp/cmd/server"
+func callerPackage(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip)
+	if !ok {
+		return ""
+	}
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return ""
+	}
+	name := fn.Name()
+	// Dấu "." đầu tiên sau "/" cuối cùng ngăn cách package path và tên function
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return ""
+	}
+	return name[:slash+1+dot]
+}
+
+// containsRegex kiểm tra slice có chứa regex cùng pattern không
+func containsRegex(list []*regexp.Regexp, re *regexp.Regexp) bool {
+	for _, item := range list {
+		if item.String() == re.String() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package goerrorkit
+
+import (
+	"reflect"
+	"testing"
+)
+
+const autoConfigureStack = `goroutine 1 [running]:
+github.com/techmaster-vietnam/goerrorkit.NewSystemError(0x0)
+	/src/goerrorkit/error.go:120 +0x18
+github.com/techmaster-vietnam/goerrorkit/adapters/fiber.handleOrder(0xc000010000)
+	/src/goerrorkit/adapters/fiber/orders.go:33 +0x4c
+github.com/other/lib.Do()
+	/go/pkg/mod/github.com/other/lib/do.go:8 +0x1f
+main.main()
+	/src/goerrorkit/cmd/api/main.go:9 +0x17
+`
+
+func TestAutoConfigureFromBuildInfo(t *testing.T) {
+	withStackTraceConfig(t)
+	if got := mainModulePath(); got != testModule {
+		t.Skipf("build info main module = %q, want %q", got, testModule)
+	}
+
+	if got := AutoConfigure(); got != testModule {
+		t.Fatalf("AutoConfigure() = %q, want %q", got, testModule)
+	}
+	AutoConfigure() // gọi lại không thêm trùng regex / SkipPackages
+
+	cfg := getStackTraceConfig()
+	if want := []string{testModule, "main"}; !reflect.DeepEqual(cfg.IncludePackages, want) {
+		t.Errorf("IncludePackages = %v, want %v", cfg.IncludePackages, want)
+	}
+	if len(cfg.IncludeRegexes) != 1 || cfg.IncludeRegexes[0].String() != "^github\\.com/techmaster-vietnam/goerrorkit/" {
+		t.Errorf("IncludeRegexes = %v, want one sub-package regex", cfg.IncludeRegexes)
+	}
+
+	var got []string
+	for _, frame := range cfg.parseStackFrames([]byte(autoConfigureStack)) {
+		got = append(got, frame.String())
+	}
+	want := []string{"fiber.handleOrder (orders.go:33)", "main.main (main.go:9)"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("frames = %q, want %q", got, want)
+	}
+}
+
+func TestCallerPackage(t *testing.T) {
+	if got := callerPackage(1); got != testModule {
+		t.Errorf("callerPackage(1) = %q, want %q", got, testModule)
+	}
+	if got := func() string { return callerPackage(1) }(); got != testModule {
+		t.Errorf("callerPackage from closure = %q, want %q", got, testModule)
+	}
+}
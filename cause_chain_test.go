@@ -0,0 +1,64 @@
+package goerrorkit
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// loggedFields log appErr bằng MemoryLogger và trả về fields của entry
+func loggedFields(t *testing.T, appErr *AppError) map[string]interface{} {
+	t.Helper()
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	LogError(appErr, "GET /orders/42")
+	entries := mem.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("entries = %v, want 1", entries)
+	}
+	return entries[0].Fields
+}
+
+func TestCauseChainOrder(t *testing.T) {
+	root := errors.New("connection refused")
+	repo := NewSystemError(fmt.Errorf("query orders: %w", root)).WithErrorCode("DB_UNAVAILABLE")
+	repo.Message = "load order failed"
+	appErr := NewSystemError(fmt.Errorf("service: %w", repo))
+
+	fields := loggedFields(t, appErr)
+	chain, ok := fields["cause_chain"].([]map[string]interface{})
+	if !ok {
+		t.Fatalf("cause_chain = %#v", fields["cause_chain"])
+	}
+	want := []map[string]interface{}{
+		{"message": "service: load order failed"},
+		{"message": "load order failed", "type": string(SystemError), "error_code": "DB_UNAVAILABLE"},
+		{"message": "query orders: connection refused"},
+		{"message": "connection refused"},
+	}
+	if !reflect.DeepEqual(chain, want) {
+		t.Errorf("cause_chain = %v, want outermost → root %v", chain, want)
+	}
+	if fields["cause"] != "service: load order failed" {
+		t.Errorf("cause = %v", fields["cause"])
+	}
+}
+
+func TestCauseChainSingleLevelOmitted(t *testing.T) {
+	fields := loggedFields(t, NewSystemError(errors.New("connection refused")))
+	if _, ok := fields["cause_chain"]; ok {
+		t.Errorf("cause_chain = %v, want omitted for an unwrapped cause", fields["cause_chain"])
+	}
+}
+
+func TestCauseChainDepthLimit(t *testing.T) {
+	err := errors.New("root")
+	for i := 0; i < 20; i++ {
+		err = fmt.Errorf("layer %d: %w", i, err)
+	}
+	if chain := causeChain(err); len(chain) != maxCauseChainDepth {
+		t.Errorf("len(cause_chain) = %d, want %d", len(chain), maxCauseChainDepth)
+	}
+}
@@ -0,0 +1,40 @@
+package goerrorkit
+
+// includeClientInfo bật/tắt việc thêm client_ip và user_agent vào log của request error
+var includeClientInfo = false
+
+// SetIncludeClientInfo bật/tắt field client_ip và user_agent trong log của LogAndRespond
+// (và Fiber middleware). Cũng được set qua LoggerOptions.IncludeClientInfo
+// Field chỉ có khi HTTPContext implement ClientIPGetter / HeaderGetter (adapters/fiber.FiberContext có sẵn)
+//
+// Example:
+//
+//	goerrorkit.SetIncludeClientInfo(true)
+//	// log: {"client_ip": "203.0.113.7", "user_agent": "Mozilla/5.0 ...", ...}
+func SetIncludeClientInfo(enabled bool) {
+	includeClientInfo = enabled
+}
+
+// clientInfoFields thêm client_ip và user_agent vào extra (nếu bật và HTTPContext hỗ trợ)
+// Không sửa map extra gốc
+func clientInfoFields(ctx HTTPContext, extra map[string]interface{}) map[string]interface{} {
+	if !includeClientInfo {
+		return extra
+	}
+
+	fields := make(map[string]interface{}, len(extra)+2)
+	for k, v := range extra {
+		fields[k] = v
+	}
+	if ipGetter, ok := ctx.(ClientIPGetter); ok {
+		if ip := ipGetter.ClientIP(); ip != "" {
+			fields["client_ip"] = ip
+		}
+	}
+	if hg, ok := ctx.(HeaderGetter); ok {
+		if ua := hg.GetHeader("User-Agent"); ua != "" {
+			fields["user_agent"] = ua
+		}
+	}
+	return fields
+}
@@ -0,0 +1,104 @@
+package goerrorkit
+
+import "testing"
+
+// clientContext là testContext implement QueryGetter và ClientIPGetter
+type clientContext struct {
+	*testContext
+	ip    string
+	query map[string]string
+}
+
+func (c *clientContext) ClientIP() string         { return c.ip }
+func (c *clientContext) Query(name string) string { return c.query[name] }
+
+func newClientContext() *clientContext {
+	ctx := &clientContext{testContext: newTestContext("GET", "/orders"), ip: "203.0.113.7", query: map[string]string{"page": "2"}}
+	ctx.headers["User-Agent"] = "curl/8.0"
+	return ctx
+}
+
+func withIncludeClientInfo(t *testing.T, enabled bool) {
+	t.Helper()
+	SetIncludeClientInfo(enabled)
+	t.Cleanup(func() { SetIncludeClientInfo(false) })
+}
+
+func TestLogAndRespondClientInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		ctx     HTTPContext
+		ip      interface{}
+		ua      interface{}
+	}{
+		{"disabled", false, newClientContext(), nil, nil},
+		{"enabled", true, newClientContext(), "203.0.113.7", "curl/8.0"},
+		{"no ClientIPGetter", true, &testContext{method: "GET", path: "/orders", headers: map[string]string{"User-Agent": "curl/8.0"}, respHeaders: map[string]string{}}, nil, "curl/8.0"},
+		{"no optional interfaces", true, bareContext{newClientContext()}, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mem := UseMemoryLogger()
+			defer SetLogger(nil)
+			withIncludeClientInfo(t, tt.enabled)
+
+			LogAndRespond(tt.ctx, NewBusinessError(404, "Order not found"), "GET /orders")
+
+			entry, ok := mem.Find("", "Order not found")
+			if !ok {
+				t.Fatalf("error not logged: %+v", mem.Entries())
+			}
+			if got := entry.Fields["client_ip"]; got != tt.ip {
+				t.Errorf("client_ip = %v, want %v", got, tt.ip)
+			}
+			if got := entry.Fields["user_agent"]; got != tt.ua {
+				t.Errorf("user_agent = %v, want %v", got, tt.ua)
+			}
+		})
+	}
+}
+
+func TestClientInfoFieldsDoesNotMutateExtra(t *testing.T) {
+	withIncludeClientInfo(t, true)
+
+	extra := map[string]interface{}{"route": "/orders"}
+	fields := clientInfoFields(newClientContext(), extra)
+
+	if fields["client_ip"] != "203.0.113.7" || fields["route"] != "/orders" {
+		t.Errorf("fields = %v", fields)
+	}
+	if _, ok := extra["client_ip"]; ok || len(extra) != 1 {
+		t.Errorf("extra mutated: %v", extra)
+	}
+}
+
+func TestResponseRecorderDelegatesClientInfo(t *testing.T) {
+	rec := newResponseRecorder(newClientContext())
+	if qg, ok := rec.(QueryGetter); !ok || qg.Query("page") != "2" {
+		t.Error("recorder does not delegate Query")
+	}
+	if ig, ok := rec.(ClientIPGetter); !ok || ig.ClientIP() != "203.0.113.7" {
+		t.Error("recorder does not delegate ClientIP")
+	}
+
+	// Context gốc không hỗ trợ: trả về chuỗi rỗng thay vì panic
+	bare := newResponseRecorder(bareContext{newTestContext("GET", "/orders")})
+	if got := bare.(QueryGetter).Query("page"); got != "" {
+		t.Errorf("Query = %q, want empty", got)
+	}
+	if got := bare.(ClientIPGetter).ClientIP(); got != "" {
+		t.Errorf("ClientIP = %q, want empty", got)
+	}
+}
+
+func TestLoggerOptionsIncludeClientInfo(t *testing.T) {
+	withIncludeClientInfo(t, false)
+	logger, _ := newTestLogrusLogger(t, LoggerOptions{ConsoleOutput: true, IncludeClientInfo: true})
+	installLogger(logger, LoggerOptions{ConsoleOutput: true, IncludeClientInfo: true})
+	defer SetLogger(nil)
+
+	if !includeClientInfo {
+		t.Error("LoggerOptions.IncludeClientInfo did not enable client info")
+	}
+}
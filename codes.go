@@ -0,0 +1,85 @@
+package goerrorkit
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// errorCodeDef là định nghĩa mặc định của một application error code
+type errorCodeDef struct {
+	message    string
+	httpStatus int
+}
+
+var (
+	errorCodesMu sync.RWMutex
+	errorCodes   = map[string]errorCodeDef{}
+)
+
+// WithErrorCode gắn application error code ổn định (ví dụ "ORD-1021") để client branch logic
+// Code được trả về trong response dưới field "code" và log dưới field "error_code"
+//
+// Example:
+//
+//	return goerrorkit.NewBusinessError(409, "Order already shipped").WithErrorCode("ORD-1021")
+func (e *AppError) WithErrorCode(code string) *AppError {
+	e.ErrCode = code
+	return e
+}
+
+// RegisterErrorCode đăng ký message và HTTP status mặc định cho một application error code
+// Gọi khi khởi động (init/main). Đăng ký trùng code sẽ panic để phát hiện sớm xung đột
+//
+// Example:
+//
+//	func init() {
+//	    goerrorkit.RegisterErrorCode("ORD-1021", "Order already shipped", 409)
+//	    goerrorkit.RegisterErrorCode("ORD-1022", "Order not found", 404)
+//	}
+func RegisterErrorCode(code, defaultMessage string, httpStatus int) {
+	errorCodesMu.Lock()
+	defer errorCodesMu.Unlock()
+	if _, exists := errorCodes[code]; exists {
+		panic(fmt.Sprintf("goerrorkit: error code %q already registered", code))
+	}
+	errorCodes[code] = errorCodeDef{message: defaultMessage, httpStatus: httpStatus}
+}
+
+// NewCodedError tạo AppError từ error code đã đăng ký (RegisterErrorCode)
+// Type được suy ra từ HTTP status: >= 500 → SystemError, còn lại → BusinessError
+// Code chưa đăng ký → SystemError 500 với message là chính code
+//
+// Example:
+//
+//	if order.Shipped {
+//	    return goerrorkit.NewCodedError("ORD-1021")
+//	    // Response: {"error": "Order already shipped", "type": "BUSINESS", "code": "ORD-1021", ...}
+//	}
+func NewCodedError(code string) *AppError {
+	file, line, function := getCallerInfo(1)
+
+	errorCodesMu.RLock()
+	def, ok := errorCodes[code]
+	errorCodesMu.RUnlock()
+	if !ok {
+		def = errorCodeDef{message: code, httpStatus: 500}
+	}
+
+	errType := BusinessError
+	if def.httpStatus >= 500 {
+		errType = SystemError
+	}
+
+	return &AppError{
+		Type:      errType,
+		Code:      def.httpStatus,
+		Message:   def.message,
+		ErrCode:   code,
+		CreatedAt: time.Now(),
+		Details: map[string]interface{}{
+			"function": function,
+			"file":     fmt.Sprintf("%s:%d", file, line),
+		},
+	}
+}
@@ -0,0 +1,83 @@
+package goerrorkit
+
+import (
+	"strings"
+	"testing"
+)
+
+// registerTestErrorCode đăng ký error code và xóa khỏi registry khi test kết thúc
+func registerTestErrorCode(t *testing.T, code, message string, status int) {
+	t.Helper()
+	RegisterErrorCode(code, message, status)
+	t.Cleanup(func() {
+		errorCodesMu.Lock()
+		delete(errorCodes, code)
+		errorCodesMu.Unlock()
+	})
+}
+
+func TestNewCodedErrorRegistryLookup(t *testing.T) {
+	registerTestErrorCode(t, "ORD-1021", "Order already shipped", 409)
+	registerTestErrorCode(t, "PAY-5001", "Payment provider unavailable", 503)
+
+	tests := []struct {
+		code    string
+		errType ErrorType
+		status  int
+		message string
+	}{
+		{"ORD-1021", BusinessError, 409, "Order already shipped"},
+		{"PAY-5001", SystemError, 503, "Payment provider unavailable"},
+		{"ORD-9999", SystemError, 500, "ORD-9999"}, // chưa đăng ký
+	}
+	for _, tt := range tests {
+		appErr := NewCodedError(tt.code)
+		if appErr.Type != tt.errType || appErr.Code != tt.status || appErr.Message != tt.message || appErr.ErrCode != tt.code {
+			t.Errorf("NewCodedError(%q) = %s %d %q (%s)", tt.code, appErr.Type, appErr.Code, appErr.Message, appErr.ErrCode)
+		}
+		if file, _ := appErr.Details["file"].(string); !strings.HasPrefix(file, "codes_test.go:") {
+			t.Errorf("NewCodedError(%q) file = %q, want caller location", tt.code, file)
+		}
+	}
+}
+
+func TestRegisterErrorCodeDuplicatePanics(t *testing.T) {
+	registerTestErrorCode(t, "ORD-1022", "Order not found", 404)
+
+	defer func() {
+		r := recover()
+		if msg, _ := r.(string); !strings.Contains(msg, `"ORD-1022" already registered`) {
+			t.Errorf("recover() = %v, want duplicate registration panic", r)
+		}
+		if got := NewCodedError("ORD-1022"); got.Code != 404 || got.Message != "Order not found" {
+			t.Errorf("first registration overwritten: %d %q", got.Code, got.Message)
+		}
+	}()
+	RegisterErrorCode("ORD-1022", "Order missing", 410)
+}
+
+func TestErrorCodeResponseAndLog(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+	registerTestErrorCode(t, "ORD-1021", "Order already shipped", 409)
+
+	ctx := newTestContext("POST", "/orders/42/cancel")
+	LogAndRespond(ctx, NewCodedError("ORD-1021"), "POST /orders/42/cancel")
+
+	resp := ctx.response()
+	if ctx.status != 409 || resp["code"] != "ORD-1021" || resp["error"] != "Order already shipped" || resp["type"] != "BUSINESS" {
+		t.Errorf("status = %d, response = %v", ctx.status, resp)
+	}
+	entry, _ := mem.Find("", "Order already shipped")
+	if entry.Fields["error_code"] != "ORD-1021" {
+		t.Errorf("error_code = %v", entry.Fields["error_code"])
+	}
+
+	// Không có code: response không có field "code"
+	if _, ok := FormatErrorResponse(NewBusinessError(404, "Not found"))["code"]; ok {
+		t.Error(`response has "code" without ErrCode`)
+	}
+	if got := FormatErrorResponse(NewBusinessError(409, "Conflict").WithErrorCode("ORD-1030"))["code"]; got != "ORD-1030" {
+		t.Errorf("WithErrorCode response code = %v", got)
+	}
+}
@@ -0,0 +1,142 @@
+package goerrorkit
+
+import (
+	"fmt"
+	"regexp"
+	"runtime/debug"
+	"sort"
+	"strings"
+)
+
+// GetStackTraceConfig trả về bản copy của stack trace config hiện tại
+// Sửa bản copy không ảnh hưởng config đang dùng (dùng SetStackTraceConfig để áp dụng)
+//
+// Example:
+//
+//	cfg := goerrorkit.GetStackTraceConfig()
+//	fmt.Println(cfg.IncludePackages) // [github.com/yourname/myapp]
+func GetStackTraceConfig() StackTraceConfig {
+	return getStackTraceConfig().clone()
+}
+
+// configSnapshot là trạng thái config hiện tại, dùng chung cho DumpConfig (text) và ConfigHandler (JSON)
+type configSnapshot struct {
+	SkipPackages      []string               `json:"skip_packages"`
+	SkipFunctions     []string               `json:"skip_functions"`
+	SkipRegexes       []string               `json:"skip_regexes"`
+	IncludePackages   []string               `json:"include_packages"`
+	IncludeRegexes    []string               `json:"include_regexes"`
+	ShowFullPath      bool                   `json:"show_full_path"`
+	MaxFrames         int                    `json:"max_frames"`
+	IncludeSource     bool                   `json:"include_source"`
+	ConsoleLogLevel   string                 `json:"console_log_level,omitempty"`
+	FileLogLevel      string                 `json:"file_log_level,omitempty"`
+	GlobalFields      map[string]interface{} `json:"global_fields,omitempty"`
+	MainModule        string                 `json:"main_module,omitempty"`
+	MainModuleMatches bool                   `json:"main_module_matches"`
+}
+
+// currentConfigSnapshot chụp config hiện tại
+// Global fields đi qua redactor giống log entry: value của key nhạy cảm (token, secret, ...) bị che
+func currentConfigSnapshot() configSnapshot {
+	cfg := getStackTraceConfig()
+	snap := configSnapshot{
+		SkipPackages:      append([]string{}, cfg.SkipPackages...),
+		SkipFunctions:     append([]string{}, cfg.SkipFunctions...),
+		SkipRegexes:       regexPatterns(cfg.SkipRegexes),
+		IncludePackages:   append([]string{}, cfg.IncludePackages...),
+		IncludeRegexes:    regexPatterns(cfg.IncludeRegexes),
+		ShowFullPath:      cfg.ShowFullPath,
+		MaxFrames:         cfg.maxFrames(),
+		IncludeSource:     cfg.IncludeSource,
+		MainModuleMatches: true,
+	}
+	snap.ConsoleLogLevel, snap.FileLogLevel = GetLogLevels()
+
+	globalFieldsMu.RLock()
+	snap.GlobalFields = getRedactor().redactMap(globalFields)
+	globalFieldsMu.RUnlock()
+
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Path != "" {
+		snap.MainModule = info.Main.Path
+		snap.MainModuleMatches = len(cfg.IncludePackages) == 0 || matchesAnyPackage(info.Main.Path, cfg.IncludePackages)
+	}
+	return snap
+}
+
+// DumpConfig trả về mô tả dễ đọc của stack trace config hiện tại, log level và global fields (đã redact)
+// Kèm main module của binary để kiểm tra ConfigureForApplication có match module path không
+//
+// Example:
+//
+//	fmt.Println(goerrorkit.DumpConfig())
+func DumpConfig() string {
+	snap := currentConfigSnapshot()
+
+	var b strings.Builder
+	b.WriteString("goerrorkit config\n")
+	fmt.Fprintf(&b, "  SkipPackages:    %s\n", formatList(snap.SkipPackages))
+	fmt.Fprintf(&b, "  SkipFunctions:   %s\n", formatList(snap.SkipFunctions))
+	fmt.Fprintf(&b, "  SkipRegexes:     %s\n", formatList(snap.SkipRegexes))
+	fmt.Fprintf(&b, "  IncludePackages: %s\n", formatList(snap.IncludePackages))
+	fmt.Fprintf(&b, "  IncludeRegexes:  %s\n", formatList(snap.IncludeRegexes))
+	fmt.Fprintf(&b, "  ShowFullPath:    %t\n", snap.ShowFullPath)
+	fmt.Fprintf(&b, "  MaxFrames:       %d\n", snap.MaxFrames)
+	fmt.Fprintf(&b, "  IncludeSource:   %t\n", snap.IncludeSource)
+	if snap.ConsoleLogLevel == "" && snap.FileLogLevel == "" {
+		b.WriteString("  LogLevel:        (logger chưa khởi tạo)\n")
+	} else {
+		fmt.Fprintf(&b, "  LogLevel:        console=%s file=%s\n", snap.ConsoleLogLevel, snap.FileLogLevel)
+	}
+	fmt.Fprintf(&b, "  GlobalFields:    %s\n", formatFields(snap.GlobalFields))
+
+	if snap.MainModule != "" {
+		fmt.Fprintf(&b, "  MainModule:      %s", snap.MainModule)
+		if !snap.MainModuleMatches {
+			b.WriteString(" (không match IncludePackages nào)")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// formatList format slice string cho DumpConfig ("(none)" nếu rỗng)
+func formatList(list []string) string {
+	if len(list) == 0 {
+		return "(none)"
+	}
+	return strings.Join(list, ", ")
+}
+
+// regexPatterns trả về pattern của từng regex (slice rỗng, không nil, để JSON ra [])
+func regexPatterns(list []*regexp.Regexp) []string {
+	patterns := make([]string, 0, len(list))
+	for _, re := range list {
+		patterns = append(patterns, re.String())
+	}
+	return patterns
+}
+
+// formatFields format map key=value theo thứ tự key cho DumpConfig
+func formatFields(fields map[string]interface{}) string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", k, fields[k]))
+	}
+	return formatList(pairs)
+}
+
+// matchesAnyPackage kiểm tra module path có nằm trong (hoặc chứa) package nào của list không
+func matchesAnyPackage(modulePath string, packages []string) bool {
+	for _, pkg := range packages {
+		if strings.HasPrefix(pkg, modulePath) || strings.HasPrefix(modulePath, pkg) {
+			return true
+		}
+	}
+	return false
+}
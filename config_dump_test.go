@@ -0,0 +1,60 @@
+package goerrorkit
+
+import (
+	"strings"
+	"testing"
+)
+
+// testModule là main module của test binary (debug.ReadBuildInfo khi chạy go test)
+const testModule = "github.com/techmaster-vietnam/goerrorkit"
+
+// withDumpFixture cấu hình IncludePackages không match module và global fields có secret
+// Logger được tạm bỏ để log level không phụ thuộc test chạy trước
+func withDumpFixture(t *testing.T) {
+	t.Helper()
+	withStackTraceConfig(t)
+	prev := defaultLogger
+	SetLogger(nil)
+	t.Cleanup(func() {
+		SetLogger(prev)
+		SetGlobalFields(nil)
+	})
+
+	ConfigureForApplication("github.com/wrong/app")
+	SetGlobalFields(map[string]interface{}{"service": "order-service", "api_token": "s3cr3t"})
+}
+
+func TestDumpConfig(t *testing.T) {
+	withDumpFixture(t)
+
+	dump := DumpConfig()
+	for _, want := range []string{
+		"IncludePackages: github.com/wrong/app",
+		"SkipPackages:    runtime, runtime/debug",
+		"MainModule:      " + testModule + " (không match IncludePackages nào)",
+		"LogLevel:        (logger chưa khởi tạo)",
+		"api_token=" + redactedValue,
+		"service=order-service",
+	} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("DumpConfig() missing %q:\n%s", want, dump)
+		}
+	}
+	if strings.Contains(dump, "s3cr3t") {
+		t.Errorf("DumpConfig() leaks a sensitive global field:\n%s", dump)
+	}
+}
+
+func TestGetStackTraceConfigReturnsCopy(t *testing.T) {
+	withStackTraceConfig(t)
+	ConfigureForApplication("github.com/yourname/myapp")
+
+	cfg := GetStackTraceConfig()
+	cfg.IncludePackages[0] = "github.com/changed/app"
+	cfg.SkipFunctions = append(cfg.SkipFunctions[:0], "changed")
+
+	current := getStackTraceConfig()
+	if current.IncludePackages[0] != "github.com/yourname/myapp" || current.SkipFunctions[0] == "changed" {
+		t.Errorf("modifying the copy changed the active config: %+v", current)
+	}
+}
@@ -0,0 +1,23 @@
+//go:build debug
+// +build debug
+
+package goerrorkit
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ConfigHandler trả về HTTP handler hiển thị config hiện tại dạng JSON (cùng nội dung DumpConfig,
+// global fields đã được redact)
+// CHỈ hoạt động khi build với -tags=debug, production build trả về 404
+//
+// Example:
+//
+//	http.Handle("/debug/goerrorkit", goerrorkit.ConfigHandler())
+func ConfigHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(currentConfigSnapshot())
+	})
+}
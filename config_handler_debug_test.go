@@ -0,0 +1,59 @@
+//go:build debug
+// +build debug
+
+package goerrorkit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestConfigHandlerJSON(t *testing.T) {
+	withDumpFixture(t)
+
+	rec := httptest.NewRecorder()
+	ConfigHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/goerrorkit", nil))
+
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("status = %d, Content-Type = %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if strings.Contains(rec.Body.String(), "s3cr3t") {
+		t.Errorf("body leaks a sensitive global field: %s", rec.Body)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body is not JSON: %v\n%s", err, rec.Body)
+	}
+	var keys []string
+	for k := range body {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	wantKeys := []string{
+		"global_fields", "include_packages", "include_regexes", "include_source", "main_module",
+		"main_module_matches", "max_frames", "show_full_path", "skip_functions", "skip_packages", "skip_regexes",
+	}
+	if !reflect.DeepEqual(keys, wantKeys) {
+		t.Errorf("keys = %v, want %v", keys, wantKeys)
+	}
+
+	if got := body["include_packages"]; !reflect.DeepEqual(got, []interface{}{"github.com/wrong/app"}) {
+		t.Errorf("include_packages = %v", got)
+	}
+	if got := body["skip_regexes"]; !reflect.DeepEqual(got, []interface{}{}) {
+		t.Errorf("skip_regexes = %v, want []", got)
+	}
+	if body["main_module"] != testModule || body["main_module_matches"] != false {
+		t.Errorf("main_module = %v, matches = %v", body["main_module"], body["main_module_matches"])
+	}
+	want := map[string]interface{}{"service": "order-service", "api_token": redactedValue}
+	if got := body["global_fields"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("global_fields = %v, want %v", got, want)
+	}
+}
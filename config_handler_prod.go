@@ -0,0 +1,12 @@
+//go:build !debug
+// +build !debug
+
+package goerrorkit
+
+import "net/http"
+
+// ConfigHandler - PRODUCTION MODE: luôn trả về 404
+// Không để lộ cấu hình nội bộ trong production build (xem config_handler_debug.go)
+func ConfigHandler() http.Handler {
+	return http.NotFoundHandler()
+}
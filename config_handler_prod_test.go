@@ -0,0 +1,25 @@
+//go:build !debug
+// +build !debug
+
+package goerrorkit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestConfigHandlerNotFoundInProduction(t *testing.T) {
+	withDumpFixture(t)
+
+	rec := httptest.NewRecorder()
+	ConfigHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/goerrorkit", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404 in production build", rec.Code)
+	}
+	if strings.Contains(rec.Body.String(), "include_packages") {
+		t.Errorf("production build exposes config: %s", rec.Body)
+	}
+}
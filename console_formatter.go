@@ -0,0 +1,152 @@
+package goerrorkit
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Mã màu ANSI cho console (giống màu của logrus.TextFormatter)
+const (
+	ansiReset  = "\x1b[0m"
+	ansiRed    = "\x1b[31m"
+	ansiYellow = "\x1b[33m"
+	ansiBlue   = "\x1b[36m"
+	ansiGray   = "\x1b[37m"
+	ansiDim    = "\x1b[2m"
+)
+
+// prettyConsoleFormatter là logrus.Formatter dễ đọc cho console khi develop (LoggerOptions.PrettyConsole)
+//   - Dòng đầu: thời gian, level (có màu), [error_type] error_code và message
+//   - Các field còn lại dạng key=value trên một dòng thụt vào
+//   - data được flatten trên dòng riêng (product_id=123 user.id=7), call_chain in thành block nhiều dòng
+//
+// Example output:
+//
+//	2025-11-28 10:00:00 ERROR [SYSTEM] DB_DOWN Internal server error
+//	    cause="connection refused" path="GET /orders/42" ref=ERR-7F3A2C severity=SEV2
+//	    data: order_id=42
+//	    call_chain:
+//	      → main.main (main.go:25)
+//	      → main.handler (main.go:40)
+type prettyConsoleFormatter struct {
+	timestampFormat string
+	disableColors   bool
+}
+
+// newPrettyConsoleFormatter tạo pretty console formatter (timestampFormat rỗng → "2006-01-02 15:04:05")
+// Màu bị tắt khi set biến môi trường NO_COLOR (https://no-color.org)
+func newPrettyConsoleFormatter(timestampFormat string) *prettyConsoleFormatter {
+	if timestampFormat == "" {
+		timestampFormat = "2006-01-02 15:04:05"
+	}
+	return &prettyConsoleFormatter{
+		timestampFormat: timestampFormat,
+		disableColors:   os.Getenv("NO_COLOR") != "",
+	}
+}
+
+// Format implements logrus.Formatter
+func (f *prettyConsoleFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	var b bytes.Buffer
+	color := levelColor(entry.Level)
+
+	b.WriteString(entry.Time.Format(f.timestampFormat))
+	b.WriteByte(' ')
+	level := strings.ToUpper(entry.Level.String())
+	if errType, ok := entry.Data["error_type"]; ok {
+		level += fmt.Sprintf(" [%v]", errType)
+	}
+	b.WriteString(f.colorize(color, level))
+	if code, ok := entry.Data["error_code"]; ok {
+		fmt.Fprintf(&b, " %v", code)
+	}
+	b.WriteByte(' ')
+	b.WriteString(entry.Message)
+	b.WriteByte('\n')
+
+	// Field còn lại (trừ các field đã in ở dòng đầu hoặc in thành block riêng)
+	flat := make(map[string]string, len(entry.Data))
+	for k, v := range entry.Data {
+		switch k {
+		case "error_type", "error_code", "call_chain", "data":
+			continue
+		}
+		flattenLogfmt(k, v, flat)
+	}
+	if len(flat) > 0 {
+		b.WriteString("    ")
+		b.WriteString(f.colorize(ansiDim, logfmtLine(flat)))
+		b.WriteByte('\n')
+	}
+
+	if data, ok := entry.Data["data"]; ok {
+		flatData := make(map[string]string)
+		if m, ok := data.(map[string]interface{}); ok {
+			for k, v := range m {
+				flattenLogfmt(k, v, flatData)
+			}
+		} else {
+			flattenLogfmt("data", data, flatData)
+		}
+		if len(flatData) > 0 {
+			b.WriteString("    data: ")
+			b.WriteString(logfmtLine(flatData))
+			b.WriteByte('\n')
+		}
+	}
+
+	if chain, ok := entry.Data["call_chain"].([]string); ok && len(chain) > 0 {
+		b.WriteString("    call_chain:\n")
+		for _, frame := range chain {
+			b.WriteString("      ")
+			b.WriteString(f.colorize(color, "→"))
+			b.WriteByte(' ')
+			b.WriteString(frame)
+			b.WriteByte('\n')
+		}
+	}
+
+	return b.Bytes(), nil
+}
+
+// colorize bọc s bằng mã màu ANSI (trừ khi disableColors)
+func (f *prettyConsoleFormatter) colorize(color, s string) string {
+	if f.disableColors {
+		return s
+	}
+	return color + s + ansiReset
+}
+
+// levelColor trả về màu ANSI theo log level
+func levelColor(level logrus.Level) string {
+	switch level {
+	case logrus.TraceLevel, logrus.DebugLevel:
+		return ansiGray
+	case logrus.InfoLevel:
+		return ansiBlue
+	case logrus.WarnLevel:
+		return ansiYellow
+	default:
+		return ansiRed
+	}
+}
+
+// logfmtLine nối các field đã flatten thành "k=v k=v" (sort theo key, quote như logfmt)
+func logfmtLine(flat map[string]string) string {
+	keys := make([]string, 0, len(flat))
+	for k := range flat {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b bytes.Buffer
+	for _, k := range keys {
+		writeLogfmtPair(&b, k, flat[k])
+	}
+	return b.String()
+}
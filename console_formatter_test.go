@@ -0,0 +1,100 @@
+package goerrorkit
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// formatPrettyConsole format một entry với thời gian cố định
+func formatPrettyConsole(t *testing.T, f *prettyConsoleFormatter, level logrus.Level, fields logrus.Fields) string {
+	t.Helper()
+	entry := &logrus.Entry{
+		Time:    time.Date(2025, 11, 28, 10, 0, 0, 0, time.UTC),
+		Level:   level,
+		Message: "Internal server error",
+		Data:    fields,
+	}
+	out, err := f.Format(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestPrettyConsoleLayout(t *testing.T) {
+	f := &prettyConsoleFormatter{timestampFormat: "2006-01-02 15:04:05", disableColors: true}
+	out := formatPrettyConsole(t, f, logrus.ErrorLevel, logrus.Fields{
+		"error_type": "SYSTEM",
+		"error_code": "DB_DOWN",
+		"path":       "GET /orders/42",
+		"cause":      "connection refused",
+		"data":       map[string]interface{}{"order_id": 42, "user": map[string]interface{}{"id": 7}},
+		"call_chain": []string{"main.main (main.go:25)", "main.handler (main.go:40)"},
+	})
+
+	want := "2025-11-28 10:00:00 ERROR [SYSTEM] DB_DOWN Internal server error\n" +
+		"    cause=\"connection refused\" path=\"GET /orders/42\"\n" +
+		"    data: order_id=42 user.id=7\n" +
+		"    call_chain:\n" +
+		"      → main.main (main.go:25)\n" +
+		"      → main.handler (main.go:40)\n"
+	if out != want {
+		t.Errorf("output =\n%s\nwant\n%s", out, want)
+	}
+}
+
+func TestPrettyConsoleMinimalEntry(t *testing.T) {
+	f := &prettyConsoleFormatter{timestampFormat: time.RFC3339, disableColors: true}
+	out := formatPrettyConsole(t, f, logrus.WarnLevel, nil)
+	if want := "2025-11-28T10:00:00Z WARNING Internal server error\n"; out != want {
+		t.Errorf("output = %q, want %q", out, want)
+	}
+}
+
+func TestPrettyConsoleColors(t *testing.T) {
+	tests := []struct {
+		level logrus.Level
+		color string
+	}{
+		{logrus.DebugLevel, ansiGray},
+		{logrus.InfoLevel, ansiBlue},
+		{logrus.WarnLevel, ansiYellow},
+		{logrus.ErrorLevel, ansiRed},
+		{logrus.PanicLevel, ansiRed},
+	}
+	f := &prettyConsoleFormatter{timestampFormat: "15:04:05"}
+	for _, tt := range tests {
+		out := formatPrettyConsole(t, f, tt.level, logrus.Fields{"error_type": "SYSTEM"})
+		level := strings.ToUpper(tt.level.String()) + " [SYSTEM]"
+		if !strings.Contains(out, tt.color+level+ansiReset) {
+			t.Errorf("%s: output = %q, want level colored %q", tt.level, out, tt.color)
+		}
+	}
+}
+
+func TestPrettyConsoleNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	out := formatPrettyConsole(t, newPrettyConsoleFormatter(""), logrus.ErrorLevel, logrus.Fields{"path": "GET /orders"})
+	if strings.Contains(out, "\x1b[") {
+		t.Errorf("output = %q, want no ANSI codes with NO_COLOR", out)
+	}
+	if !strings.HasPrefix(out, "2025-11-28 10:00:00 ERROR ") {
+		t.Errorf("output = %q, want default timestamp format", out)
+	}
+}
+
+func TestPrettyConsoleOption(t *testing.T) {
+	logger, _ := newTestLogrusLogger(t, LoggerOptions{ConsoleOutput: true, PrettyConsole: true})
+	if _, ok := logger.consoleLogger.Formatter.(*prettyConsoleFormatter); !ok {
+		t.Errorf("console formatter = %T, want prettyConsoleFormatter", logger.consoleLogger.Formatter)
+	}
+
+	// PrettyConsole chỉ áp dụng cho format text
+	logger, _ = newTestLogrusLogger(t, LoggerOptions{ConsoleOutput: true, PrettyConsole: true, Format: "json"})
+	if _, ok := logger.consoleLogger.Formatter.(*prettyConsoleFormatter); ok {
+		t.Error("PrettyConsole applied to JSON console")
+	}
+}
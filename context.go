@@ -1,8 +1,14 @@
 package goerrorkit
 
+import "context"
+
 // HTTPContext là interface trừu tượng cho HTTP context
 // Cho phép thư viện hoạt động với bất kỳ web framework nào
 // Framework-specific adapters sẽ implement interface này
+//
+// Capability thêm sau (đọc header, query, client IP, set header, ...) là các interface optional
+// bên dưới, được kiểm tra bằng type assertion, thay vì method mới của HTTPContext:
+// HTTPContext do user tự implement vẫn compile khi nâng version
 type HTTPContext interface {
 	// Method trả về HTTP method (GET, POST, etc.)
 	Method() string
@@ -19,3 +25,83 @@ type HTTPContext interface {
 	// JSON gửi JSON response
 	JSON(data interface{}) error
 }
+
+// HeaderSetter là interface optional cho HTTPContext hỗ trợ set response header
+// Tách riêng để không break các HTTPContext implementation hiện có
+type HeaderSetter interface {
+	// SetHeader set response header
+	SetHeader(key, value string)
+}
+
+// HeaderGetter là interface optional cho HTTPContext hỗ trợ đọc request header
+// Dùng cho content negotiation (Accept) và các tính năng cần thông tin request
+// Tên là GetHeader chứ không phải Header: adapter bọc http.ResponseWriter (net/http, chi, ...)
+// thường đã có Header() http.Header, method Header(string) string sẽ trùng tên
+type HeaderGetter interface {
+	// GetHeader trả về giá trị request header (chuỗi rỗng nếu không có)
+	GetHeader(key string) string
+}
+
+// BodySender là interface optional cho HTTPContext hỗ trợ gửi raw body với Content-Type tùy ý
+// Dùng bởi ResponseWriter không phải JSON thuần (problem+json, XML, HTML, ...)
+type BodySender interface {
+	// SendBody gửi body với Content-Type cho trước
+	SendBody(contentType string, body []byte) error
+}
+
+// QueryGetter là interface optional cho HTTPContext hỗ trợ đọc query parameter
+type QueryGetter interface {
+	// Query trả về giá trị query parameter (chuỗi rỗng nếu không có)
+	Query(name string) string
+}
+
+// ClientIPGetter là interface optional cho HTTPContext hỗ trợ lấy IP của client
+// Dùng cho field client_ip trong log (xem LoggerOptions.IncludeClientInfo)
+// Tên ClientIP (như Gin) khớp với field client_ip và rõ nghĩa hơn IP trên một interface nhỏ
+type ClientIPGetter interface {
+	// ClientIP trả về IP của client (tôn trọng cấu hình proxy của framework)
+	ClientIP() string
+}
+
+// RequestContextGetter là interface optional cho HTTPContext cung cấp context của request
+// Dùng để nhận biết client đã hủy request (xem IsClientDisconnect)
+type RequestContextGetter interface {
+	// RequestContext trả về context bị cancel khi client hủy request/đóng connection
+	RequestContext() context.Context
+}
+
+// UpgradeStateGetter là interface optional cho HTTPContext báo connection đã được upgrade/hijack
+// Dùng bởi IsUpgradedConnection để không ghi error response vào connection WebSocket
+type UpgradeStateGetter interface {
+	// Upgraded trả về true nếu response đã là 101 Switching Protocols hoặc connection đã bị hijack
+	Upgraded() bool
+}
+
+// LogFieldsProvider là interface optional cho HTTPContext cung cấp thêm field cho error log
+// của request (raw_path, status, latency, ... do adapter ghi nhận)
+type LogFieldsProvider interface {
+	// LogFields trả về field được thêm vào error log (nil nếu không có)
+	LogFields() map[string]interface{}
+}
+
+// contextLogFields gộp field của LogFieldsProvider với extra (extra được ưu tiên khi trùng key)
+// Không sửa map extra gốc
+func contextLogFields(ctx HTTPContext, extra map[string]interface{}) map[string]interface{} {
+	provider, ok := ctx.(LogFieldsProvider)
+	if !ok {
+		return extra
+	}
+	ctxFields := provider.LogFields()
+	if len(ctxFields) == 0 {
+		return extra
+	}
+
+	fields := make(map[string]interface{}, len(ctxFields)+len(extra))
+	for k, v := range ctxFields {
+		fields[k] = v
+	}
+	for k, v := range extra {
+		fields[k] = v
+	}
+	return fields
+}
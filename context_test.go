@@ -0,0 +1,100 @@
+package goerrorkit
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// testContext là HTTPContext in-memory cho test: ghi lại status, header và body đã gửi
+type testContext struct {
+	method  string
+	path    string
+	locals  map[string]interface{}
+	headers map[string]string // request headers
+
+	status      int
+	respHeaders map[string]string
+	body        []byte
+	jsonCalls   int
+}
+
+func newTestContext(method, path string) *testContext {
+	return &testContext{
+		method:      method,
+		path:        path,
+		locals:      map[string]interface{}{},
+		headers:     map[string]string{},
+		respHeaders: map[string]string{},
+	}
+}
+
+func (c *testContext) Method() string                  { return c.method }
+func (c *testContext) Path() string                    { return c.path }
+func (c *testContext) GetLocal(key string) interface{} { return c.locals[key] }
+func (c *testContext) GetHeader(key string) string     { return c.headers[key] }
+func (c *testContext) SetHeader(key, value string)     { c.respHeaders[key] = value }
+
+func (c *testContext) Status(code int) HTTPContext {
+	c.status = code
+	return c
+}
+
+func (c *testContext) JSON(data interface{}) error {
+	c.jsonCalls++
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	c.body = body
+	return nil
+}
+
+// response decode body JSON đã gửi
+func (c *testContext) response() map[string]interface{} {
+	var out map[string]interface{}
+	_ = json.Unmarshal(c.body, &out)
+	return out
+}
+
+// logFieldsContext là testContext implement LogFieldsProvider
+type logFieldsContext struct {
+	*testContext
+	fields map[string]interface{}
+}
+
+func (c *logFieldsContext) LogFields() map[string]interface{} { return c.fields }
+
+func TestLogAndRespondMergesContextLogFields(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	ctx := &logFieldsContext{
+		testContext: newTestContext("GET", "/users/42"),
+		fields:      map[string]interface{}{"raw_path": "/users/42", "status": 404, "latency_ms": int64(3)},
+	}
+	LogAndRespond(ctx, NewBusinessError(404, "User not found"), "GET /users/:id")
+
+	entry, ok := mem.Find("", "User not found")
+	if !ok {
+		t.Fatalf("error not logged: %+v", mem.Entries())
+	}
+	if entry.Fields["path"] != "GET /users/:id" || entry.Fields["raw_path"] != "/users/42" || entry.Fields["status"] != 404 {
+		t.Errorf("fields = %v, want context log fields merged with route path", entry.Fields)
+	}
+}
+
+func TestContextLogFieldsExtraWins(t *testing.T) {
+	ctx := &logFieldsContext{testContext: newTestContext("GET", "/"), fields: map[string]interface{}{"status": 200, "raw_path": "/"}}
+	extra := map[string]interface{}{"status": 499}
+
+	fields := contextLogFields(ctx, extra)
+	if fields["status"] != 499 || fields["raw_path"] != "/" {
+		t.Errorf("fields = %v, want extra to override provider fields", fields)
+	}
+	if len(extra) != 1 {
+		t.Errorf("extra mutated: %v", extra)
+	}
+	if got := contextLogFields(newTestContext("GET", "/"), extra); len(got) != 1 || got["status"] != 499 {
+		t.Errorf("without provider = %v, want extra unchanged", got)
+	}
+}
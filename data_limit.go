@@ -0,0 +1,79 @@
+package goerrorkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync/atomic"
+)
+
+// maxDataBytes giới hạn kích thước (JSON) của field "data" khi log, 0 = không giới hạn
+var maxDataBytes atomic.Int64
+
+// SetMaxDataBytes giới hạn kích thước serialized (JSON) của Data khi LogError ghi log
+// Khi vượt giới hạn, các value lớn nhất lần lượt bị thay bằng "[truncated: X bytes]"
+// cho tới khi vừa giới hạn, metadata nhỏ (product_id, user_id, ...) được giữ nguyên
+// Chỉ ảnh hưởng log, không sửa AppError.Data (debug response vẫn thấy dữ liệu gốc)
+// n <= 0 → tắt giới hạn (mặc định)
+//
+// Example:
+//
+//	goerrorkit.SetMaxDataBytes(8 * 1024)
+//	// .WithData(map[string]interface{}{"file": twoMB, "order_id": 42})
+//	// log: {"data": {"file": "[truncated: 2097154 bytes]", "order_id": 42}, ...}
+func SetMaxDataBytes(n int) {
+	if n < 0 {
+		n = 0
+	}
+	maxDataBytes.Store(int64(n))
+}
+
+// limitData thay các value lớn nhất của data bằng placeholder khi tổng kích thước vượt SetMaxDataBytes
+// data phải là bản copy (đã redact) vì bị sửa trực tiếp
+func limitData(data map[string]interface{}) map[string]interface{} {
+	limit := int(maxDataBytes.Load())
+	if limit <= 0 || len(data) == 0 {
+		return data
+	}
+
+	total := serializedSize(data)
+	if total <= limit {
+		return data
+	}
+
+	sizes := make(map[string]int, len(data))
+	keys := make([]string, 0, len(data))
+	for k, v := range data {
+		sizes[k] = serializedSize(v)
+		keys = append(keys, k)
+	}
+	// Value lớn nhất bị thay trước; cùng kích thước thì theo key để kết quả ổn định
+	sort.Slice(keys, func(i, j int) bool {
+		if sizes[keys[i]] != sizes[keys[j]] {
+			return sizes[keys[i]] > sizes[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	for _, k := range keys {
+		if total <= limit {
+			break
+		}
+		placeholder := fmt.Sprintf("[truncated: %d bytes]", sizes[k])
+		saved := sizes[k] - serializedSize(placeholder)
+		if saved <= 0 {
+			continue
+		}
+		data[k] = placeholder
+		total -= saved
+	}
+	return data
+}
+
+// serializedSize trả về kích thước JSON của v (fallback fmt khi không encode được)
+func serializedSize(v interface{}) int {
+	if b, err := json.Marshal(v); err == nil {
+		return len(b)
+	}
+	return len(fmt.Sprint(v))
+}
@@ -0,0 +1,80 @@
+package goerrorkit
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// loggedData log appErr bằng MemoryLogger và trả về field "data" của entry
+func loggedData(t *testing.T, appErr *AppError) map[string]interface{} {
+	t.Helper()
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	LogError(appErr, "POST /uploads")
+	entries := mem.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("entries = %v, want 1", entries)
+	}
+	data, _ := entries[0].Fields["data"].(map[string]interface{})
+	return data
+}
+
+func TestSetMaxDataBytesTruncatesLargestValue(t *testing.T) {
+	SetMaxDataBytes(200)
+	defer SetMaxDataBytes(0)
+
+	file := strings.Repeat("x", 5000)
+	notes := strings.Repeat("y", 100)
+	appErr := NewSystemError(errors.New("upload failed")).WithData(map[string]interface{}{
+		"file":     file,
+		"notes":    notes,
+		"order_id": 42,
+	})
+
+	data := loggedData(t, appErr)
+	if got := data["file"]; got != "[truncated: 5002 bytes]" {
+		t.Errorf("file = %v, want truncated placeholder", got)
+	}
+	if data["notes"] != notes || data["order_id"] != 42 {
+		t.Errorf("smaller values should be kept: notes=%v order_id=%v", data["notes"], data["order_id"])
+	}
+	if serializedSize(data) > 200 {
+		t.Errorf("logged data is %d bytes, want <= 200", serializedSize(data))
+	}
+	if appErr.Data["file"] != file {
+		t.Error("AppError.Data must keep the original value")
+	}
+}
+
+func TestSetMaxDataBytesTruncatesUntilWithinLimit(t *testing.T) {
+	SetMaxDataBytes(120)
+	defer SetMaxDataBytes(0)
+
+	data := loggedData(t, NewSystemError(nil).WithData(map[string]interface{}{
+		"request":  strings.Repeat("r", 300),
+		"response": strings.Repeat("s", 400),
+		"user_id":  "u-1",
+	}))
+	want := map[string]interface{}{
+		"request":  "[truncated: 302 bytes]",
+		"response": "[truncated: 402 bytes]",
+		"user_id":  "u-1",
+	}
+	for k, v := range want {
+		if data[k] != v {
+			t.Errorf("%s = %v, want %v", k, data[k], v)
+		}
+	}
+}
+
+func TestSetMaxDataBytesDisabled(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		SetMaxDataBytes(n)
+		big := strings.Repeat("x", 5000)
+		if data := loggedData(t, NewSystemError(nil).WithData(map[string]interface{}{"file": big})); data["file"] != big {
+			t.Errorf("SetMaxDataBytes(%d) should not truncate, got %v", n, data["file"])
+		}
+	}
+}
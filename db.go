@@ -0,0 +1,65 @@
+package goerrorkit
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+)
+
+// transientDBErrors là các lỗi connection pool có thể retry bằng cách lấy connection mới
+var transientDBErrors = []error{
+	sql.ErrConnDone,
+	driver.ErrBadConn,
+}
+
+// WrapDBError đóng gói database error thành SystemError với stack trace tự động
+// Lỗi connection pool (sql.ErrConnDone, driver.ErrBadConn) được đánh dấu Retryable
+// và Details["transient"] = true để retry layer biết cần re-acquire connection
+//
+// Example:
+//
+//	if err := db.QueryRowContext(ctx, query, id).Scan(&user); err != nil {
+//	    appErr := goerrorkit.WrapDBError(err).WithQuery(query, id)
+//	    if appErr.Retryable {
+//	        // retry với connection mới
+//	    }
+//	    return appErr
+//	}
+func WrapDBError(err error) *AppError {
+	if err == nil {
+		return nil
+	}
+	file, line, function := getCallerInfo(1)
+	return newWrapError(err, err.Error(), file, line, function)
+}
+
+// IsRetryable kiểm tra error (hoặc AppError trong chain) có thể retry không
+func IsRetryable(err error) bool {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr.Retryable
+	}
+	return isTransientDBError(err)
+}
+
+// isTransientDBError kiểm tra err có phải lỗi connection pool tạm thời không
+func isTransientDBError(err error) bool {
+	for _, target := range transientDBErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// markTransient đánh dấu AppError là retryable nếu cause là lỗi connection tạm thời
+func markTransient(appErr *AppError) {
+	if appErr.Cause == nil || !isTransientDBError(appErr.Cause) {
+		return
+	}
+	appErr.Retryable = true
+	if appErr.Details == nil {
+		appErr.Details = make(map[string]interface{})
+	}
+	appErr.Details["transient"] = true
+}
@@ -0,0 +1,53 @@
+package goerrorkit
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestWrapDBErrorTransient(t *testing.T) {
+	tests := map[string]error{
+		"sql.ErrConnDone":     sql.ErrConnDone,
+		"driver.ErrBadConn":   driver.ErrBadConn,
+		"wrapped ErrConnDone": fmt.Errorf("query users: %w", sql.ErrConnDone),
+		"wrapped ErrBadConn":  fmt.Errorf("exec: %w", driver.ErrBadConn),
+	}
+	for name, err := range tests {
+		for wrapName, wrap := range map[string]func(error) *AppError{"WrapDBError": WrapDBError, "Wrap": Wrap} {
+			appErr := wrap(err)
+			if !appErr.Retryable || appErr.Details["transient"] != true {
+				t.Errorf("%s(%s): Retryable = %v, transient = %v, want retryable transient", wrapName, name, appErr.Retryable, appErr.Details["transient"])
+			}
+			if appErr.Type != SystemError || !errors.Is(appErr, err) {
+				t.Errorf("%s(%s) = %v (%s), want SystemError wrapping cause", wrapName, name, appErr, appErr.Type)
+			}
+			if !IsRetryable(appErr) {
+				t.Errorf("IsRetryable(%s(%s)) = false", wrapName, name)
+			}
+		}
+		if !IsRetryable(err) {
+			t.Errorf("IsRetryable(%s) = false for plain error", name)
+		}
+	}
+}
+
+func TestWrapDBErrorNotTransient(t *testing.T) {
+	for _, err := range []error{sql.ErrNoRows, sql.ErrTxDone, errors.New("syntax error at or near")} {
+		appErr := WrapDBError(err)
+		if appErr.Retryable {
+			t.Errorf("WrapDBError(%v).Retryable = true", err)
+		}
+		if _, ok := appErr.Details["transient"]; ok {
+			t.Errorf("WrapDBError(%v) marked transient", err)
+		}
+		if IsRetryable(err) {
+			t.Errorf("IsRetryable(%v) = true", err)
+		}
+	}
+	if WrapDBError(nil) != nil {
+		t.Error("WrapDBError(nil) != nil")
+	}
+}
@@ -0,0 +1,247 @@
+package goerrorkit
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultDedupMaxKeys là số fingerprint tối đa được theo dõi cùng lúc (giới hạn memory)
+const defaultDedupMaxKeys = 10000
+
+// DedupOptions cấu hình dedup cho LogError: lần xuất hiện đầu tiên của một fingerprint được log ngay,
+// các lần lặp lại trong Window bị suppress và một summary record được log khi window kết thúc
+// (background flush mỗi FlushInterval, hoặc ngay khi gọi FlushLogs/CloseLogger)
+type DedupOptions struct {
+	// Window - Khoảng thời gian gộp các error giống nhau
+	Window time.Duration
+
+	// KeepConsole - Console vẫn nhận mọi entry, chỉ file log bị dedup
+	// (cần logger implement ConsoleLogger, LogrusLogger có hỗ trợ)
+	KeepConsole bool
+
+	// MaxKeys - Số fingerprint tối đa được theo dõi (mặc định 10000). Khi đầy, error mới không bị dedup
+	MaxKeys int
+
+	// KeyFunc - Tính fingerprint cho AppError (mặc định: ErrorType + Code + file:line + Message)
+	KeyFunc func(appErr *AppError) string
+
+	// FlushInterval - Chu kỳ background flush emit summary của window đã kết thúc (mặc định 1 giây)
+	FlushInterval time.Duration
+
+	// Clock - Nguồn thời gian, inject được cho test (mặc định time.Now)
+	Clock func() time.Time
+}
+
+// ConsoleLogger là interface optional cho Logger hỗ trợ chỉ ghi ra console
+// Dùng bởi DedupOptions.KeepConsole để entry bị dedup vẫn hiện trên console
+type ConsoleLogger interface {
+	LogConsole(level string, msg string, fields map[string]interface{})
+}
+
+// dedupEntry theo dõi một fingerprint trong window hiện tại
+type dedupEntry struct {
+	level       string
+	message     string
+	windowStart time.Time
+	first       time.Time
+	last        time.Time
+	repeats     int
+}
+
+// dedupSummary là thông tin summary của một fingerprint đã hết window
+type dedupSummary struct {
+	key     string
+	level   string
+	message string
+	repeats int
+	first   time.Time
+	last    time.Time
+}
+
+// deduper áp dụng DedupOptions, an toàn khi dùng đồng thời
+type deduper struct {
+	opts DedupOptions
+
+	mu        sync.Mutex
+	entries   map[string]*dedupEntry
+	lastSweep time.Time
+
+	stop chan struct{} // đóng khi deduper bị thay thế (dừng background flush)
+}
+
+var (
+	deduperMu      sync.RWMutex
+	defaultDeduper *deduper
+)
+
+// SetDedup bật dedup cho LogError (truyền nil hoặc Window <= 0 để tắt)
+// InitLogger gọi SetDedup từ LoggerOptions.DedupWindow/DedupKeepConsole khi DedupWindow > 0
+// Summary đang chờ của deduper cũ được emit ngay khi bị thay thế
+//
+// Example:
+//
+//	goerrorkit.SetDedup(&goerrorkit.DedupOptions{
+//	    Window:      time.Minute,
+//	    KeepConsole: true,
+//	})
+func SetDedup(opts *DedupOptions) {
+	var d *deduper
+	if opts != nil && opts.Window > 0 {
+		d = &deduper{
+			opts:    *opts,
+			entries: make(map[string]*dedupEntry),
+			stop:    make(chan struct{}),
+		}
+		if d.opts.MaxKeys <= 0 {
+			d.opts.MaxKeys = defaultDedupMaxKeys
+		}
+		if d.opts.KeyFunc == nil {
+			d.opts.KeyFunc = defaultDedupKey
+		}
+		if d.opts.FlushInterval <= 0 {
+			d.opts.FlushInterval = defaultSummaryFlushInterval
+		}
+		if d.opts.Clock == nil {
+			d.opts.Clock = time.Now
+		}
+		go runSummaryFlusher(d.opts.FlushInterval, d.stop, d.flushExpired)
+	}
+
+	deduperMu.Lock()
+	prev := defaultDeduper
+	defaultDeduper = d
+	deduperMu.Unlock()
+
+	if prev != nil {
+		close(prev.stop)
+		prev.flushPending()
+	}
+}
+
+// getDeduper trả về deduper hiện tại (nil nếu không bật)
+func getDeduper() *deduper {
+	deduperMu.RLock()
+	defer deduperMu.RUnlock()
+	return defaultDeduper
+}
+
+// defaultDedupKey là fingerprint mặc định: ErrorType + Code + file:line + Message
+func defaultDedupKey(appErr *AppError) string {
+	return defaultSampleKey(appErr) + "|" + appErr.Message
+}
+
+// allow quyết định có log AppError không (false: lặp lại trong window)
+// Trả về thêm các summary của những fingerprint đã hết window
+func (d *deduper) allow(appErr *AppError, level string) (bool, []dedupSummary) {
+	now := d.opts.Clock()
+	key := d.opts.KeyFunc(appErr)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	summaries := d.sweep(now, false)
+
+	if entry, exists := d.entries[key]; exists {
+		entry.repeats++
+		entry.last = now
+		return false, summaries
+	}
+
+	// Giới hạn memory: sweep ngay khi đầy, nếu vẫn đầy thì log mà không theo dõi
+	if len(d.entries) >= d.opts.MaxKeys {
+		summaries = append(summaries, d.sweep(now, true)...)
+		if len(d.entries) >= d.opts.MaxKeys {
+			return true, summaries
+		}
+	}
+
+	d.entries[key] = &dedupEntry{
+		level:       level,
+		message:     appErr.Message,
+		windowStart: now,
+		first:       now,
+		last:        now,
+	}
+	return true, summaries
+}
+
+// sweep xóa các fingerprint đã hết window và trả về summary cho các fingerprint bị lặp lại
+// Chạy tối đa một lần mỗi giây (trừ khi force)
+func (d *deduper) sweep(now time.Time, force bool) []dedupSummary {
+	if !force && now.Sub(d.lastSweep) < time.Second {
+		return nil
+	}
+	d.lastSweep = now
+
+	var summaries []dedupSummary
+	for key, entry := range d.entries {
+		if now.Sub(entry.windowStart) < d.opts.Window {
+			continue
+		}
+		if entry.repeats > 0 {
+			summaries = append(summaries, dedupSummary{
+				key:     key,
+				level:   entry.level,
+				message: entry.message,
+				repeats: entry.repeats,
+				first:   entry.first,
+				last:    entry.last,
+			})
+		}
+		delete(d.entries, key)
+	}
+	return summaries
+}
+
+// flushExpired emit summary của các fingerprint đã hết window (gọi bởi background flush)
+func (d *deduper) flushExpired() {
+	now := d.opts.Clock()
+	d.mu.Lock()
+	summaries := d.sweep(now, true)
+	d.mu.Unlock()
+	emitDedupSummaries(summaries)
+}
+
+// flushPending emit summary cho mọi fingerprint đang có lần lặp bị suppress, kể cả khi window
+// chưa kết thúc (FlushLogs, CloseLogger). Fingerprint được giữ lại nên vẫn bị dedup tới hết window
+func (d *deduper) flushPending() {
+	d.mu.Lock()
+	var summaries []dedupSummary
+	for key, entry := range d.entries {
+		if entry.repeats == 0 {
+			continue
+		}
+		summaries = append(summaries, dedupSummary{
+			key:     key,
+			level:   entry.level,
+			message: entry.message,
+			repeats: entry.repeats,
+			first:   entry.first,
+			last:    entry.last,
+		})
+		entry.repeats = 0
+		entry.first = entry.last
+	}
+	d.mu.Unlock()
+	emitDedupSummaries(summaries)
+}
+
+// emitDedupSummaries log summary record cho các fingerprint bị lặp lại
+// Ví dụ: "Database unavailable (repeated 1,284 times between 10:00:01 and 10:00:59)"
+func emitDedupSummaries(summaries []dedupSummary) {
+	if len(summaries) == 0 || defaultLogger == nil {
+		return
+	}
+	for _, summary := range summaries {
+		logAtLevel(summary.level, fmt.Sprintf("%s (repeated %s times between %s and %s)",
+			summary.message, formatThousands(summary.repeats),
+			summary.first.Format(time.RFC3339), summary.last.Format(time.RFC3339)),
+			map[string]interface{}{
+				"dedup_key":     summary.key,
+				"repeated":      summary.repeats,
+				"first_seen_at": summary.first.Format(time.RFC3339Nano),
+				"last_seen_at":  summary.last.Format(time.RFC3339Nano),
+			})
+	}
+}
@@ -0,0 +1,131 @@
+package goerrorkit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func logDatabaseDown(n int) {
+	for i := 0; i < n; i++ {
+		LogError(NewSystemError(errors.New("connection refused")), "GET /orders")
+	}
+}
+
+func TestDedupSuppressesRepeatsAndSummarizes(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+	clock := newFakeClock()
+	SetDedup(&DedupOptions{Window: time.Minute, Clock: clock.Now, FlushInterval: time.Hour})
+	defer SetDedup(nil)
+
+	logDatabaseDown(1)
+	clock.Advance(10 * time.Second)
+	logDatabaseDown(1284)
+	if got := len(mem.Entries()); got != 1 {
+		t.Fatalf("logged %d entries inside window, want only the first", got)
+	}
+
+	clock.Advance(time.Minute)
+	getDeduper().flushExpired()
+
+	summary := findEntries(mem, "repeated")
+	want := "Internal server error (repeated 1,284 times between 2025-11-28T10:00:00Z and 2025-11-28T10:00:10Z)"
+	if len(summary) != 1 || summary[0].Message != want || summary[0].Level != "error" {
+		t.Fatalf("summary = %+v, want %q", summary, want)
+	}
+
+	// Window mới: lần xuất hiện đầu được log ngay
+	logDatabaseDown(1)
+	if got := len(findEntries(mem, "Internal server error")); got != 3 {
+		t.Errorf("got %d entries after window closed, want first + summary + new first", got)
+	}
+}
+
+func TestDedupBackgroundFlush(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+	clock := newFakeClock()
+	SetDedup(&DedupOptions{Window: time.Minute, Clock: clock.Now, FlushInterval: 5 * time.Millisecond})
+	defer SetDedup(nil)
+
+	logDatabaseDown(3)
+	clock.Advance(time.Minute)
+
+	entry := waitForEntry(t, mem, "repeated")
+	if entry.Fields["repeated"] != 2 {
+		t.Errorf("repeated = %v, want 2", entry.Fields["repeated"])
+	}
+}
+
+func TestDedupFlushOnFlushLogsAndCloseLogger(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+	clock := newFakeClock()
+	SetDedup(&DedupOptions{Window: time.Hour, Clock: clock.Now, FlushInterval: time.Hour})
+	defer SetDedup(nil)
+
+	logDatabaseDown(5)
+	if err := FlushLogs(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if summary := findEntries(mem, "repeated"); len(summary) != 1 || summary[0].Fields["repeated"] != 4 {
+		t.Fatalf("summary after FlushLogs = %+v", summary)
+	}
+
+	// Fingerprint vẫn trong window: lần lặp tiếp theo vẫn bị suppress
+	logDatabaseDown(2)
+	if got := len(findEntries(mem, "Internal server error")); got != 2 {
+		t.Errorf("got %d entries, want repeats still suppressed", got)
+	}
+
+	if err := CloseLogger(); err != nil {
+		t.Fatal(err)
+	}
+	if summary := findEntries(mem, "repeated"); len(summary) != 2 || summary[1].Fields["repeated"] != 2 {
+		t.Errorf("summary after CloseLogger = %+v", summary)
+	}
+}
+
+func TestDedupMaxKeys(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+	SetDedup(&DedupOptions{
+		Window:        time.Minute,
+		MaxKeys:       2,
+		KeyFunc:       func(appErr *AppError) string { return appErr.Message },
+		Clock:         newFakeClock().Now,
+		FlushInterval: time.Hour,
+	})
+	defer SetDedup(nil)
+
+	for _, msg := range []string{"a", "b", "c", "c"} {
+		LogError(NewBusinessError(404, msg), "GET /")
+	}
+
+	d := getDeduper()
+	d.mu.Lock()
+	tracked := len(d.entries)
+	d.mu.Unlock()
+	if tracked != 2 {
+		t.Errorf("tracked %d fingerprints, want MaxKeys 2", tracked)
+	}
+	// "c" không được theo dõi nên không bị dedup
+	if got := len(findEntries(mem, "c")); got != 2 {
+		t.Errorf("untracked fingerprint logged %d times, want 2", got)
+	}
+}
+
+func TestSetDedupReplacementFlushesPending(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+	SetDedup(&DedupOptions{Window: time.Hour, Clock: newFakeClock().Now, FlushInterval: time.Hour})
+
+	logDatabaseDown(3)
+	SetDedup(nil)
+
+	if summary := findEntries(mem, "repeated"); len(summary) != 1 || summary[0].Fields["repeated"] != 2 {
+		t.Errorf("summary after SetDedup(nil) = %+v", summary)
+	}
+}
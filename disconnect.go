@@ -0,0 +1,91 @@
+package goerrorkit
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// StatusClientClosedRequest là status code cho request bị client hủy (quy ước của nginx)
+const StatusClientClosedRequest = 499
+
+// logClientDisconnects bật/tắt ghi log (level info) cho request bị client hủy, mặc định tắt
+var logClientDisconnects atomic.Bool
+
+// IsClientDisconnect kiểm tra err có phải do client hủy request/đóng connection không
+// Quyết định dựa trên trạng thái request: reqCtx (context của request) phải đã bị cancel,
+// và err là context.Canceled, broken pipe, connection reset hoặc ghi vào connection đã đóng.
+// ECONNRESET/EPIPE từ database hay upstream service khi request vẫn còn sống là lỗi thật,
+// không bị xếp vào client disconnect
+//
+// Example:
+//
+//	if goerrorkit.IsClientDisconnect(r.Context(), err) {
+//	    return // client đã đi, không cần response
+//	}
+func IsClientDisconnect(reqCtx context.Context, err error) bool {
+	if err == nil || reqCtx == nil || !errors.Is(reqCtx.Err(), context.Canceled) {
+		return false
+	}
+	return errors.Is(err, context.Canceled) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, net.ErrClosed)
+}
+
+// SetLogClientDisconnects bật/tắt ghi log (level info) cho request bị client hủy
+// Mặc định tắt: các request này được convert thành 499 và không log
+// Áp dụng cho ConvertToAppErrorCtx, WriteError và các adapter
+//
+// Example:
+//
+//	goerrorkit.SetLogClientDisconnects(true)
+func SetLogClientDisconnects(enabled bool) {
+	logClientDisconnects.Store(enabled)
+}
+
+// IsLogClientDisconnectsEnabled trả về trạng thái của SetLogClientDisconnects
+func IsLogClientDisconnectsEnabled() bool {
+	return logClientDisconnects.Load()
+}
+
+// ConvertToAppErrorCtx giống ConvertToAppError nhưng biết trạng thái request:
+// khi client đã hủy request (xem IsClientDisconnect) error được convert thành 499 level info
+// (chỉ log khi SetLogClientDisconnects(true)) thay vì 500 SystemError
+//
+// Example:
+//
+//	appErr := goerrorkit.ConvertToAppErrorCtx(r.Context(), err, requestID)
+func ConvertToAppErrorCtx(reqCtx context.Context, err error, requestID string) *AppError {
+	if IsClientDisconnect(reqCtx, err) {
+		return NewClientDisconnectError(err, requestID, logClientDisconnects.Load())
+	}
+	return ConvertToAppError(err, requestID)
+}
+
+// NewClientDisconnectError tạo AppError 499 level info cho request bị client hủy
+// logged == false → SkipLogging (vẫn có thể response nếu connection còn mở)
+// Dùng cho adapter đã tự kiểm tra IsClientDisconnect
+func NewClientDisconnectError(err error, requestID string, logged bool) *AppError {
+	appErr := (&AppError{
+		Type:      BusinessError,
+		Code:      StatusClientClosedRequest,
+		Message:   "Client closed request",
+		Cause:     err,
+		RequestID: requestID,
+		CreatedAt: time.Now(),
+	}).Level("info")
+	appErr.skipLog = !logged
+	return appErr
+}
+
+// requestContext trả về context của request nếu HTTPContext implement RequestContextGetter
+func requestContext(ctx HTTPContext) context.Context {
+	if getter, ok := ctx.(RequestContextGetter); ok {
+		return getter.RequestContext()
+	}
+	return nil
+}
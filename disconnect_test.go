@@ -0,0 +1,119 @@
+package goerrorkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+)
+
+// canceledContext trả về context đã bị cancel (giả lập client đóng connection)
+func canceledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+func TestIsClientDisconnect(t *testing.T) {
+	disconnectErrs := []error{
+		context.Canceled,
+		fmt.Errorf("write response: %w", syscall.EPIPE),
+		&net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET},
+		net.ErrClosed,
+	}
+	for _, err := range disconnectErrs {
+		if !IsClientDisconnect(canceledContext(), err) {
+			t.Errorf("IsClientDisconnect(canceled, %v) = false, want true", err)
+		}
+		if IsClientDisconnect(context.Background(), err) {
+			t.Errorf("IsClientDisconnect(live, %v) = true, want false", err)
+		}
+		if IsClientDisconnect(nil, err) {
+			t.Errorf("IsClientDisconnect(nil, %v) = true, want false", err)
+		}
+	}
+
+	if IsClientDisconnect(canceledContext(), errors.New("validation failed")) {
+		t.Error("unrelated error classified as client disconnect")
+	}
+	deadline, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	<-deadline.Done()
+	if IsClientDisconnect(deadline, context.Canceled) {
+		t.Error("deadline exceeded request classified as client disconnect")
+	}
+}
+
+func TestConvertToAppErrorUpstreamResetIsSystemError(t *testing.T) {
+	// ECONNRESET từ database khi request vẫn còn sống là sự cố thật
+	err := fmt.Errorf("query orders: %w", &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET})
+
+	for _, appErr := range []*AppError{
+		ConvertToAppError(err, "req-1"),
+		ConvertToAppErrorCtx(context.Background(), err, "req-1"),
+		ConvertToAppError(context.Canceled, "req-1"),
+	} {
+		if appErr.Type != SystemError || appErr.Code != 500 || appErr.skipLog {
+			t.Errorf("got %s %d skipLog=%v, want logged SYSTEM 500", appErr.Type, appErr.Code, appErr.skipLog)
+		}
+	}
+}
+
+func TestConvertToAppErrorCtxClientDisconnect(t *testing.T) {
+	appErr := ConvertToAppErrorCtx(canceledContext(), context.Canceled, "req-1")
+
+	if appErr.Code != StatusClientClosedRequest || appErr.GetLogLevel() != "info" {
+		t.Errorf("got %d level %s, want 499 info", appErr.Code, appErr.GetLogLevel())
+	}
+	if !appErr.skipLog {
+		t.Error("client disconnect logged by default")
+	}
+	if appErr.RequestID != "req-1" {
+		t.Errorf("RequestID = %q", appErr.RequestID)
+	}
+}
+
+func TestSetLogClientDisconnects(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	ctx := &requestCtxContext{testContext: newTestContext("GET", "/stream"), reqCtx: canceledContext()}
+	WriteError(ctx, context.Canceled, "GET /stream")
+	if len(mem.Entries()) != 0 {
+		t.Fatalf("client disconnect logged by default: %+v", mem.Entries())
+	}
+	if ctx.status != StatusClientClosedRequest {
+		t.Errorf("status = %d, want 499", ctx.status)
+	}
+
+	SetLogClientDisconnects(true)
+	defer SetLogClientDisconnects(false)
+
+	WriteError(ctx, context.Canceled, "GET /stream")
+	entries := mem.Entries()
+	if len(entries) != 1 || entries[0].Level != "info" {
+		t.Fatalf("entries = %+v, want one info entry", entries)
+	}
+}
+
+func TestWriteErrorWithoutRequestContext(t *testing.T) {
+	UseMemoryLogger()
+	defer SetLogger(nil)
+
+	// HTTPContext không implement RequestContextGetter: không đoán client disconnect từ kiểu error
+	ctx := newTestContext("GET", "/orders")
+	WriteError(ctx, syscall.EPIPE, "GET /orders")
+	if ctx.status != 500 {
+		t.Errorf("status = %d, want 500", ctx.status)
+	}
+}
+
+// requestCtxContext là testContext implement RequestContextGetter
+type requestCtxContext struct {
+	*testContext
+	reqCtx context.Context
+}
+
+func (c *requestCtxContext) RequestContext() context.Context { return c.reqCtx }
@@ -0,0 +1,180 @@
+package goerrorkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ecsVersion là phiên bản Elastic Common Schema mà ecsFormatter tuân theo
+const ecsVersion = "8.11.0"
+
+// ecsFieldNames map field của goerrorkit sang field ECS tương ứng
+// Field cần xử lý riêng (path, file, call_chain, latency_ms, data) nằm trong ecsFormatter.Format
+var ecsFieldNames = map[string]string{
+	"error_type":   "error.type",
+	"error_code":   "error.code",
+	"request_id":   "http.request.id",
+	"trace_id":     "trace.id",
+	"span_id":      "span.id",
+	"raw_path":     "url.path",
+	"status":       "http.response.status_code",
+	"client_ip":    "client.ip",
+	"user_agent":   "user_agent.original",
+	"hostname":     "host.hostname",
+	"pid":          "process.pid",
+	"service.name": "service.name",
+	"function":     "log.origin.function",
+}
+
+// ecsFormatter là logrus.Formatter ghi log theo Elastic Common Schema (LoggerOptions.Format = "ecs")
+//   - Field chuẩn được đổi tên: error.type, error.message, error.stack_trace, http.request.method,
+//     url.path, http.request.id, trace.id, log.origin.*, host.hostname, ...
+//   - Data của AppError nằm trong labels.* (giá trị không phải string được encode JSON)
+//   - Field riêng của goerrorkit (severity, ref, panic_*, cause, ...) nằm trong goerrorkit.*
+type ecsFormatter struct {
+	timestampFormat string
+	prettyPrint     bool
+}
+
+// newECSFormatter tạo ECS formatter (timestampFormat rỗng → RFC3339Nano)
+func newECSFormatter(timestampFormat string, prettyPrint bool) *ecsFormatter {
+	if timestampFormat == "" {
+		timestampFormat = time.RFC3339Nano
+	}
+	return &ecsFormatter{timestampFormat: timestampFormat, prettyPrint: prettyPrint}
+}
+
+// Format implements logrus.Formatter
+func (f *ecsFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	doc := map[string]interface{}{}
+	setECSField(doc, "@timestamp", entry.Time.Format(f.timestampFormat))
+	setECSField(doc, "log.level", entry.Level.String())
+	setECSField(doc, "message", entry.Message)
+	setECSField(doc, "ecs.version", ecsVersion)
+
+	_, hasRawPath := entry.Data["raw_path"]
+	for k, v := range entry.Data {
+		if name, ok := ecsFieldNames[k]; ok {
+			setECSField(doc, name, v)
+			continue
+		}
+
+		switch k {
+		case "path":
+			// "GET /users/:id" → method + path (route pattern khi đã có raw_path)
+			path, _ := v.(string)
+			if method, rest, ok := strings.Cut(path, " "); ok {
+				setECSField(doc, "http.request.method", method)
+				path = rest
+			}
+			if hasRawPath {
+				setECSField(doc, "goerrorkit.route", path)
+			} else {
+				setECSField(doc, "url.path", path)
+			}
+		case "file":
+			// "handler.go:42" → log.origin.file.name + log.origin.file.line
+			file := fmt.Sprint(v)
+			if name, line, ok := strings.Cut(file, ":"); ok {
+				if n, err := strconv.Atoi(line); err == nil {
+					setECSField(doc, "log.origin.file.name", name)
+					setECSField(doc, "log.origin.file.line", n)
+					continue
+				}
+			}
+			setECSField(doc, "log.origin.file.name", file)
+		case "call_chain":
+			if chain, ok := v.([]string); ok {
+				setECSField(doc, "error.stack_trace", strings.Join(chain, "\n"))
+			} else {
+				setECSField(doc, "error.stack_trace", fmt.Sprint(v))
+			}
+		case "latency_ms":
+			// event.duration tính bằng nanosecond
+			if ms, ok := v.(int64); ok {
+				setECSField(doc, "event.duration", ms*int64(time.Millisecond))
+			} else {
+				setECSField(doc, "goerrorkit.latency_ms", v)
+			}
+		case "data":
+			if data, ok := v.(map[string]interface{}); ok {
+				for dk, dv := range data {
+					setECSField(doc, "labels."+ecsLabelKey(dk), ecsLabelValue(dv))
+				}
+			} else {
+				setECSField(doc, "labels.data", ecsLabelValue(v))
+			}
+		default:
+			if err, ok := v.(error); ok {
+				v = err.Error()
+			}
+			setECSField(doc, "goerrorkit."+k, v)
+		}
+	}
+
+	// Log của AppError: error.message là message của error
+	if _, ok := entry.Data["error_type"]; ok {
+		setECSField(doc, "error.message", entry.Message)
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if f.prettyPrint {
+		encoder.SetIndent("", "  ")
+	}
+	if err := encoder.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to marshal ECS log entry: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// setECSField gán value vào doc theo tên field có dấu chấm ("http.request.method" → nested object)
+// Nếu một phần của path đã là giá trị thường (không phải object), giữ nguyên tên có dấu chấm
+func setECSField(doc map[string]interface{}, name string, value interface{}) {
+	parts := strings.Split(name, ".")
+	current := doc
+	for i, part := range parts[:len(parts)-1] {
+		next, exists := current[part]
+		if !exists {
+			child := map[string]interface{}{}
+			current[part] = child
+			current = child
+			continue
+		}
+		child, ok := next.(map[string]interface{})
+		if !ok {
+			current[strings.Join(parts[i:], ".")] = value
+			return
+		}
+		current = child
+	}
+	current[parts[len(parts)-1]] = value
+}
+
+// ecsLabelKey thay dấu chấm trong key của Data (ECS labels không được lồng nhau)
+func ecsLabelKey(key string) string {
+	return strings.ReplaceAll(key, ".", "_")
+}
+
+// ecsLabelValue chuyển giá trị Data sang string (ECS labels là keyword)
+func ecsLabelValue(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case error:
+		return val.Error()
+	case fmt.Stringer:
+		return val.String()
+	}
+	if b, err := json.Marshal(v); err == nil {
+		return string(b)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,151 @@
+package goerrorkit
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// updateGolden ghi lại file golden trong testdata: go test -run ECS -update
+var updateGolden = flag.Bool("update", false, "update golden files in testdata")
+
+// assertGolden so sánh got với testdata/name
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *updateGolden {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("%s mismatch:\n--- got\n%s\n--- want\n%s", name, got, want)
+	}
+}
+
+// formatECS format một entry với thời gian cố định
+func formatECS(t *testing.T, level logrus.Level, msg string, fields logrus.Fields) []byte {
+	t.Helper()
+	entry := &logrus.Entry{
+		Time:    time.Date(2025, 11, 28, 9, 30, 0, 0, time.UTC),
+		Level:   level,
+		Message: msg,
+		Data:    fields,
+	}
+	out, err := newECSFormatter("", true).Format(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestECSFormatterSystemErrorGolden(t *testing.T) {
+	out := formatECS(t, logrus.ErrorLevel, "Internal server error", logrus.Fields{
+		"error_type": "SYSTEM",
+		"error_code": "ORD-1021",
+		"severity":   "SEV2",
+		"ref":        "ERR-BA5869",
+		"request_id": "req-7",
+		"trace_id":   "4bf92f3577b34da6a3ce929d0e0e4736",
+		"path":       "GET /orders/:id",
+		"raw_path":   "/orders/42",
+		"status":     500,
+		"latency_ms": int64(12),
+		"file":       "service.go:42",
+		"function":   "orders.(*Service).Checkout",
+		"hostname":   "api-1",
+		"pid":        4242,
+		"cause":      errors.New("db down"),
+		"created_at": "2025-11-28T09:30:00Z",
+		"data": map[string]interface{}{
+			"order_id": 42,
+			"customer": "an@example.com",
+			"tags":     []string{"vip", "b2b"},
+			"geo.city": "Hanoi",
+		},
+	})
+	assertGolden(t, "ecs_system_error.golden", out)
+}
+
+func TestECSFormatterPanicErrorGolden(t *testing.T) {
+	out := formatECS(t, logrus.ErrorLevel, "Panic recovered: runtime error: index out of range [3] with length 0", logrus.Fields{
+		"error_type":  "PANIC",
+		"severity":    "SEV1",
+		"ref":         "ERR-629783",
+		"request_id":  "req-8",
+		"path":        "POST /pay",
+		"file":        "payment.go:77",
+		"function":    "payment.Charge",
+		"panic_kind":  "index_out_of_range",
+		"panic_type":  "runtime.Error",
+		"panic_value": "runtime error: index out of range [3] with length 0",
+		"call_chain": []string{
+			"payment.Charge (payment.go:77)",
+			"orders.(*Service).Checkout (service.go:42)",
+			"main.main (main.go:15)",
+		},
+	})
+	assertGolden(t, "ecs_panic_error.golden", out)
+}
+
+func TestECSFormatOption(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.log")
+	logger, _ := newTestLogrusLogger(t, LoggerOptions{
+		FileOutput:   true,
+		FilePath:     path,
+		Format:       "ecs",
+		FileLogLevel: "error",
+	})
+	SetLogger(logger)
+	defer SetLogger(nil)
+
+	LogError(NewSystemError(errors.New("db down")).WithData(map[string]interface{}{"order_id": 42}), "GET /orders/42")
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("file has %d lines, want one compact ECS record:\n%s", len(lines), content)
+	}
+	var doc struct {
+		Error struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"error"`
+		HTTP struct {
+			Request struct {
+				Method string `json:"method"`
+			} `json:"request"`
+		} `json:"http"`
+		URL struct {
+			Path string `json:"path"`
+		} `json:"url"`
+		Labels map[string]string `json:"labels"`
+		ECS    struct {
+			Version string `json:"version"`
+		} `json:"ecs"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Error.Type != "SYSTEM" || doc.Error.Message != "Internal server error" || doc.HTTP.Request.Method != "GET" ||
+		doc.URL.Path != "/orders/42" || doc.Labels["order_id"] != "42" || doc.ECS.Version != ecsVersion {
+		t.Errorf("ECS record = %s", lines[0])
+	}
+}
@@ -0,0 +1,114 @@
+package goerrorkit
+
+import "sync"
+
+// Các giá trị environment phổ biến
+const (
+	EnvDevelopment = "development"
+	EnvStaging     = "staging"
+	EnvProduction  = "production"
+)
+
+// CauseExposurePolicy quyết định có trả Cause (lỗi gốc) về cho client hay không
+// dựa trên environment hiện tại và loại lỗi
+type CauseExposurePolicy func(env string, errType ErrorType) bool
+
+var (
+	environmentMu sync.RWMutex
+
+	// currentEnvironment là environment hiện tại, mặc định "production" (an toàn nhất)
+	currentEnvironment = EnvProduction
+
+	// debugResponses bật/tắt việc trả debug info (Details, Data, Cause) trong response
+	// Mặc định tắt để production response không bao giờ lộ internal details
+	debugResponses = false
+
+	// causeExposurePolicy là policy hiện tại, mặc định là DefaultCauseExposurePolicy
+	causeExposurePolicy CauseExposurePolicy = DefaultCauseExposurePolicy
+)
+
+// DefaultCauseExposurePolicy chỉ expose cause ở "development" và "staging" (allowlist)
+// Environment khác - kể cả rỗng hoặc gõ sai như "prod" - không expose (fail closed)
+func DefaultCauseExposurePolicy(env string, errType ErrorType) bool {
+	switch env {
+	case EnvDevelopment, EnvStaging:
+		return true
+	default:
+		return false
+	}
+}
+
+// SetEnvironment thiết lập environment hiện tại (development, staging, production, ...)
+//
+// Example:
+//
+//	goerrorkit.SetEnvironment(os.Getenv("APP_ENV"))
+func SetEnvironment(env string) {
+	environmentMu.Lock()
+	currentEnvironment = env
+	environmentMu.Unlock()
+}
+
+// GetEnvironment trả về environment hiện tại
+func GetEnvironment() string {
+	environmentMu.RLock()
+	defer environmentMu.RUnlock()
+	return currentEnvironment
+}
+
+// SetCauseExposurePolicy cho phép user tùy chỉnh khi nào Cause được trả về trong response
+// Truyền nil để quay về DefaultCauseExposurePolicy
+//
+// Example:
+//
+//	// Chỉ expose cause của ExternalError ở staging, expose tất cả ở development
+//	goerrorkit.SetCauseExposurePolicy(func(env string, errType goerrorkit.ErrorType) bool {
+//	    switch env {
+//	    case "development":
+//	        return true
+//	    case "staging":
+//	        return errType == goerrorkit.ExternalError
+//	    default:
+//	        return false
+//	    }
+//	})
+func SetCauseExposurePolicy(policy func(env string, errType ErrorType) bool) {
+	if policy == nil {
+		policy = DefaultCauseExposurePolicy
+	}
+	environmentMu.Lock()
+	causeExposurePolicy = policy
+	environmentMu.Unlock()
+}
+
+// SetDebugResponses bật/tắt việc thêm object "debug" (Details, Data, Cause) vào error response
+// CHỈ nên bật khi develop local, mặc định tắt
+//
+// Example:
+//
+//	if os.Getenv("APP_ENV") == "development" {
+//	    goerrorkit.SetDebugResponses(true)
+//	}
+func SetDebugResponses(enabled bool) {
+	environmentMu.Lock()
+	debugResponses = enabled
+	environmentMu.Unlock()
+}
+
+// IsDebugResponsesEnabled trả về trạng thái debug responses hiện tại
+func IsDebugResponsesEnabled() bool {
+	environmentMu.RLock()
+	defer environmentMu.RUnlock()
+	return debugResponses
+}
+
+// shouldExposeCause kiểm tra xem có trả cause của AppError về client không
+func shouldExposeCause(appErr *AppError) bool {
+	if appErr.Cause == nil {
+		return false
+	}
+	environmentMu.RLock()
+	policy, env := causeExposurePolicy, currentEnvironment
+	environmentMu.RUnlock()
+	return policy(env, appErr.Type)
+}
@@ -0,0 +1,212 @@
+package goerrorkit
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// withEnvironment set environment cho một test và khôi phục khi test kết thúc
+func withEnvironment(t *testing.T, env string) {
+	t.Helper()
+	prev := GetEnvironment()
+	SetEnvironment(env)
+	t.Cleanup(func() { SetEnvironment(prev) })
+}
+
+func TestDefaultCauseExposurePolicy(t *testing.T) {
+	tests := []struct {
+		env  string
+		want bool
+	}{
+		{EnvDevelopment, true},
+		{EnvStaging, true},
+		{EnvProduction, false},
+		{"", false},
+		{"prod", false},
+		{"Production", false},
+		{"qa", false},
+	}
+	for _, tt := range tests {
+		if got := DefaultCauseExposurePolicy(tt.env, SystemError); got != tt.want {
+			t.Errorf("DefaultCauseExposurePolicy(%q) = %v, want %v", tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestCauseExposureDevelopmentResponse(t *testing.T) {
+	withEnvironment(t, EnvDevelopment)
+
+	ctx := newTestContext("GET", "/orders/1")
+	UseMemoryLogger()
+	defer SetLogger(nil)
+	LogAndRespond(ctx, NewSystemError(errors.New("dial tcp 10.0.0.5:5432: connection refused")), "GET /orders/1")
+
+	resp := ctx.response()
+	if ctx.status != 500 {
+		t.Errorf("status = %d, want 500", ctx.status)
+	}
+	if resp["cause"] != "dial tcp 10.0.0.5:5432: connection refused" {
+		t.Errorf("cause = %v, want underlying error in development", resp["cause"])
+	}
+}
+
+func TestCauseExposureProductionResponse(t *testing.T) {
+	for _, env := range []string{EnvProduction, "", "prod"} {
+		withEnvironment(t, env)
+
+		ctx := newTestContext("GET", "/orders/1")
+		UseMemoryLogger()
+		LogAndRespond(ctx, NewSystemError(errors.New("dial tcp 10.0.0.5:5432: connection refused")), "GET /orders/1")
+		SetLogger(nil)
+
+		resp := ctx.response()
+		if _, ok := resp["cause"]; ok {
+			t.Errorf("env %q: cause leaked in response: %v", env, resp["cause"])
+		}
+		if _, ok := resp["debug"]; ok {
+			t.Errorf("env %q: debug leaked in response: %v", env, resp["debug"])
+		}
+		if resp["error"] != "Internal server error" {
+			t.Errorf("env %q: error = %v", env, resp["error"])
+		}
+	}
+}
+
+func TestSetCauseExposurePolicy(t *testing.T) {
+	withEnvironment(t, EnvStaging)
+	SetCauseExposurePolicy(func(env string, errType ErrorType) bool {
+		return env == EnvStaging && errType == ExternalError
+	})
+	defer SetCauseExposurePolicy(nil)
+
+	external := NewExternalError(502, "Payment gateway unavailable", errors.New("timeout"))
+	if got := FormatErrorResponse(external)["cause"]; got != "timeout" {
+		t.Errorf("ExternalError cause = %v, want timeout", got)
+	}
+	system := NewSystemError(errors.New("nil map"))
+	if _, ok := FormatErrorResponse(system)["cause"]; ok {
+		t.Error("SystemError cause exposed despite custom policy")
+	}
+}
+
+func TestEnvironmentConcurrentAccess(t *testing.T) {
+	withEnvironment(t, EnvProduction)
+	defer SetCauseExposurePolicy(nil)
+
+	appErr := NewSystemError(errors.New("db down"))
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				SetEnvironment([]string{EnvDevelopment, EnvProduction}[(i+j)%2])
+				SetCauseExposurePolicy(nil)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				FormatErrorResponse(appErr)
+				_ = GetEnvironment()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// withDebugResponses bật/tắt debug responses cho một test và khôi phục khi test kết thúc
+func withDebugResponses(t *testing.T, enabled bool) {
+	t.Helper()
+	prev := IsDebugResponsesEnabled()
+	SetDebugResponses(enabled)
+	t.Cleanup(func() { SetDebugResponses(prev) })
+}
+
+// newInternalError tạo error có đủ internal details (file, call chain, data, cause)
+func newInternalError() *AppError {
+	return NewSystemError(errors.New("pq: password authentication failed for user \"app\"")).
+		WithCallChain().
+		WithData(map[string]interface{}{"order_id": 42})
+}
+
+func TestDebugResponsesDefaultOff(t *testing.T) {
+	if IsDebugResponsesEnabled() {
+		t.Fatal("debug responses must be disabled by default")
+	}
+}
+
+func TestProductionResponseDoesNotLeakInternals(t *testing.T) {
+	withEnvironment(t, EnvProduction)
+	withDebugResponses(t, false)
+	UseMemoryLogger()
+	defer SetLogger(nil)
+
+	ctx := newTestContext("GET", "/orders/42")
+	LogAndRespond(ctx, newInternalError(), "GET /orders/42")
+
+	resp := ctx.response()
+	for _, key := range []string{"debug", "cause", "file", "function", "call_chain", "data", "frames"} {
+		if _, ok := resp[key]; ok {
+			t.Errorf("production response leaks %q: %v", key, resp)
+		}
+	}
+	body, _ := json.Marshal(resp)
+	for _, internal := range []string{"password authentication", "environment_test.go", "order_id"} {
+		if strings.Contains(string(body), internal) {
+			t.Errorf("production response body contains %q: %s", internal, body)
+		}
+	}
+}
+
+func TestDebugResponsesIncludeInternals(t *testing.T) {
+	withDebugResponses(t, true)
+	UseMemoryLogger()
+	defer SetLogger(nil)
+
+	ctx := newTestContext("GET", "/orders/42")
+	LogAndRespond(ctx, newInternalError(), "GET /orders/42")
+
+	debugInfo, ok := ctx.response()["debug"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("response has no debug object: %v", ctx.response())
+	}
+	if file, _ := debugInfo["file"].(string); !strings.HasPrefix(file, "environment_test.go:") {
+		t.Errorf("debug.file = %v", debugInfo["file"])
+	}
+	if debugInfo["function"] == nil || debugInfo["call_chain"] == nil {
+		t.Errorf("debug object missing function/call_chain: %v", debugInfo)
+	}
+	if data, _ := debugInfo["data"].(map[string]interface{}); data["order_id"] != float64(42) {
+		t.Errorf("debug.data = %v", debugInfo["data"])
+	}
+	if cause, _ := debugInfo["cause"].(string); !strings.Contains(cause, "password authentication") {
+		t.Errorf("debug.cause = %v", debugInfo["cause"])
+	}
+}
+
+func TestDebugResponsesConcurrentAccess(t *testing.T) {
+	withDebugResponses(t, false)
+
+	appErr := newInternalError()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				SetDebugResponses((i+j)%2 == 0)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				FormatErrorResponse(appErr)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -1,7 +1,12 @@
 package goerrorkit
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // ErrorType định nghĩa các loại lỗi trong hệ thống
@@ -16,17 +21,47 @@ const (
 	PanicError      ErrorType = "PANIC"      // Recovered panic
 )
 
+// DefaultHTTPStatus trả về HTTP status mặc định của ErrorType
+// Dùng bởi các factory function không nhận code (NewSystemError, Wrap, NewValidationError, ...)
+func (t ErrorType) DefaultHTTPStatus() int {
+	switch t {
+	case ValidationError:
+		return 400
+	case AuthError:
+		return 401
+	case BusinessError:
+		return 409 // Conflict - trạng thái hiện tại không cho phép thao tác
+	case ExternalError:
+		return 502
+	default: // SystemError, PanicError và type không xác định
+		return 500
+	}
+}
+
 // AppError là cấu trúc error chính của thư viện
 // Chứa đầy đủ thông tin về lỗi bao gồm type, code, message, stack trace, etc.
 type AppError struct {
-	Type      ErrorType              // Loại lỗi
-	Code      int                    // HTTP status code
-	Message   string                 // Message hiển thị
-	Details   map[string]interface{} // Thông tin metadata hệ thống (file, line, function, stack trace)
-	Data      map[string]interface{} // Dữ liệu đặc thù của tình huống (product_id, user_id, etc.)
-	Cause     error                  // Lỗi gốc (nếu có)
-	RequestID string                 // Request ID để trace
-	logLevel  string                 // Custom log level (warn, error, panic) - private field
+	Type        ErrorType              // Loại lỗi
+	Code        int                    // HTTP status code
+	Message     string                 // Message hiển thị
+	Details     map[string]interface{} // Thông tin metadata hệ thống (file, line, function, stack trace)
+	Data        map[string]interface{} // Dữ liệu đặc thù của tình huống (product_id, user_id, etc.)
+	Cause       error                  // Lỗi gốc (nếu có)
+	Causes      []error                // Nhiều lỗi gốc (fan-out), Cause = errors.Join(Causes...) - xem WithCauses
+	RequestID   string                 // Request ID để trace
+	CreatedAt   time.Time              // Thời điểm tạo error (khác thời điểm log khi dùng async logging)
+	Frames      []StackFrame           // Call chain dạng structured (populate bởi WithCallChain và HandlePanic)
+	Retryable   bool                   // Lỗi tạm thời, có thể retry (ví dụ sql.ErrConnDone, driver.ErrBadConn)
+	Headers     map[string]string      // HTTP headers gửi kèm response (ví dụ WWW-Authenticate)
+	MessageKey  string                 // Translation key cho message trả về client (xem WithMessageKey)
+	MessageArgs []interface{}          // Args cho MessageKey
+	Tier        string                 // SLA tier của khách hàng (gold/silver/bronze) để ưu tiên xử lý
+	ErrCode     string                 // Application error code ổn định cho client (ví dụ "ORD-1021"), độc lập với HTTP status
+	FieldErrors []FieldError           // Lỗi theo từng field của ValidationError (xem AddFieldError)
+	logLevel    string                 // Custom log level (warn, error, panic) - private field
+	severity    Severity               // Custom severity (SEV1..SEV4) - private field
+	monitor     bool                   // MonitorOnly: luôn ghi vào file sink, không page - private field
+	skipLog     bool                   // SkipLogging: vẫn response nhưng không ghi log - private field
 }
 
 // Error implements error interface
@@ -39,8 +74,25 @@ func (e *AppError) Unwrap() error {
 	return e.Cause
 }
 
+// AsStdError trả về một error thường (kiểu errors.New) chỉ chứa Message
+// Dùng ở boundary cần trả stdlib error mà không muốn lộ kiểu AppError
+// (ví dụ khi implement interface của thư viện bên thứ ba)
+//
+// Example:
+//
+//	func (s *Store) Get(key string) ([]byte, error) {
+//	    if appErr := s.load(key); appErr != nil {
+//	        return nil, appErr.AsStdError()
+//	    }
+//	    // ...
+//	}
+func (e *AppError) AsStdError() error {
+	return errors.New(e.Message)
+}
+
 // WithData thêm dữ liệu đặc thù của tình huống vào error
 // Dữ liệu này sẽ được log trong trường "data" riêng biệt
+// Data nil hoặc rỗng được bỏ qua ở mọi nơi (không có trường "data" trong log lẫn debug response)
 //
 // Example:
 //
@@ -55,6 +107,236 @@ func (e *AppError) WithData(data map[string]interface{}) *AppError {
 	return e
 }
 
+// WithCaller ghi lại vị trí phát sinh (file, function trong Details) theo caller cách skip frame
+// Dùng trong helper/integration package để error trỏ về code của user thay vì helper
+// skip = 0: hàm gọi WithCaller; skip = 1: caller của hàm đó
+//
+// Example:
+//
+//	func MustFindUser(id string) error {
+//	    return goerrorkit.NewBusinessError(404, "User not found").WithCaller(1) // vị trí của caller
+//	}
+func (e *AppError) WithCaller(skip int) *AppError {
+	file, line, function := getCallerInfo(skip + 1)
+	if e.Details == nil {
+		e.Details = make(map[string]interface{}, 2)
+	}
+	e.Details["function"] = function
+	e.Details["file"] = fmt.Sprintf("%s:%d", file, line)
+	return e
+}
+
+// HasData cho biết error có dữ liệu đặc thù không (Data khác nil và không rỗng)
+func (e *AppError) HasData() bool {
+	return len(e.Data) > 0
+}
+
+// WithField thêm một key vào Data (khởi tạo Data nếu chưa có)
+// Gọn hơn WithData khi chỉ cần thêm một vài key. Lưu ý: WithData gọi sau sẽ thay thế toàn bộ Data
+//
+// Example:
+//
+//	return goerrorkit.NewBusinessError(404, "Order not found").
+//	    WithField("user_id", userID).
+//	    WithField("order_id", orderID)
+func (e *AppError) WithField(key string, value interface{}) *AppError {
+	if e.Data == nil {
+		e.Data = make(map[string]interface{})
+	}
+	e.Data[key] = value
+	return e
+}
+
+// WithCauses gắn nhiều lỗi gốc (ví dụ các call song song cùng fail)
+// Cause trở thành errors.Join của tất cả nên errors.Is/errors.As match được bất kỳ lỗi nào
+// Cause đã có trước đó (nếu có) được giữ ở đầu danh sách; nil bị bỏ qua
+// Log có field "causes" (mảng message) thay cho "cause"
+//
+// Example:
+//
+//	var errs []error
+//	for _, r := range results {
+//	    if r.Err != nil {
+//	        errs = append(errs, r.Err)
+//	    }
+//	}
+//	if len(errs) > 0 {
+//	    return goerrorkit.NewExternalError(502, "Some providers failed", nil).WithCauses(errs...)
+//	}
+func (e *AppError) WithCauses(errs ...error) *AppError {
+	if len(e.Causes) == 0 && e.Cause != nil {
+		e.Causes = append(e.Causes, e.Cause)
+	}
+	for _, err := range errs {
+		if err != nil {
+			e.Causes = append(e.Causes, err)
+		}
+	}
+	if len(e.Causes) > 0 {
+		e.Cause = errors.Join(e.Causes...)
+	}
+	return e
+}
+
+// GetData đọc một key trong Data, trả về (nil, false) nếu không có
+//
+// Example:
+//
+//	if orderID, ok := appErr.GetData("order_id"); ok {
+//	    report.Tag("order_id", fmt.Sprint(orderID))
+//	}
+func (e *AppError) GetData(key string) (interface{}, bool) {
+	v, ok := e.Data[key]
+	return v, ok
+}
+
+// GetDataString đọc key trong Data dưới dạng string
+// Trả về ("", false) nếu không có key hoặc value không phải string
+func (e *AppError) GetDataString(key string) (string, bool) {
+	s, ok := e.Data[key].(string)
+	return s, ok
+}
+
+// GetDataInt đọc key trong Data dưới dạng int
+// Chấp nhận mọi kiểu số nguyên và float64 không có phần thập phân (ví dụ sau khi decode JSON)
+// Trả về (0, false) nếu không có key hoặc value không phải số nguyên
+func (e *AppError) GetDataInt(key string) (int, bool) {
+	return toInt(e.Data[key])
+}
+
+// GetDetail đọc một key trong Details (metadata hệ thống: file, function, call_chain, ...)
+//
+// Example:
+//
+//	file, _ := appErr.GetDetail("file")
+func (e *AppError) GetDetail(key string) (interface{}, bool) {
+	v, ok := e.Details[key]
+	return v, ok
+}
+
+// toInt chuyển các kiểu số nguyên (và float64 nguyên) sang int
+func toInt(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int8:
+		return int(n), true
+	case int16:
+		return int(n), true
+	case int32:
+		return int(n), true
+	case int64:
+		return int(n), true
+	case uint:
+		return int(n), true
+	case uint8:
+		return int(n), true
+	case uint16:
+		return int(n), true
+	case uint32:
+		return int(n), true
+	case uint64:
+		return int(n), true
+	case float64:
+		if n == float64(int(n)) {
+			return int(n), true
+		}
+	}
+	return 0, false
+}
+
+// WithExpectation lưu giá trị mong đợi và giá trị nhận được vào Data["expectation"]
+// Giúp debug config/schema validation nhanh hơn khi thấy hai giá trị cạnh nhau
+// Lưu ý: gọi sau .WithData() vì WithData thay thế toàn bộ Data
+//
+// Example:
+//
+//	return goerrorkit.NewValidationError("Invalid config version", nil).
+//	    WithExpectation("v2", cfg.Version)
+//	// data: {"expectation": {"expected": "v2", "received": "v1"}}
+func (e *AppError) WithExpectation(expected, received interface{}) *AppError {
+	if e.Data == nil {
+		e.Data = make(map[string]interface{})
+	}
+	e.Data["expectation"] = map[string]interface{}{
+		"expected": expected,
+		"received": received,
+	}
+	return e
+}
+
+// WithStatus thay đổi HTTP status code mà vẫn giữ nguyên ErrorType (và log level mặc định theo type)
+// LogAndRespond và mọi ResponseWriter dùng status này cho response
+//
+// Example:
+//
+//	// Vẫn là ValidationError nhưng trả 422 thay vì 400
+//	return goerrorkit.NewValidationError("Email không hợp lệ", nil).WithStatus(422)
+//
+//	// Wrap lỗi optimistic locking thành 409
+//	return goerrorkit.Wrap(err).WithStatus(409)
+func (e *AppError) WithStatus(code int) *AppError {
+	// Cảnh báo theo vị trí tạo error (Details["file"]) thay vì capture stack thêm lần nữa
+	if isMismatchedStatus(e.Type, code) {
+		location, _ := e.Details["file"].(string)
+		warnMismatchedStatusAt(e.Type, code, location)
+	}
+	e.Code = code
+	return e
+}
+
+// WithRequestID gắn request ID vào error
+// Hữu ích khi gọi LogError thủ công trong lúc xử lý request (trước khi error tới middleware)
+//
+// Example:
+//
+//	appErr := goerrorkit.NewSystemError(err).WithRequestID(goerrorkit.RequestIDFromContext(ctx))
+//	goerrorkit.LogError(appErr, "service:orders")
+func (e *AppError) WithRequestID(id string) *AppError {
+	e.RequestID = id
+	return e
+}
+
+// WithHeader thêm HTTP header vào response của error
+// Header chỉ được gửi khi HTTPContext hỗ trợ set header (adapters/fiber.FiberContext có hỗ trợ)
+// Ký tự CR/LF trong value bị loại bỏ (chống header injection), key không hợp lệ bị bỏ qua
+//
+// Example:
+//
+//	return goerrorkit.NewBusinessError(429, "Too many requests").WithHeader("Retry-After", "30")
+//
+//	// 404 cache ngắn để CDN không dồn request về origin
+//	return goerrorkit.NewBusinessError(404, "Product not found").
+//	    WithHeader("Cache-Control", "public, max-age=30")
+func (e *AppError) WithHeader(key, value string) *AppError {
+	if !validHeaderKey(key) {
+		return e
+	}
+	if e.Headers == nil {
+		e.Headers = make(map[string]string)
+	}
+	e.Headers[key] = sanitizeHeaderValue(value)
+	return e
+}
+
+// WithRetryAfter set header Retry-After (số giây, làm tròn lên) cho response 429/503
+// d <= 0 → "0"
+//
+// Example:
+//
+//	return goerrorkit.NewExternalError(503, "Maintenance", nil).WithRetryAfter(2 * time.Minute)
+func (e *AppError) WithRetryAfter(d time.Duration) *AppError {
+	return e.WithHeader("Retry-After", strconv.FormatInt(retryAfterSeconds(d), 10))
+}
+
+// retryAfterSeconds đổi duration sang số giây nguyên (làm tròn lên, không âm)
+func retryAfterSeconds(d time.Duration) int64 {
+	if d <= 0 {
+		return 0
+	}
+	return int64((d + time.Second - 1) / time.Second)
+}
+
 // WithCallChain thêm full call chain (stack trace) vào error
 // Hữu ích khi cần debug chi tiết hoặc trace flow phức tạp
 // Lưu ý: Có overhead performance nên chỉ dùng khi cần thiết
@@ -71,14 +353,37 @@ func (e *AppError) WithData(data map[string]interface{}) *AppError {
 //	    WithData(map[string]interface{}{"product_id": id}).
 //	    WithCallChain()
 func (e *AppError) WithCallChain() *AppError {
-	callChain := formatStackTraceArray()
+	return e.setCallChain(captureStackFrames())
+}
+
+// WithCallChainDepth giống WithCallChain nhưng override số frame tối đa cho lần gọi này
+// (thay vì StackTraceConfig.MaxFrames). n = 0: dùng MaxFrames của config, n < 0: không giới hạn
+//
+// Example:
+//
+//	// Recursive algorithm - chỉ cần 10 frames
+//	return goerrorkit.Wrap(err).WithCallChainDepth(10)
+func (e *AppError) WithCallChainDepth(n int) *AppError {
+	return e.setCallChain(captureStackFramesLimit(n))
+}
+
+// setCallChain lưu frames vào AppError (structured và dạng string)
+func (e *AppError) setCallChain(frames []StackFrame) *AppError {
 	if e.Details == nil {
 		e.Details = make(map[string]interface{})
 	}
-	e.Details["call_chain"] = callChain
+	e.Frames = frames
+	// Giữ call_chain dạng string để tương thích ngược
+	e.Details["call_chain"] = formatCallChain(frames)
 	return e
 }
 
+// StackFrames trả về call chain dạng structured
+// Trả về nil nếu error chưa được capture call chain (WithCallChain hoặc panic)
+func (e *AppError) StackFrames() []StackFrame {
+	return e.Frames
+}
+
 // Level thiết lập custom log level cho error
 // Hỗ trợ fluent API và cho phép override log level mặc định
 // Valid levels: "trace", "debug", "info", "warn", "error", "panic"
@@ -101,14 +406,51 @@ func (e *AppError) Level(level string) *AppError {
 	return e
 }
 
+// MonitorOnly đánh dấu error là điều kiện "đã biết, cần theo dõi" nhưng không cần page:
+// log ở level warn (hoặc info nếu đã set .Level("info")) VÀ luôn được ghi vào file sink
+// bất kể FileLogLevel. HTTP code giữ nguyên (ví dụ vẫn trả 500)
+//
+// Example:
+//
+//	// Upstream timeout đã được monitor - vẫn trả 500 nhưng không page on-call
+//	return goerrorkit.NewSystemError(err).MonitorOnly()
+func (e *AppError) MonitorOnly() *AppError {
+	e.monitor = true
+	if e.logLevel != "info" {
+		e.logLevel = "warn"
+	}
+	return e
+}
+
+// IsMonitorOnly cho biết error có được đánh dấu MonitorOnly không
+func (e *AppError) IsMonitorOnly() bool {
+	return e.monitor
+}
+
+// SkipLogging đánh dấu error không cần ghi log (vẫn được response bình thường)
+// Dùng cho các tình huống không phải lỗi thật như client hủy request
+//
+// Example:
+//
+//	return goerrorkit.NewBusinessError(499, "Client closed request").SkipLogging()
+func (e *AppError) SkipLogging() *AppError {
+	e.skipLog = true
+	return e
+}
+
 // GetLogLevel trả về log level của error
-// Nếu không có custom level, trả về level mặc định dựa trên ErrorType
+// Thứ tự ưu tiên: .Level() → SetDefaultLogLevels → level mặc định dựa trên ErrorType
 func (e *AppError) GetLogLevel() string {
 	// Nếu có custom level, dùng custom level
 	if e.logLevel != "" {
 		return e.logLevel
 	}
 
+	// Level mặc định theo error type do user cấu hình (SetDefaultLogLevels)
+	if level, ok := defaultLogLevelFor(e.Type); ok {
+		return level
+	}
+
 	// Ngược lại, dùng log level mặc định theo error type
 	switch e.Type {
 	case ValidationError, AuthError:
@@ -122,6 +464,34 @@ func (e *AppError) GetLogLevel() string {
 	}
 }
 
+// Component trả về tên package (component) nơi phát sinh lỗi, derive từ Details["function"]
+// Dùng làm label cho metrics để giữ cardinality thấp (thay vì full file/function)
+// Trả về "unknown" nếu không xác định được
+//
+// Example:
+//
+//	// Details["function"] = "orders.(*Service).Checkout" → "orders"
+//	errorCounter.WithLabelValues(string(appErr.Type), appErr.Component()).Inc()
+func (e *AppError) Component() string {
+	function, _ := e.Details["function"].(string)
+	return componentFromFunction(function)
+}
+
+// componentFromFunction lấy tên package từ function name
+// "github.com/user/app/orders.(*Service).Checkout" → "orders", "main.main.func1" → "main"
+func componentFromFunction(function string) string {
+	if function == "" || function == "unknown" {
+		return "unknown"
+	}
+	if idx := strings.LastIndex(function, "/"); idx >= 0 {
+		function = function[idx+1:]
+	}
+	if idx := strings.Index(function, "."); idx > 0 {
+		return function[:idx]
+	}
+	return "unknown"
+}
+
 // ============================================================================
 // Factory Functions - Tạo Error Dễ Dàng
 // ============================================================================
@@ -147,20 +517,13 @@ func Wrap(err error) *AppError {
 		return nil
 	}
 	file, line, function := getCallerInfo(1)
-	return &AppError{
-		Type:    SystemError,
-		Code:    500,
-		Message: err.Error(),
-		Cause:   err,
-		Details: map[string]interface{}{
-			"function": function,
-			"file":     fmt.Sprintf("%s:%d", file, line),
-		},
-	}
+	return newWrapError(err, err.Error(), file, line, function)
 }
 
 // WrapWithMessage đóng gói Go error với custom message để thêm context
 // Message mô tả rõ hơn về ngữ cảnh lỗi, error gốc vẫn được giữ trong Cause
+// Nếu err đã là AppError (ví dụ BusinessError 404 từ layer dưới), Type/Code/Data, Headers,
+// MessageKey và FieldErrors được giữ nguyên và message thành "message: <message gốc>" thay vì bị hạ cấp thành SystemError 500
 //
 // Example:
 //
@@ -180,16 +543,113 @@ func WrapWithMessage(err error, message string) *AppError {
 		return nil
 	}
 	file, line, function := getCallerInfo(1)
-	return &AppError{
-		Type:    SystemError,
-		Code:    500,
-		Message: message,
-		Cause:   err,
+	return newWrapMessageError(err, message, file, line, function)
+}
+
+// WrapCtx giống Wrap nhưng lấy request ID từ context.Context (xem ContextWithRequestID)
+// Hữu ích cho background jobs: job ID được set sớm vào context và tự động đi theo error
+//
+// Example:
+//
+//	ctx := goerrorkit.ContextWithRequestID(context.Background(), "job-42")
+//	if err := sendEmail(ctx); err != nil {
+//	    return goerrorkit.WrapCtx(ctx, err) // RequestID = "job-42"
+//	}
+func WrapCtx(ctx context.Context, err error) *AppError {
+	if err == nil {
+		return nil
+	}
+	file, line, function := getCallerInfo(1)
+	appErr := newWrapError(err, err.Error(), file, line, function)
+	if rid := RequestIDFromContext(ctx); rid != "" {
+		appErr.RequestID = rid
+	}
+	if tier := TierFromContext(ctx); tier != "" {
+		appErr.Tier = tier
+	}
+	return appErr
+}
+
+// WrapWithMessageCtx giống WrapWithMessage nhưng lấy request ID từ context.Context
+//
+// Example:
+//
+//	if err := repo.Save(ctx, order); err != nil {
+//	    return goerrorkit.WrapWithMessageCtx(ctx, err, "Failed to save order")
+//	}
+func WrapWithMessageCtx(ctx context.Context, err error, message string) *AppError {
+	if err == nil {
+		return nil
+	}
+	file, line, function := getCallerInfo(1)
+	appErr := newWrapMessageError(err, message, file, line, function)
+	if rid := RequestIDFromContext(ctx); rid != "" {
+		appErr.RequestID = rid
+	}
+	if tier := TierFromContext(ctx); tier != "" {
+		appErr.Tier = tier
+	}
+	return appErr
+}
+
+// newWrapError tạo SystemError bọc err với vị trí caller đã được xác định
+// Request ID và SLA tier của AppError bên trong (nếu có) được giữ lại
+// Lỗi connection pool tạm thời được đánh dấu Retryable
+func newWrapError(err error, message, file string, line int, function string) *AppError {
+	appErr := &AppError{
+		Type:      SystemError,
+		Code:      SystemError.DefaultHTTPStatus(),
+		Message:   message,
+		Cause:     err,
+		RequestID: inheritRequestID(err),
+		Tier:      inheritTier(err),
+		CreatedAt: time.Now(),
 		Details: map[string]interface{}{
 			"function": function,
 			"file":     fmt.Sprintf("%s:%d", file, line),
 		},
 	}
+	// Lỗi connection pool tạm thời → Retryable
+	markTransient(appErr)
+	return appErr
+}
+
+// newWrapMessageError giống newWrapError nhưng giữ Type/Code/Data (và ErrCode, log level,
+// Headers, MessageKey/MessageArgs, FieldErrors, Retryable) của AppError bên trong,
+// message mới được prepend vào message gốc. Map/slice được copy để không sửa AppError bên trong
+func newWrapMessageError(err error, message, file string, line int, function string) *AppError {
+	var inner *AppError
+	if !errors.As(err, &inner) {
+		return newWrapError(err, message, file, line, function)
+	}
+
+	appErr := newWrapError(err, message+": "+inner.Message, file, line, function)
+	appErr.Type = inner.Type
+	appErr.Code = inner.Code
+	appErr.ErrCode = inner.ErrCode
+	appErr.logLevel = inner.logLevel
+	appErr.severity = inner.severity
+	appErr.Retryable = inner.Retryable
+	appErr.MessageKey = inner.MessageKey
+	if len(inner.MessageArgs) > 0 {
+		appErr.MessageArgs = append([]interface{}(nil), inner.MessageArgs...)
+	}
+	if len(inner.FieldErrors) > 0 {
+		appErr.FieldErrors = append([]FieldError(nil), inner.FieldErrors...)
+	}
+	if len(inner.Headers) > 0 {
+		appErr.Headers = make(map[string]string, len(inner.Headers))
+		for k, v := range inner.Headers {
+			appErr.Headers[k] = v
+		}
+	}
+	if inner.HasData() {
+		appErr.Data = make(map[string]interface{}, len(inner.Data))
+		for k, v := range inner.Data {
+			appErr.Data[k] = v
+		}
+	}
+	return appErr
 }
 
 // NewBusinessError tạo lỗi business logic với stack trace chính xác
@@ -209,10 +669,40 @@ func WrapWithMessage(err error, message string) *AppError {
 //	})
 func NewBusinessError(code int, msg string) *AppError {
 	file, line, function := getCallerInfo(1)
+	warnMismatchedStatus(BusinessError, code, file, line)
+	return &AppError{
+		Type:      BusinessError,
+		Code:      code,
+		Message:   msg,
+		CreatedAt: time.Now(),
+		Details: map[string]interface{}{
+			"function": function,
+			"file":     fmt.Sprintf("%s:%d", file, line),
+		},
+	}
+}
+
+// NewBusinessErrorCtx giống NewBusinessError nhưng lấy request ID từ context.Context
+// (xem ContextWithRequestID; Fiber middleware tự gắn request ID vào c.UserContext())
+//
+// Example:
+//
+//	func (s *OrderService) Cancel(ctx context.Context, id string) error {
+//	    if order.Shipped {
+//	        return goerrorkit.NewBusinessErrorCtx(ctx, 409, "Order already shipped")
+//	    }
+//	    // ...
+//	}
+func NewBusinessErrorCtx(ctx context.Context, code int, msg string) *AppError {
+	file, line, function := getCallerInfo(1)
+	warnMismatchedStatus(BusinessError, code, file, line)
 	return &AppError{
-		Type:    BusinessError,
-		Code:    code,
-		Message: msg,
+		Type:      BusinessError,
+		Code:      code,
+		Message:   msg,
+		RequestID: RequestIDFromContext(ctx),
+		Tier:      TierFromContext(ctx),
+		CreatedAt: time.Now(),
 		Details: map[string]interface{}{
 			"function": function,
 			"file":     fmt.Sprintf("%s:%d", file, line),
@@ -237,10 +727,11 @@ func NewBusinessError(code int, msg string) *AppError {
 func NewSystemError(err error) *AppError {
 	file, line, function := getCallerInfo(1)
 	return &AppError{
-		Type:    SystemError,
-		Code:    500,
-		Message: "Internal server error",
-		Cause:   err,
+		Type:      SystemError,
+		Code:      SystemError.DefaultHTTPStatus(),
+		Message:   "Internal server error",
+		Cause:     err,
+		CreatedAt: time.Now(),
 		Details: map[string]interface{}{
 			"function": function,
 			"file":     fmt.Sprintf("%s:%d", file, line),
@@ -263,9 +754,10 @@ func NewSystemError(err error) *AppError {
 func NewValidationError(msg string, data map[string]interface{}) *AppError {
 	file, line, function := getCallerInfo(1)
 	return &AppError{
-		Type:    ValidationError,
-		Code:    400,
-		Message: msg,
+		Type:      ValidationError,
+		Code:      ValidationError.DefaultHTTPStatus(),
+		Message:   msg,
+		CreatedAt: time.Now(),
 		Details: map[string]interface{}{
 			"function": function,
 			"file":     fmt.Sprintf("%s:%d", file, line),
@@ -289,16 +781,89 @@ func NewValidationError(msg string, data map[string]interface{}) *AppError {
 //	    "token_expired": true,
 //	})
 func NewAuthError(code int, msg string) *AppError {
+	file, line, function := getCallerInfo(1)
+	warnMismatchedStatus(AuthError, code, file, line)
+	return &AppError{
+		Type:      AuthError,
+		Code:      code,
+		Message:   msg,
+		CreatedAt: time.Now(),
+		Details: map[string]interface{}{
+			"function": function,
+			"file":     fmt.Sprintf("%s:%d", file, line),
+		},
+	}
+}
+
+// NewUnauthenticatedError tạo AuthError 401 (chưa đăng nhập / token không hợp lệ)
+// Response có header WWW-Authenticate và hint auth_action = "login"
+//
+// Example:
+//
+//	if token == "" {
+//	    return goerrorkit.NewUnauthenticatedError("Missing authorization token")
+//	}
+func NewUnauthenticatedError(msg string) *AppError {
+	file, line, function := getCallerInfo(1)
+	return &AppError{
+		Type:      AuthError,
+		Code:      401,
+		Message:   msg,
+		Headers:   map[string]string{"WWW-Authenticate": "Bearer"},
+		CreatedAt: time.Now(),
+		Details: map[string]interface{}{
+			"function": function,
+			"file":     fmt.Sprintf("%s:%d", file, line),
+		},
+	}
+}
+
+// NewForbiddenError tạo AuthError 403 (đã đăng nhập nhưng không đủ quyền)
+// Response có hint auth_action = "contact_admin"
+//
+// Example:
+//
+//	if !user.IsAdmin() {
+//	    return goerrorkit.NewForbiddenError("Admin permission required")
+//	}
+func NewForbiddenError(msg string) *AppError {
 	file, line, function := getCallerInfo(1)
 	return &AppError{
-		Type:    AuthError,
-		Code:    code,
-		Message: msg,
+		Type:      AuthError,
+		Code:      403,
+		Message:   msg,
+		CreatedAt: time.Now(),
+		Details: map[string]interface{}{
+			"function": function,
+			"file":     fmt.Sprintf("%s:%d", file, line),
+		},
+	}
+}
+
+// NewTooManyRequestsError tạo BusinessError 429 (rate limit) với header Retry-After
+// retryAfter <= 0 → không gửi Retry-After
+//
+// Example:
+//
+//	if !limiter.Allow() {
+//	    return goerrorkit.NewTooManyRequestsError("Too many requests", 30*time.Second)
+//	}
+func NewTooManyRequestsError(msg string, retryAfter time.Duration) *AppError {
+	file, line, function := getCallerInfo(1)
+	appErr := &AppError{
+		Type:      BusinessError,
+		Code:      429,
+		Message:   msg,
+		CreatedAt: time.Now(),
 		Details: map[string]interface{}{
 			"function": function,
 			"file":     fmt.Sprintf("%s:%d", file, line),
 		},
 	}
+	if retryAfter > 0 {
+		appErr.WithRetryAfter(retryAfter)
+	}
+	return appErr
 }
 
 // NewExternalError tạo lỗi từ external service với cause
@@ -317,11 +882,13 @@ func NewAuthError(code int, msg string) *AppError {
 //	})
 func NewExternalError(code int, msg string, cause error) *AppError {
 	file, line, function := getCallerInfo(1)
+	warnMismatchedStatus(ExternalError, code, file, line)
 	return &AppError{
-		Type:    ExternalError,
-		Code:    code,
-		Message: msg,
-		Cause:   cause,
+		Type:      ExternalError,
+		Code:      code,
+		Message:   msg,
+		Cause:     cause,
+		CreatedAt: time.Now(),
 		Details: map[string]interface{}{
 			"function": function,
 			"file":     fmt.Sprintf("%s:%d", file, line),
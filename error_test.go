@@ -0,0 +1,547 @@
+package goerrorkit
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWrapWithMessagePreservesAppError(t *testing.T) {
+	inner := NewAuthError(401, "Token expired").
+		WithHeader("WWW-Authenticate", `Bearer error="invalid_token"`).
+		WithMessageKey("auth.token_expired", "access").
+		WithData(map[string]interface{}{"user_id": 7})
+	inner.ErrCode = "AUTH-1001"
+	inner.Retryable = true
+
+	wrapped := WrapWithMessage(inner, "loading profile")
+
+	if wrapped.Type != AuthError || wrapped.Code != 401 || wrapped.ErrCode != "AUTH-1001" {
+		t.Fatalf("got %s %d %q, want AUTH 401 AUTH-1001", wrapped.Type, wrapped.Code, wrapped.ErrCode)
+	}
+	if wrapped.Message != "loading profile: Token expired" {
+		t.Errorf("Message = %q", wrapped.Message)
+	}
+	if !errors.Is(wrapped, inner) {
+		t.Error("inner AppError is not in the Cause chain")
+	}
+	if got := wrapped.Headers["WWW-Authenticate"]; got != `Bearer error="invalid_token"` {
+		t.Errorf("Headers = %v", wrapped.Headers)
+	}
+	if wrapped.MessageKey != "auth.token_expired" || !reflect.DeepEqual(wrapped.MessageArgs, []interface{}{"access"}) {
+		t.Errorf("MessageKey = %q, MessageArgs = %v", wrapped.MessageKey, wrapped.MessageArgs)
+	}
+	if wrapped.Data["user_id"] != 7 || !wrapped.Retryable {
+		t.Errorf("Data = %v, Retryable = %v", wrapped.Data, wrapped.Retryable)
+	}
+
+	// Sửa bản wrap không ảnh hưởng AppError bên trong
+	wrapped.WithHeader("X-Extra", "1")
+	wrapped.MessageArgs[0] = "changed"
+	wrapped.Data["user_id"] = 8
+	if _, ok := inner.Headers["X-Extra"]; ok || inner.MessageArgs[0] != "access" || inner.Data["user_id"] != 7 {
+		t.Errorf("inner mutated: headers=%v args=%v data=%v", inner.Headers, inner.MessageArgs, inner.Data)
+	}
+}
+
+func TestWrapWithMessagePreservesFieldErrors(t *testing.T) {
+	inner := NewValidationErrors("Invalid order").
+		AddFieldError("quantity", "must be positive", map[string]interface{}{"min": 1})
+
+	wrapped := WrapWithMessage(inner, "create order")
+
+	if wrapped.Type != ValidationError || !wrapped.HasFieldErrors() || wrapped.FieldErrors[0].Field != "quantity" {
+		t.Errorf("got %s with field errors %v", wrapped.Type, wrapped.FieldErrors)
+	}
+	wrapped.AddFieldError("note", "too long", nil)
+	if len(inner.FieldErrors) != 1 {
+		t.Errorf("inner FieldErrors mutated: %v", inner.FieldErrors)
+	}
+}
+
+func TestWrapWithMessagePlainError(t *testing.T) {
+	wrapped := WrapWithMessage(errors.New("connection refused"), "loading profile")
+
+	if wrapped.Type != SystemError || wrapped.Code != 500 {
+		t.Errorf("got %s %d, want SYSTEM 500", wrapped.Type, wrapped.Code)
+	}
+	if wrapped.Headers != nil || wrapped.MessageKey != "" {
+		t.Errorf("unexpected Headers %v / MessageKey %q", wrapped.Headers, wrapped.MessageKey)
+	}
+}
+
+func TestAsStdError(t *testing.T) {
+	appErr := NewBusinessError(404, "Order not found").
+		WithData(map[string]interface{}{"order_id": 42}).
+		WithRequestID("req-1")
+	appErr.Cause = errors.New("sql: no rows in result set")
+
+	stdErr := appErr.AsStdError()
+
+	if stdErr.Error() != "Order not found" {
+		t.Errorf("Error() = %q, want only the message", stdErr.Error())
+	}
+	var target *AppError
+	if errors.As(stdErr, &target) {
+		t.Error("AsStdError result is still an AppError")
+	}
+	if errors.Unwrap(stdErr) != nil || errors.Is(stdErr, appErr.Cause) {
+		t.Error("AsStdError result exposes the cause chain")
+	}
+}
+
+func TestComponentFromFunction(t *testing.T) {
+	cases := map[string]string{
+		"github.com/acme/shop/orders.(*Service).Checkout": "orders",
+		"orders.(*Service).Checkout":                      "orders",
+		"main.main.func1":                                 "main",
+		"github.com/acme/shop/internal/payment.Charge":    "payment",
+		"unknown":   "unknown",
+		"":          "unknown",
+		"noPackage": "unknown",
+	}
+	for function, want := range cases {
+		if got := componentFromFunction(function); got != want {
+			t.Errorf("componentFromFunction(%q) = %q, want %q", function, got, want)
+		}
+	}
+}
+
+func TestComponentLogField(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+	defer SetIncludeComponent(false)
+
+	// Mặc định tắt: không có label component
+	LogError(NewBusinessError(404, "Order not found"), "GET /orders/1")
+	if entry, _ := mem.Find("", "Order not found"); entry.Fields["component"] != nil {
+		t.Errorf("component logged while disabled: %v", entry.Fields["component"])
+	}
+
+	SetIncludeComponent(true)
+	mem.Reset()
+	appErr := NewBusinessError(404, "Order not found")
+	LogError(appErr, "GET /orders/1")
+	LogError(&AppError{Type: SystemError, Code: 500, Message: "No location"}, "GET /orders/1")
+
+	entry, _ := mem.Find("", "Order not found")
+	if entry.Fields["component"] != "goerrorkit" || entry.Fields["component"] != appErr.Component() {
+		t.Errorf("component = %v, want goerrorkit (package of this test)", entry.Fields["component"])
+	}
+	if entry, _ := mem.Find("", "No location"); entry.Fields["component"] != "unknown" {
+		t.Errorf("component without location = %v, want unknown", entry.Fields["component"])
+	}
+}
+
+func TestAuthErrorHeadersAndHint(t *testing.T) {
+	UseMemoryLogger()
+	defer SetLogger(nil)
+
+	tests := []struct {
+		name         string
+		err          *AppError
+		status       int
+		authenticate string
+		authAction   string
+	}{
+		{"unauthenticated", NewUnauthenticatedError("Missing authorization token"), 401, "Bearer", "login"},
+		{"forbidden", NewForbiddenError("Admin permission required"), 403, "", "contact_admin"},
+		{"auth error 401", NewAuthError(401, "Token expired"), 401, "", "login"},
+		{"other auth code", NewAuthError(419, "Session expired"), 419, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err.Type != AuthError {
+				t.Errorf("Type = %s, want AUTH", tt.err.Type)
+			}
+			ctx := newTestContext("GET", "/admin")
+			LogAndRespond(ctx, tt.err, "GET /admin")
+
+			if ctx.status != tt.status {
+				t.Errorf("status = %d, want %d", ctx.status, tt.status)
+			}
+			if got := ctx.respHeaders["WWW-Authenticate"]; got != tt.authenticate {
+				t.Errorf("WWW-Authenticate = %q, want %q", got, tt.authenticate)
+			}
+			got, ok := ctx.response()["auth_action"]
+			if tt.authAction == "" {
+				if ok {
+					t.Errorf("auth_action = %v, want none", got)
+				}
+			} else if got != tt.authAction {
+				t.Errorf("auth_action = %v, want %s", got, tt.authAction)
+			}
+		})
+	}
+}
+
+func TestAuthActionOnlyForAuthError(t *testing.T) {
+	if _, ok := FormatErrorResponse(NewBusinessError(403, "Quota exceeded"))["auth_action"]; ok {
+		t.Error("auth_action set on a non-auth error")
+	}
+}
+
+func TestSetAuthActions(t *testing.T) {
+	prev := authActions
+	defer SetAuthActions(prev)
+
+	SetAuthActions(map[int]string{401: "refresh_token"})
+	if got := FormatErrorResponse(NewUnauthenticatedError("Token expired"))["auth_action"]; got != "refresh_token" {
+		t.Errorf("401 auth_action = %v, want refresh_token", got)
+	}
+	if got, ok := FormatErrorResponse(NewForbiddenError("Admin only"))["auth_action"]; ok {
+		t.Errorf("403 auth_action = %v, want none after mapping replaced", got)
+	}
+}
+
+func TestSetAuthActionsCopiesMap(t *testing.T) {
+	prev := authActions
+	defer SetAuthActions(prev)
+
+	actions := map[int]string{401: "refresh_token"}
+	SetAuthActions(actions)
+	actions[401] = "changed"
+
+	if got := FormatErrorResponse(NewUnauthenticatedError("Token expired"))["auth_action"]; got != "refresh_token" {
+		t.Errorf("401 auth_action = %v, want caller map copied", got)
+	}
+}
+
+func TestSetAuthActionsConcurrent(t *testing.T) {
+	prev := authActions
+	defer SetAuthActions(prev)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				SetAuthActions(map[int]string{401: "login"})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				FormatErrorResponse(NewUnauthenticatedError("Token expired"))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestWithExpectation(t *testing.T) {
+	appErr := NewValidationError("Invalid config version", nil).
+		WithData(map[string]interface{}{"file": "app.yaml"}).
+		WithExpectation("v2", "v1")
+
+	want := map[string]interface{}{"expected": "v2", "received": "v1"}
+	if got := appErr.Data["expectation"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("expectation = %#v, want %#v", got, want)
+	}
+	if appErr.Data["file"] != "app.yaml" {
+		t.Errorf("Data = %v, want existing data kept", appErr.Data)
+	}
+
+	// Giá trị structured được giữ nguyên kiểu
+	appErr = NewValidationError("Invalid ports", nil).WithExpectation([]int{80, 443}, []int{8080})
+	expectation := appErr.Data["expectation"].(map[string]interface{})
+	if !reflect.DeepEqual(expectation["expected"], []int{80, 443}) || !reflect.DeepEqual(expectation["received"], []int{8080}) {
+		t.Errorf("expectation = %#v", expectation)
+	}
+}
+
+func TestWithExpectationLogged(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	LogError(NewValidationError("Invalid timeout", nil).WithExpectation("<= 30s", "45s"), "PUT /config")
+
+	entry, ok := mem.Find("", "Invalid timeout")
+	if !ok {
+		t.Fatalf("error not logged: %v", mem.Entries())
+	}
+	data, _ := entry.Fields["data"].(map[string]interface{})
+	expectation, _ := data["expectation"].(map[string]interface{})
+	if expectation["expected"] != "<= 30s" || expectation["received"] != "45s" {
+		t.Errorf("logged data = %v", entry.Fields["data"])
+	}
+}
+
+func TestCreatedAt(t *testing.T) {
+	before := time.Now()
+	errs := map[string]*AppError{
+		"business":   NewBusinessError(404, "Order not found"),
+		"system":     NewSystemError(errors.New("db down")),
+		"validation": NewValidationError("Invalid email", nil),
+		"auth":       NewAuthError(401, "Unauthorized"),
+		"external":   NewExternalError(502, "Gateway down", errors.New("timeout")),
+		"wrap":       Wrap(errors.New("db down")),
+		"converted":  ConvertToAppError(errors.New("db down"), "req-1"),
+		"panic":      HandlePanic("boom", "req-1"),
+	}
+	after := time.Now()
+
+	for name, appErr := range errs {
+		if appErr.CreatedAt.Before(before) || appErr.CreatedAt.After(after) {
+			t.Errorf("%s: CreatedAt = %v, want between %v and %v", name, appErr.CreatedAt, before, after)
+		}
+	}
+}
+
+func TestCreatedAtLogged(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	appErr := NewBusinessError(404, "Order not found")
+	LogError(appErr, "GET /orders/42")
+
+	entry, _ := mem.Find("", "Order not found")
+	if got, want := entry.Fields["created_at"], appErr.CreatedAt.Format(time.RFC3339Nano); got != want {
+		t.Errorf("created_at = %v, want %s", got, want)
+	}
+}
+
+func TestWithField(t *testing.T) {
+	appErr := NewBusinessError(404, "Order not found").
+		WithField("user_id", 7).
+		WithField("order_id", "A-42")
+
+	want := map[string]interface{}{"user_id": 7, "order_id": "A-42"}
+	if !reflect.DeepEqual(appErr.Data, want) {
+		t.Errorf("Data = %v, want %v", appErr.Data, want)
+	}
+
+	// WithField sau WithData thêm key, không thay thế Data
+	appErr = NewBusinessError(404, "Order not found").
+		WithData(map[string]interface{}{"user_id": 7}).
+		WithField("order_id", "A-42")
+	if !reflect.DeepEqual(appErr.Data, want) {
+		t.Errorf("Data = %v, want %v", appErr.Data, want)
+	}
+}
+
+func TestDataOmittedWhenEmpty(t *testing.T) {
+	withDebugResponses(t, true)
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	tests := []struct {
+		name    string
+		data    map[string]interface{}
+		hasData bool
+	}{
+		{"nil", nil, false},
+		{"empty", map[string]interface{}{}, false},
+		{"populated", map[string]interface{}{"order_id": 42}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mem.Reset()
+			appErr := NewBusinessError(404, "Order not found").WithData(tt.data)
+			if appErr.HasData() != tt.hasData {
+				t.Errorf("HasData() = %v, want %v", appErr.HasData(), tt.hasData)
+			}
+
+			ctx := newTestContext("GET", "/orders/42")
+			LogAndRespond(ctx, appErr, "GET /orders/42")
+
+			entry, _ := mem.Find("", "Order not found")
+			_, logged := entry.Fields["data"]
+			debugInfo, _ := ctx.response()["debug"].(map[string]interface{})
+			_, responded := debugInfo["data"]
+			if logged != tt.hasData || responded != tt.hasData {
+				t.Errorf("data in log = %v, in debug response = %v, want %v", logged, responded, tt.hasData)
+			}
+		})
+	}
+}
+
+func TestDataNeverInResponseWithoutDebug(t *testing.T) {
+	withDebugResponses(t, false)
+	resp := FormatErrorResponse(NewBusinessError(404, "Order not found").WithData(map[string]interface{}{"order_id": 42}))
+	if _, ok := resp["data"]; ok {
+		t.Errorf("response = %v, data must stay internal", resp)
+	}
+}
+
+func TestNewTooManyRequestsErrorRetryAfter(t *testing.T) {
+	tests := []struct {
+		retryAfter time.Duration
+		want       string // "" → không có header
+	}{
+		{30 * time.Second, "30"},
+		{1500 * time.Millisecond, "2"},
+		{time.Nanosecond, "1"},
+		{2 * time.Minute, "120"},
+		{0, ""},
+		{-time.Second, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.retryAfter.String(), func(t *testing.T) {
+			appErr := NewTooManyRequestsError("Too many requests", tt.retryAfter)
+			if appErr.Type != BusinessError || appErr.Code != 429 {
+				t.Errorf("got %s %d, want 429 BusinessError", appErr.Type, appErr.Code)
+			}
+			got, ok := appErr.Headers["Retry-After"]
+			if got != tt.want || ok != (tt.want != "") {
+				t.Errorf("Retry-After = %q (set %v), want %q", got, ok, tt.want)
+			}
+		})
+	}
+
+	if got := NewBusinessError(503, "Busy").WithRetryAfter(-time.Second).Headers["Retry-After"]; got != "0" {
+		t.Errorf("WithRetryAfter(negative) = %q, want 0", got)
+	}
+}
+
+func TestWithHeaderSanitizes(t *testing.T) {
+	appErr := NewBusinessError(404, "Product not found").
+		WithHeader("Cache-Control", "public, max-age=30").
+		WithHeader("X-Note", "a\r\nSet-Cookie: session=evil\x00").
+		WithHeader("Bad Key", "v").
+		WithHeader("Bad:Key", "v").
+		WithHeader("", "v")
+
+	want := map[string]string{
+		"Cache-Control": "public, max-age=30",
+		"X-Note":        "aSet-Cookie: session=evil",
+	}
+	if !reflect.DeepEqual(appErr.Headers, want) {
+		t.Errorf("Headers = %q, want %q", appErr.Headers, want)
+	}
+}
+
+func TestLogAndRespondAppliesHeaders(t *testing.T) {
+	UseMemoryLogger()
+	defer SetLogger(nil)
+
+	appErr := NewTooManyRequestsError("Too many requests", 30*time.Second)
+	// Headers gán trực tiếp (không qua WithHeader) vẫn được sanitize trước khi gửi
+	appErr.Headers["X-Injected"] = "ok\r\nX-Evil: 1"
+	appErr.Headers["Bad Key"] = "v"
+
+	ctx := newTestContext("GET", "/api/orders")
+	LogAndRespond(ctx, appErr, "GET /api/orders")
+
+	want := map[string]string{"Retry-After": "30", "X-Injected": "okX-Evil: 1"}
+	if !reflect.DeepEqual(ctx.respHeaders, want) {
+		t.Errorf("response headers = %q, want %q", ctx.respHeaders, want)
+	}
+	if ctx.status != 429 {
+		t.Errorf("status = %d, want 429", ctx.status)
+	}
+}
+
+// providerError là error type riêng để kiểm tra errors.As qua WithCauses
+type providerError struct{ provider string }
+
+func (e *providerError) Error() string { return e.provider + " unavailable" }
+
+func TestDataAccessors(t *testing.T) {
+	appErr := NewBusinessError(404, "Order not found").WithData(map[string]interface{}{
+		"order_id": "A-42",
+		"qty":      int64(3),
+		"page":     float64(2), // số sau khi decode JSON
+		"ratio":    1.5,
+	})
+
+	if v, ok := appErr.GetData("order_id"); !ok || v != "A-42" {
+		t.Errorf("GetData(order_id) = %v, %v", v, ok)
+	}
+	if _, ok := appErr.GetData("missing"); ok {
+		t.Error("GetData(missing) ok = true, want false")
+	}
+	if s, ok := appErr.GetDataString("order_id"); !ok || s != "A-42" {
+		t.Errorf("GetDataString(order_id) = %q, %v", s, ok)
+	}
+	if _, ok := appErr.GetDataString("qty"); ok {
+		t.Error("GetDataString(qty) ok = true, want false for non-string")
+	}
+
+	tests := []struct {
+		key  string
+		want int
+		ok   bool
+	}{
+		{"qty", 3, true},
+		{"page", 2, true},
+		{"ratio", 0, false},
+		{"order_id", 0, false},
+		{"missing", 0, false},
+	}
+	for _, tt := range tests {
+		if got, ok := appErr.GetDataInt(tt.key); got != tt.want || ok != tt.ok {
+			t.Errorf("GetDataInt(%s) = %d, %v, want %d, %v", tt.key, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	// Data nil không panic
+	var empty AppError
+	if _, ok := empty.GetDataInt("qty"); ok {
+		t.Error("GetDataInt on nil Data ok = true, want false")
+	}
+}
+
+func TestGetDetail(t *testing.T) {
+	appErr := NewBusinessError(404, "Order not found")
+	if file, ok := appErr.GetDetail("file"); !ok || file == "" {
+		t.Errorf("GetDetail(file) = %v, %v, want creation site", file, ok)
+	}
+	if _, ok := appErr.GetDetail("missing"); ok {
+		t.Error("GetDetail(missing) ok = true, want false")
+	}
+}
+
+func TestWithCausesErrorsIsAndAs(t *testing.T) {
+	errTimeout := errors.New("timeout")
+	stripe := &providerError{provider: "stripe"}
+	appErr := NewExternalError(502, "Some providers failed", nil).
+		WithCauses(errTimeout, nil, fmt.Errorf("paypal: %w", stripe))
+
+	if len(appErr.Causes) != 2 {
+		t.Fatalf("Causes = %v, want nil skipped", appErr.Causes)
+	}
+	if !errors.Is(appErr, errTimeout) {
+		t.Error("errors.Is should reach the first cause")
+	}
+	var pe *providerError
+	if !errors.As(appErr, &pe) || pe != stripe {
+		t.Errorf("errors.As should reach the wrapped second cause, got %v", pe)
+	}
+}
+
+func TestWithCausesKeepsExistingCause(t *testing.T) {
+	errDB := errors.New("db down")
+	errCache := errors.New("cache down")
+	appErr := NewSystemError(errDB).WithCauses(errCache)
+
+	if !reflect.DeepEqual(appErr.Causes, []error{errDB, errCache}) {
+		t.Errorf("Causes = %v, want existing Cause first", appErr.Causes)
+	}
+	if !errors.Is(appErr, errDB) || !errors.Is(appErr, errCache) {
+		t.Error("errors.Is should reach both causes")
+	}
+}
+
+func TestWithCausesLogged(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	LogError(NewExternalError(502, "Some providers failed", nil).
+		WithCauses(errors.New("stripe: timeout"), errors.New("paypal: 503")), "POST /checkout")
+
+	entry, ok := mem.Find("error", "Some providers failed")
+	if !ok {
+		t.Fatalf("entry not logged: %v", mem.Entries())
+	}
+	if got := entry.Fields["causes"]; !reflect.DeepEqual(got, []string{"stripe: timeout", "paypal: 503"}) {
+		t.Errorf("causes = %#v", got)
+	}
+	if _, ok := entry.Fields["cause"]; ok {
+		t.Errorf("cause = %v, want only causes for WithCauses", entry.Fields["cause"])
+	}
+}
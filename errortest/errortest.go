@@ -0,0 +1,62 @@
+// Package errortest cung cấp helper để assert goerrorkit.AppError trong test
+//
+// Example:
+//
+//	func TestGetUser_NotFound(t *testing.T) {
+//	    _, err := svc.GetUser(ctx, "missing")
+//	    errortest.AssertAppError(t, err, goerrorkit.BusinessError, 404)
+//	    errortest.AssertErrorCode(t, err, "USER_NOT_FOUND")
+//	}
+package errortest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/techmaster-vietnam/goerrorkit"
+)
+
+// AsAppError lấy *goerrorkit.AppError trong chain của err (hỗ trợ error bị wrap)
+// Fail test nếu err là nil hoặc không chứa AppError
+func AsAppError(t testing.TB, err error) *goerrorkit.AppError {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("expected *goerrorkit.AppError, got nil error")
+		return nil
+	}
+	var appErr *goerrorkit.AppError
+	if !errors.As(err, &appErr) {
+		t.Fatalf("expected *goerrorkit.AppError in chain, got %T: %v", err, err)
+		return nil
+	}
+	return appErr
+}
+
+// AssertAppError assert err chứa AppError với ErrorType và HTTP code mong đợi
+// Trả về AppError để assert thêm (Data, Details, ...)
+func AssertAppError(t testing.TB, err error, wantType goerrorkit.ErrorType, wantCode int) *goerrorkit.AppError {
+	t.Helper()
+	appErr := AsAppError(t, err)
+	if appErr == nil {
+		return nil
+	}
+	if appErr.Type != wantType || appErr.Code != wantCode {
+		t.Errorf("AppError mismatch:\n  got:  type=%s code=%d message=%q\n  want: type=%s code=%d",
+			appErr.Type, appErr.Code, appErr.Message, wantType, wantCode)
+	}
+	return appErr
+}
+
+// AssertErrorCode assert err chứa AppError với application error code mong đợi (xem WithErrorCode)
+func AssertErrorCode(t testing.TB, err error, wantCode string) *goerrorkit.AppError {
+	t.Helper()
+	appErr := AsAppError(t, err)
+	if appErr == nil {
+		return nil
+	}
+	if appErr.ErrCode != wantCode {
+		t.Errorf("AppError error code mismatch:\n  got:  %q (type=%s code=%d message=%q)\n  want: %q",
+			appErr.ErrCode, appErr.Type, appErr.Code, appErr.Message, wantCode)
+	}
+	return appErr
+}
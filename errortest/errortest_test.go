@@ -0,0 +1,95 @@
+package errortest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/techmaster-vietnam/goerrorkit"
+)
+
+// fakeTB ghi lại lỗi thay vì fail test thật (Fatalf không dừng goroutine)
+type fakeTB struct {
+	testing.TB
+	errors []string
+	fatal  bool
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeTB) Fatalf(format string, args ...interface{}) {
+	f.fatal = true
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeTB) failed(t *testing.T, want string) {
+	t.Helper()
+	if len(f.errors) != 1 || !strings.Contains(f.errors[0], want) {
+		t.Errorf("failures = %q, want one containing %q", f.errors, want)
+	}
+}
+
+func TestAsAppError(t *testing.T) {
+	appErr := goerrorkit.NewBusinessError(404, "User not found")
+	wrapped := fmt.Errorf("get user: %w", appErr)
+
+	tb := &fakeTB{}
+	if got := AsAppError(tb, wrapped); got != appErr || len(tb.errors) != 0 {
+		t.Errorf("AsAppError = %v, failures %q", got, tb.errors)
+	}
+
+	tb = &fakeTB{}
+	if got := AsAppError(tb, nil); got != nil || !tb.fatal {
+		t.Errorf("nil error: got %v, fatal = %v", got, tb.fatal)
+	}
+	tb.failed(t, "got nil error")
+
+	tb = &fakeTB{}
+	if got := AsAppError(tb, errors.New("plain")); got != nil || !tb.fatal {
+		t.Errorf("plain error: got %v, fatal = %v", got, tb.fatal)
+	}
+	tb.failed(t, "*errors.errorString")
+}
+
+func TestAssertAppError(t *testing.T) {
+	err := fmt.Errorf("wrap: %w", goerrorkit.NewBusinessError(404, "User not found"))
+
+	tb := &fakeTB{}
+	if AssertAppError(tb, err, goerrorkit.BusinessError, 404) == nil || len(tb.errors) != 0 {
+		t.Errorf("matching type/code reported %q", tb.errors)
+	}
+
+	tb = &fakeTB{}
+	if AssertAppError(tb, err, goerrorkit.SystemError, 500) == nil || tb.fatal {
+		t.Errorf("mismatch should use Errorf and still return the AppError")
+	}
+	tb.failed(t, "code=404")
+
+	tb = &fakeTB{}
+	if AssertAppError(tb, nil, goerrorkit.BusinessError, 404) != nil || !tb.fatal {
+		t.Errorf("nil error should fail fatally")
+	}
+}
+
+func TestAssertErrorCode(t *testing.T) {
+	err := goerrorkit.NewBusinessError(404, "User not found").WithErrorCode("USER_NOT_FOUND")
+
+	tb := &fakeTB{}
+	if AssertErrorCode(tb, err, "USER_NOT_FOUND") == nil || len(tb.errors) != 0 {
+		t.Errorf("matching code reported %q", tb.errors)
+	}
+
+	tb = &fakeTB{}
+	AssertErrorCode(tb, err, "ORDER_NOT_FOUND")
+	tb.failed(t, `"USER_NOT_FOUND"`)
+
+	tb = &fakeTB{}
+	if AssertErrorCode(tb, errors.New("plain"), "USER_NOT_FOUND") != nil || !tb.fatal {
+		t.Errorf("plain error should fail fatally")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"github.com/techmaster-vietnam/goerrorkit"
+	goerrorkitfiber "github.com/techmaster-vietnam/goerrorkit/adapters/fiber"
 )
 
 func main() {
@@ -40,6 +41,8 @@ func main() {
 		LogLevel:      "trace", // Console log từ trace (cần -tags=debug), warn, error trở lên
 		FileLogLevel:  "error", // File chỉ log error và panic (bỏ qua warn)
 	})
+	// Đóng file log khi main kết thúc để record cuối cùng không bị mất
+	defer goerrorkit.CloseLogger()
 
 	// 2. Configure stack trace for this application
 	// 🎯 MỤC ĐÍCH: Lọc stack trace để CHỈ HIỂN THỊ code của BẠN, bỏ qua:
@@ -93,7 +96,7 @@ func main() {
 	// 4. Add middlewares (RequestID must be before ErrorHandler)
 	app.Use(requestid.New())
 	app.Use(logger.New())
-	app.Use(goerrorkit.FiberErrorHandler())
+	app.Use(goerrorkitfiber.ErrorHandler())
 
 	// 5. Routes - Demo different error types
 	app.Get("/", homeHandler)
@@ -788,68 +791,59 @@ func debugHandler(c *fiberv2.Ctx) error {
 func traceComplexFlowHandler(c *fiberv2.Ctx) error {
 	orderID := c.Query("order_id", "ORD-12345")
 
-	// ⭐ Start trace
-	goerrorkit.Trace("Order Processing Flow Started", map[string]interface{}{
-		"order_id":  orderID,
-		"timestamp": "2025-11-28T10:30:00Z",
+	// ⭐ Start trace flow - các bước được gom lại và emit MỘT dòng summary khi End()
+	flow := goerrorkit.StartTraceFlow("Order Processing Flow", map[string]interface{}{
+		"order_id": orderID,
 	})
 
 	// Step 1: Validate order
-	goerrorkit.Trace("Step 1: Validating order", map[string]interface{}{
+	flow.Step("Validating order", map[string]interface{}{
 		"order_exists":   true,
 		"customer_id":    "CUST-456",
 		"payment_method": "valid",
-		"duration_ms":    50,
 	})
 
 	// Step 2: Check inventory
-	goerrorkit.Trace("Step 2: Checking inventory", map[string]interface{}{
+	flow.Step("Checking inventory", map[string]interface{}{
 		"product_id":    "PROD-789",
 		"requested_qty": 2,
 		"available_qty": 10,
 		"stock_status":  "available",
-		"duration_ms":   120,
 	})
 
 	// Step 3: Reserve inventory
-	goerrorkit.Trace("Step 3: Reserving inventory", map[string]interface{}{
+	flow.Step("Reserving inventory", map[string]interface{}{
 		"warehouse":      "WH-01",
 		"reservation_id": "RES-999",
 		"status":         "reserved",
-		"duration_ms":    80,
 	})
 
 	// Step 4: Process payment
-	goerrorkit.Trace("Step 4: Processing payment", map[string]interface{}{
+	flow.Step("Processing payment", map[string]interface{}{
 		"amount":         "200,000 VND",
 		"gateway":        "stripe",
 		"transaction_id": "TXN-111",
 		"status":         "captured",
-		"duration_ms":    450,
 	})
 
 	// Step 5: Create shipment
-	goerrorkit.Trace("Step 5: Creating shipment", map[string]interface{}{
+	flow.Step("Creating shipment", map[string]interface{}{
 		"carrier":            "DHL",
 		"tracking_number":    "DHL123456789",
 		"estimated_delivery": "2025-12-02",
 		"status":             "created",
-		"duration_ms":        200,
 	})
 
 	// Step 6: Send confirmation
-	goerrorkit.Trace("Step 6: Sending confirmation email", map[string]interface{}{
-		"to":          "customer@example.com",
-		"template":    "order_confirmation",
-		"status":      "sent",
-		"duration_ms": 300,
+	flow.Step("Sending confirmation email", map[string]interface{}{
+		"to":       "customer@example.com",
+		"template": "order_confirmation",
+		"status":   "sent",
 	})
 
-	// ⭐ End trace with summary
-	goerrorkit.Trace("Order Processing Flow Completed", map[string]interface{}{
-		"total_duration_ms": 1200,
-		"order_status":      "confirmed",
-		"all_steps":         "successful",
+	// ⭐ End trace with summary (total duration + breakdown từng step)
+	flow.End(map[string]interface{}{
+		"order_status": "confirmed",
 	})
 
 	return c.JSON(fiberv2.Map{
@@ -857,7 +851,7 @@ func traceComplexFlowHandler(c *fiberv2.Ctx) error {
 		"order_id":        orderID,
 		"status":          "confirmed",
 		"tracking_number": "DHL123456789",
-		"trace":           "Check console for detailed flow trace (6 steps)",
+		"trace":           "Check console for flow trace summary (6 steps)",
 		"total_duration":  "1,200ms",
 	})
 }
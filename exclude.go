@@ -0,0 +1,84 @@
+package goerrorkit
+
+import (
+	"path"
+	"strings"
+	"sync"
+)
+
+// excludedPaths là danh sách pattern path không ghi log (set bởi SetExcludedPaths)
+var (
+	excludedPathsMu sync.RWMutex
+	excludedPaths   []string
+)
+
+// SetExcludedPaths cấu hình các request path KHÔNG ghi error log (health check, metrics, ...)
+// Error vẫn được convert và response về client bình thường, chỉ bỏ qua bước log.
+// Gọi lại sẽ thay thế danh sách cũ; gọi không tham số để xóa.
+//
+// Pattern hỗ trợ:
+//   - Exact: "/healthz"
+//   - Prefix (kết thúc bằng "*"): "/metrics*" match "/metrics", "/metrics/go"
+//   - Glob (path.Match): "/api/*/ping" match "/api/v1/ping"
+//
+// Example:
+//
+//	goerrorkit.SetExcludedPaths("/healthz", "/readyz", "/metrics*")
+func SetExcludedPaths(paths ...string) {
+	patterns := make([]string, 0, len(paths))
+	for _, p := range paths {
+		if p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+
+	excludedPathsMu.Lock()
+	excludedPaths = patterns
+	excludedPathsMu.Unlock()
+}
+
+// isExcludedPath kiểm tra request path có match pattern nào trong SetExcludedPaths không
+func isExcludedPath(requestPath string) bool {
+	excludedPathsMu.RLock()
+	defer excludedPathsMu.RUnlock()
+
+	for _, pattern := range excludedPaths {
+		if matchPathPattern(pattern, requestPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPathPattern match một path với pattern exact, prefix ("...*") hoặc glob
+func matchPathPattern(pattern, requestPath string) bool {
+	if pattern == requestPath {
+		return true
+	}
+	// Prefix: "*" ở cuối và không có ký tự glob nào khác
+	if prefix := strings.TrimSuffix(pattern, "*"); prefix != pattern && !strings.ContainsAny(prefix, "*?[") {
+		return strings.HasPrefix(requestPath, prefix)
+	}
+	matched, err := path.Match(pattern, requestPath)
+	return err == nil && matched
+}
+
+// LogRequestError log AppError của request mà không gửi response (framework agnostic)
+// Tôn trọng SetExcludedPaths và SetIncludeClientInfo giống LogAndRespond
+// Dùng khi response đã được ghi hoặc client đã đóng connection
+func LogRequestError(ctx HTTPContext, appErr *AppError, requestPath string) {
+	logRequestError(ctx, appErr, requestPath, nil)
+}
+
+// logRequestError log AppError trừ khi request path nằm trong SetExcludedPaths
+// client_ip/user_agent được thêm khi bật SetIncludeClientInfo, headers/query theo SetLoggedRequestHeaders /
+// SetLoggedQueryParams, field của LogFieldsProvider luôn được thêm
+func logRequestError(ctx HTTPContext, appErr *AppError, requestPath string, extra map[string]interface{}) {
+	if isExcludedPath(ctx.Path()) {
+		return
+	}
+	fields := contextLogFields(ctx, extra)
+	fields = clientInfoFields(ctx, fields)
+	fields = requestFields(ctx, fields)
+	logErrorWithFields(appErr, requestPath, fields)
+}
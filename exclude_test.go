@@ -0,0 +1,62 @@
+package goerrorkit
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMatchPathPattern(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"/healthz", "/healthz", true},
+		{"/healthz", "/healthz/db", false},
+		{"/metrics*", "/metrics", true},
+		{"/metrics*", "/metrics/go", true},
+		{"/metrics*", "/api/metrics", false},
+		{"/api/*/ping", "/api/v1/ping", true},
+		{"/api/*/ping", "/api/v1/v2/ping", false},
+		{"/api/*/ping*", "/api/v1/pingdom", true},
+		{"/[", "/[", true},
+		{"/[", "/x", false},
+	}
+	for _, tt := range tests {
+		if got := matchPathPattern(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("matchPathPattern(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestSetExcludedPathsSkipsLogging(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+	SetExcludedPaths("/healthz", "/metrics*")
+	defer SetExcludedPaths()
+
+	for _, path := range []string{"/healthz", "/metrics/go"} {
+		ctx := newTestContext("GET", path)
+		LogAndRespond(ctx, NewSystemError(errors.New("db ping failed")), "GET "+path)
+
+		// Vẫn response bình thường, chỉ bỏ qua log
+		if ctx.status != 500 || ctx.response()["error"] == nil {
+			t.Errorf("%s: status = %d, response = %v", path, ctx.status, ctx.response())
+		}
+	}
+	if entries := mem.Entries(); len(entries) != 0 {
+		t.Errorf("excluded paths logged: %v", entries)
+	}
+
+	ctx := newTestContext("GET", "/orders")
+	LogAndRespond(ctx, NewSystemError(errors.New("db ping failed")), "GET /orders")
+	if len(mem.Entries()) != 1 {
+		t.Errorf("/orders: entries = %v, want 1", mem.Entries())
+	}
+
+	// Gọi không tham số để xóa danh sách
+	SetExcludedPaths()
+	LogRequestError(newTestContext("GET", "/healthz"), NewSystemError(errors.New("db ping failed")), "GET /healthz")
+	if len(mem.Entries()) != 2 {
+		t.Errorf("after reset: entries = %v, want /healthz logged", mem.Entries())
+	}
+}
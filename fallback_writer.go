@@ -0,0 +1,41 @@
+package goerrorkit
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// fallbackWriter ghi vào primary (file log); nếu ghi lỗi (ví dụ disk full) thì ghi record
+// sang fallback (stdout) để log không bị mất, kèm một cảnh báo một lần ra stderr
+type fallbackWriter struct {
+	primary  io.WriteCloser
+	fallback io.Writer
+	warn     io.Writer // nơi ghi cảnh báo một lần (stderr)
+	warnOnce sync.Once
+}
+
+// newFallbackWriter tạo fallbackWriter với fallback là stdout, cảnh báo ra stderr
+func newFallbackWriter(primary io.WriteCloser) *fallbackWriter {
+	return &fallbackWriter{primary: primary, fallback: os.Stdout, warn: os.Stderr}
+}
+
+// Write implements io.Writer
+// Primary vẫn được thử lại ở mỗi lần ghi để tự phục hồi khi disk có chỗ trở lại
+func (w *fallbackWriter) Write(p []byte) (int, error) {
+	n, err := w.primary.Write(p)
+	if err == nil {
+		return n, nil
+	}
+
+	w.warnOnce.Do(func() {
+		fmt.Fprintf(w.warn, "goerrorkit: file log write failed, falling back to stdout: %v\n", err)
+	})
+	return w.fallback.Write(p)
+}
+
+// Close đóng primary writer
+func (w *fallbackWriter) Close() error {
+	return w.primary.Close()
+}
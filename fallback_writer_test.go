@@ -0,0 +1,81 @@
+package goerrorkit
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+// failingWriter mô phỏng file log không ghi được (disk full) cho tới khi fail = false
+type failingWriter struct {
+	fail bool
+	buf  bytes.Buffer
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.fail {
+		return 0, syscall.ENOSPC
+	}
+	return w.buf.Write(p)
+}
+
+func (w *failingWriter) Close() error { return nil }
+
+func newTestFallbackWriter(primary *failingWriter) (*fallbackWriter, *bytes.Buffer, *bytes.Buffer) {
+	w := newFallbackWriter(primary)
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	w.fallback, w.warn = stdout, stderr
+	return w, stdout, stderr
+}
+
+func TestFallbackWriterFallsBackOnWriteError(t *testing.T) {
+	primary := &failingWriter{fail: true}
+	w, stdout, stderr := newTestFallbackWriter(primary)
+
+	for _, record := range []string{"first\n", "second\n"} {
+		if n, err := w.Write([]byte(record)); err != nil || n != len(record) {
+			t.Fatalf("Write(%q) = %d, %v", record, n, err)
+		}
+	}
+	if stdout.String() != "first\nsecond\n" {
+		t.Errorf("stdout = %q, want both records", stdout.String())
+	}
+	// Cảnh báo chỉ một lần
+	if got := strings.Count(stderr.String(), "falling back to stdout"); got != 1 || !strings.Contains(stderr.String(), "no space left") {
+		t.Errorf("stderr = %q, want a single warning with the cause", stderr.String())
+	}
+
+	// Disk có chỗ trở lại: ghi vào file, không ghi stdout nữa
+	primary.fail = false
+	_, _ = w.Write([]byte("third\n"))
+	if primary.buf.String() != "third\n" || strings.Contains(stdout.String(), "third") {
+		t.Errorf("file = %q, stdout = %q, want recovery to the file", primary.buf.String(), stdout.String())
+	}
+}
+
+func TestFileSinkFailureStillReachesConsole(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.log")
+	logger, console := newTestLogrusLogger(t, LoggerOptions{
+		ConsoleOutput: true,
+		FileOutput:    true,
+		FilePath:      path,
+		JSONFormat:    true,
+	})
+	fw, ok := logger.files[0].file.(*fallbackWriter)
+	if !ok {
+		t.Fatalf("file sink writer = %T, want *fallbackWriter", logger.files[0].file)
+	}
+	stdout := &bytes.Buffer{}
+	fw.primary, fw.fallback, fw.warn = &failingWriter{fail: true}, stdout, &bytes.Buffer{}
+
+	logger.Error("Internal server error", map[string]interface{}{"error_type": "SYSTEM"})
+
+	if !strings.Contains(console.String(), "Internal server error") {
+		t.Errorf("console = %q, want record", console.String())
+	}
+	if !strings.Contains(stdout.String(), `"message":"Internal server error"`) {
+		t.Errorf("stdout = %q, want JSON record from failed file write", stdout.String())
+	}
+}
@@ -0,0 +1,192 @@
+package goerrorkit
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// FileSink cấu hình một file log với khoảng level riêng (LoggerOptions.FileOutputs)
+// Dùng để tách noise (validation, auth - warn) khỏi sự cố thật (error, panic)
+//
+// Example:
+//
+//	opts.FileOutputs = []goerrorkit.FileSink{
+//	    {Path: "logs/warnings.log", MinLevel: "warn", MaxLevel: "warn"},
+//	    {Path: "logs/errors.log", MinLevel: "error"},
+//	}
+type FileSink struct {
+	// Path - Đường dẫn file log (bắt buộc)
+	Path string
+
+	// MinLevel - Level ít nghiêm trọng nhất được ghi (VD: "warn" → ghi warn, error, panic)
+	// Rỗng → "error"
+	MinLevel string
+
+	// MaxLevel - Level nghiêm trọng nhất được ghi (VD: "warn" → bỏ qua error, panic)
+	// Rỗng → không giới hạn
+	MaxLevel string
+
+	// Format - "json", "ecs" hoặc "logfmt". Rỗng → theo LoggerOptions.Format/JSONFormat
+	// (format "text" của console → file dùng JSON)
+	Format string
+}
+
+// fileSinkLogger là một file output đã khởi tạo của LogrusLogger
+type fileSinkLogger struct {
+	path     string         // FileSink.Path (dùng bởi SetFileSinkLevel)
+	logger   *logrus.Logger // lọc theo MinLevel (logrus level)
+	monitor  *logrus.Logger // cùng output/formatter nhưng không lọc level (cho MonitorOnly)
+	maxLevel logrus.Level   // entry nghiêm trọng hơn maxLevel bị bỏ qua (PanicLevel: không giới hạn)
+	async    *asyncWriter   // nil nếu ghi file đồng bộ
+	file     io.Closer      // file writer (lumberjack hoặc timeRotatingWriter qua fallbackWriter)
+}
+
+// close drain async buffer (nếu có) và đóng file
+func (s *fileSinkLogger) close() error {
+	if s.async != nil {
+		return s.async.Close() // asyncWriter đóng file sau khi drain
+	}
+	return s.file.Close()
+}
+
+// accepts kiểm tra entry level có nằm trong khoảng [MinLevel, MaxLevel] của sink không
+func (s *fileSinkLogger) accepts(lvl logrus.Level) bool {
+	return lvl >= s.maxLevel && s.logger.IsLevelEnabled(lvl)
+}
+
+// log ghi entry nếu level nằm trong khoảng của sink
+func (s *fileSinkLogger) log(lvl logrus.Level, msg string, fields map[string]interface{}) {
+	if s.accepts(lvl) {
+		s.logger.WithFields(fields).Log(lvl, msg)
+	}
+}
+
+// fileSinks trả về danh sách sink cần khởi tạo: FileOutputs, hoặc FilePath/FileLogLevel
+// (shorthand cho một sink) khi FileOutput bật
+func fileSinks(opts LoggerOptions) []FileSink {
+	if len(opts.FileOutputs) > 0 {
+		return opts.FileOutputs
+	}
+	if opts.FileOutput {
+		return []FileSink{{Path: opts.FilePath, MinLevel: opts.FileLogLevel}}
+	}
+	return nil
+}
+
+// newFileSinkLogger khởi tạo file output cho sink (rotation theo opts, formatter theo format)
+// Luôn trả về sink dùng được (với giá trị fallback), kèm các lỗi cấu hình
+func newFileSinkLogger(opts LoggerOptions, sink FileSink, format string, fieldMap logrus.FieldMap) (*fileSinkLogger, []error) {
+	var errs []error
+	prefix := "FileOutputs[" + sink.Path + "]"
+	if len(opts.FileOutputs) == 0 {
+		prefix = "FileLogLevel"
+	}
+
+	// Rotate theo thời gian (RotateDaily/RotateInterval) hoặc chỉ theo kích thước
+	var logFile io.WriteCloser
+	activePath := sink.Path
+	if opts.RotateDaily || opts.RotateInterval > 0 {
+		sinkOpts := opts
+		sinkOpts.FilePath = sink.Path
+		rotating := newTimeRotatingWriter(sinkOpts)
+		activePath = rotating.currentFilename()
+		logFile = rotating
+	} else {
+		logFile = &lumberjack.Logger{
+			Filename:   sink.Path,
+			MaxSize:    opts.MaxFileSize,
+			MaxBackups: opts.MaxBackups,
+			MaxAge:     opts.MaxAge,
+			Compress:   true,
+			LocalTime:  true,
+		}
+	}
+
+	if sink.Path == "" {
+		if len(opts.FileOutputs) > 0 {
+			errs = append(errs, errors.New("FileOutputs: sink Path is empty"))
+		} else {
+			errs = append(errs, errors.New("FileOutput is enabled but FilePath is empty"))
+		}
+	} else {
+		// Tạo thư mục chứa file log nếu chưa có (lấy từ Path, không hardcode "logs")
+		dirPerm := opts.DirPerm
+		if dirPerm == 0 {
+			dirPerm = 0755
+		}
+		logDir := filepath.Dir(sink.Path)
+		if err := os.MkdirAll(logDir, dirPerm); err != nil {
+			errs = append(errs, fmt.Errorf("cannot create log directory %q: %w", logDir, err))
+		} else if f, err := os.OpenFile(activePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644); err != nil {
+			// Kiểm tra sớm file log có ghi được không (lumberjack chỉ mở file khi ghi lần đầu)
+			errs = append(errs, fmt.Errorf("cannot open log file %q: %w", activePath, err))
+		} else {
+			f.Close()
+		}
+	}
+
+	s := &fileSinkLogger{path: sink.Path, logger: logrus.New(), maxLevel: logrus.PanicLevel}
+
+	// File ghi lỗi (disk full, ...) → record được ghi ra stdout để không bị mất
+	fallbackFile := newFallbackWriter(logFile)
+	s.file = fallbackFile
+	var fileOutput io.Writer = fallbackFile
+	if opts.AsyncFile {
+		s.async = newAsyncWriter(fallbackFile, opts.AsyncBufferSize)
+		fileOutput = s.async
+	}
+	s.logger.SetOutput(fileOutput)
+
+	// Format riêng của sink (nếu có) thay cho format chung
+	if sink.Format != "" {
+		switch f := strings.ToLower(strings.TrimSpace(sink.Format)); f {
+		case logFormatJSON, logFormatECS, logFormatLogfmt:
+			format = f
+		default:
+			errs = append(errs, fmt.Errorf("%s: unsupported file format %q (supported: json, ecs, logfmt)", prefix, sink.Format))
+		}
+	}
+
+	// Cấu hình formatter cho file (JSON, ECS hoặc logfmt - không bao giờ là text có màu)
+	switch format {
+	case logFormatECS:
+		s.logger.SetFormatter(newECSFormatter(opts.TimestampFormat, opts.FilePrettyPrint))
+	case logFormatLogfmt:
+		s.logger.SetFormatter(newLogfmtFormatter(opts.TimestampFormat, fieldMap))
+	default:
+		s.logger.SetFormatter(newJSONFormatter(opts.TimestampFormat, opts.FilePrettyPrint, fieldMap))
+	}
+
+	// Set log level cho file (rỗng → mặc định error)
+	minLevel := logrus.ErrorLevel
+	if sink.MinLevel != "" {
+		parsed, err := parseLogrusLevel(sink.MinLevel)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", prefix, err))
+		} else {
+			minLevel = parsed
+		}
+	}
+	s.logger.SetLevel(minLevel)
+
+	if sink.MaxLevel != "" {
+		parsed, err := parseLogrusLevel(sink.MaxLevel)
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Errorf("%s: MaxLevel: %w", prefix, err))
+		case parsed > minLevel:
+			errs = append(errs, fmt.Errorf("%s: MaxLevel %q is less severe than MinLevel", prefix, sink.MaxLevel))
+		default:
+			s.maxLevel = parsed
+		}
+	}
+
+	return s, errs
+}
@@ -0,0 +1,198 @@
+package goerrorkit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// initSplitLogger cài logger với warnings.log (chỉ warn) và errors.log (error trở lên)
+func initSplitLogger(t *testing.T, opts LoggerOptions) (warnings, errs string) {
+	t.Helper()
+	dir := t.TempDir()
+	warnings = filepath.Join(dir, "warnings.log")
+	errs = filepath.Join(dir, "errors.log")
+	opts.JSONFormat = true
+	opts.FileOutputs = []FileSink{
+		{Path: warnings, MinLevel: "warn", MaxLevel: "warn", Format: "logfmt"},
+		{Path: errs, MinLevel: "error"},
+	}
+	if err := InitLoggerE(opts); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = CloseLogger()
+		SetLogger(nil)
+	})
+	return warnings, errs
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestFileOutputsSplitByLevel(t *testing.T) {
+	warnings, errs := initSplitLogger(t, LoggerOptions{})
+
+	LogError(NewValidationError("Email is required", nil), "POST /users")
+	LogError(NewSystemError(errors.New("db down")), "GET /orders")
+	if err := CloseLogger(); err != nil {
+		t.Fatal(err)
+	}
+
+	warnContent, errContent := readFile(t, warnings), readFile(t, errs)
+	if !strings.Contains(warnContent, "Email is required") || strings.Contains(warnContent, "db down") {
+		t.Errorf("warnings.log = %q, want only the ValidationError", warnContent)
+	}
+	if !strings.Contains(errContent, "db down") || strings.Contains(errContent, "Email is required") {
+		t.Errorf("errors.log = %q, want only the SystemError", errContent)
+	}
+}
+
+func TestFileOutputsPerSinkFormat(t *testing.T) {
+	warnings, errs := initSplitLogger(t, LoggerOptions{})
+
+	LogError(NewValidationError("Email is required", nil), "POST /users")
+	LogError(NewSystemError(errors.New("db down")), "GET /orders")
+	_ = CloseLogger()
+
+	// warnings.log: logfmt của sink; errors.log: JSON theo LoggerOptions
+	if got := readFile(t, warnings); !strings.Contains(got, "level=warning") || strings.HasPrefix(got, "{") {
+		t.Errorf("warnings.log = %q, want logfmt", got)
+	}
+	if records := jsonRecords(t, readFile(t, errs)); len(records) != 1 {
+		t.Errorf("errors.log records = %v, want one JSON record", records)
+	}
+}
+
+func TestFileOutputsRotationPerSink(t *testing.T) {
+	warnings, errs := initSplitLogger(t, LoggerOptions{RotateDaily: true})
+
+	LogError(NewValidationError("Email is required", nil), "POST /users")
+	LogError(NewSystemError(errors.New("db down")), "GET /orders")
+	_ = CloseLogger()
+
+	// Mỗi sink có file theo ngày riêng (errors-2025-11-28.log, warnings-2025-11-28.log)
+	for path, want := range map[string]string{warnings: "Email is required", errs: "db down"} {
+		dated, _ := filepath.Glob(strings.TrimSuffix(path, ".log") + "-????-??-??.log")
+		if len(dated) != 1 {
+			t.Fatalf("dated files for %s = %v, want one", filepath.Base(path), dated)
+		}
+		if got := readFile(t, dated[0]); !strings.Contains(got, want) {
+			t.Errorf("%s = %q, want %q", filepath.Base(dated[0]), got, want)
+		}
+	}
+}
+
+func TestFileOutputsMonitorFallsBackToFirstSink(t *testing.T) {
+	dir := t.TempDir()
+	errs := filepath.Join(dir, "errors.log")
+	logger, _ := newTestLogrusLogger(t, LoggerOptions{
+		JSONFormat:  true,
+		FileOutputs: []FileSink{{Path: errs, MinLevel: "error"}},
+	})
+
+	// Entry info nằm ngoài khoảng của mọi sink: MonitorOnly vẫn ghi vào sink đầu tiên
+	logger.Monitor("info", "Order not found", nil)
+	_ = logger.Close()
+
+	if got := readFile(t, errs); !strings.Contains(got, "Order not found") {
+		t.Errorf("errors.log = %q, want monitor entry", got)
+	}
+}
+
+func TestFileOutputsInvalidSink(t *testing.T) {
+	tests := []struct {
+		name string
+		sink FileSink
+		want string
+	}{
+		{"empty path", FileSink{}, "sink Path is empty"},
+		{"bad level", FileSink{Path: "errors.log", MinLevel: "loud"}, "invalid log level"},
+		{"bad format", FileSink{Path: "errors.log", Format: "yaml"}, "unsupported file format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := tt.sink
+			if sink.Path != "" {
+				sink.Path = filepath.Join(t.TempDir(), sink.Path)
+			}
+			logger, err := newLogrusLogger(LoggerOptions{FileOutputs: []FileSink{sink}})
+			logger.Close()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileOutputsRuntimeLevels(t *testing.T) {
+	warnings, errs := initSplitLogger(t, LoggerOptions{})
+
+	// SetFileLogLevel không được gộp các sink về cùng một level
+	if err := SetFileLogLevel("debug"); err == nil {
+		t.Error("SetFileLogLevel with several sinks = nil, want error")
+	}
+	if got := GetFileSinkLogLevels(); got[warnings] != "warn" || got[errs] != "error" {
+		t.Errorf("sink levels = %v, want per-sink MinLevel kept", got)
+	}
+	if _, file := GetLogLevels(); file != "warn" {
+		t.Errorf("file level = %q, want least severe MinLevel warn", file)
+	}
+
+	// Chỉ warnings.log nhận thêm info, errors.log giữ nguyên
+	if err := SetFileSinkLogLevel(warnings, "info"); err != nil {
+		t.Fatal(err)
+	}
+	if got := GetFileSinkLogLevels(); got[warnings] != "info" || got[errs] != "error" {
+		t.Errorf("sink levels = %v, want only warnings.log changed", got)
+	}
+	Info("Cache warmed", nil)
+	_ = CloseLogger()
+
+	if got := readFile(t, warnings); !strings.Contains(got, "Cache warmed") {
+		t.Errorf("warnings.log = %q, want info entry", got)
+	}
+	if got := readFile(t, errs); strings.Contains(got, "Cache warmed") {
+		t.Errorf("errors.log = %q, want no info entry", got)
+	}
+}
+
+func TestFileOutputsSetFileSinkLevelErrors(t *testing.T) {
+	warnings, _ := initSplitLogger(t, LoggerOptions{})
+
+	tests := []struct {
+		name, path, level string
+	}{
+		{"unknown sink", "other.log", "info"},
+		{"invalid level", warnings, "loud"},
+		{"above MaxLevel", warnings, "error"},
+	}
+	for _, tt := range tests {
+		if err := SetFileSinkLogLevel(tt.path, tt.level); err == nil {
+			t.Errorf("%s: SetFileSinkLogLevel(%q, %q) = nil, want error", tt.name, tt.path, tt.level)
+		}
+	}
+	if got := GetFileSinkLogLevels()[warnings]; got != "warn" {
+		t.Errorf("warnings.log level = %q after rejected changes, want warn", got)
+	}
+}
+
+func TestFileSinkLevelsUnsupportedLogger(t *testing.T) {
+	UseMemoryLogger()
+	defer SetLogger(nil)
+
+	if err := SetFileSinkLogLevel("errors.log", "info"); !errors.Is(err, errLevelAdjustUnsupported) {
+		t.Errorf("SetFileSinkLogLevel = %v, want errLevelAdjustUnsupported", err)
+	}
+	if got := GetFileSinkLogLevels(); got != nil {
+		t.Errorf("GetFileSinkLogLevels() = %v, want nil", got)
+	}
+}
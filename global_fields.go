@@ -0,0 +1,82 @@
+package goerrorkit
+
+import (
+	"os"
+	"sync"
+)
+
+var (
+	globalFieldsMu sync.RWMutex
+
+	// globalFields được merge vào mọi log entry (field của từng entry thắng khi trùng key)
+	globalFields map[string]interface{}
+
+	// hostFields chứa hostname và pid, tự động thêm vào mọi log entry trừ khi bị tắt
+	hostFields        = detectHostFields()
+	includeHostFields = true
+)
+
+// detectHostFields lấy hostname và pid của process hiện tại
+func detectHostFields() map[string]interface{} {
+	fields := map[string]interface{}{
+		"pid": os.Getpid(),
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		fields["hostname"] = hostname
+	}
+	return fields
+}
+
+// SetGlobalFields thiết lập các field được thêm vào MỌI log entry
+// (LogError và các helper Error/Info/Debug/Trace/Warn/Panic)
+// Field của từng entry thắng khi trùng key
+//
+// Example:
+//
+//	goerrorkit.SetGlobalFields(map[string]interface{}{
+//	    "service": "order-service",
+//	    "env":     os.Getenv("APP_ENV"),
+//	    "version": buildVersion,
+//	})
+func SetGlobalFields(fields map[string]interface{}) {
+	copied := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		copied[k] = v
+	}
+
+	globalFieldsMu.Lock()
+	globalFields = copied
+	globalFieldsMu.Unlock()
+}
+
+// SetHostFields bật/tắt việc tự động thêm "hostname" và "pid" vào mọi log entry (mặc định bật)
+func SetHostFields(enabled bool) {
+	globalFieldsMu.Lock()
+	includeHostFields = enabled
+	globalFieldsMu.Unlock()
+}
+
+// withGlobalFields trả về map mới gồm global fields + fields (fields thắng khi trùng key)
+// Không sửa map của caller
+func withGlobalFields(fields map[string]interface{}) map[string]interface{} {
+	globalFieldsMu.RLock()
+	defer globalFieldsMu.RUnlock()
+
+	if len(globalFields) == 0 && !includeHostFields {
+		return fields
+	}
+
+	merged := make(map[string]interface{}, len(globalFields)+len(hostFields)+len(fields))
+	if includeHostFields {
+		for k, v := range hostFields {
+			merged[k] = v
+		}
+	}
+	for k, v := range globalFields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return merged
+}
@@ -0,0 +1,90 @@
+package goerrorkit
+
+import (
+	"os"
+	"testing"
+)
+
+// withGlobalFieldsReset khôi phục global fields và host fields khi test kết thúc
+func withGlobalFieldsReset(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		SetGlobalFields(nil)
+		SetHostFields(true)
+	})
+}
+
+func TestGlobalFieldsConsoleAndFile(t *testing.T) {
+	withGlobalFieldsReset(t)
+	logger, console, path := newServiceNameLogger(t)
+	SetLogger(logger)
+	defer SetLogger(nil)
+
+	SetGlobalFields(map[string]interface{}{"service": "order-service", "env": "production", "version": "1.4.2"})
+	LogError(NewSystemError(os.ErrDeadlineExceeded), "GET /orders")
+	Info("cache warmed", map[string]interface{}{"version": "1.5.0-canary"})
+
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostname, _ := os.Hostname()
+	for output, records := range map[string]map[string]map[string]interface{}{
+		"console": jsonRecords(t, console.String()),
+		"file":    jsonRecords(t, string(content)),
+	} {
+		logged := records["Internal server error"]
+		for key, want := range map[string]interface{}{
+			"service":  "order-service",
+			"env":      "production",
+			"version":  "1.4.2",
+			"hostname": hostname,
+			"pid":      float64(os.Getpid()),
+		} {
+			if logged[key] != want {
+				t.Errorf("%s: LogError %s = %v, want %v", output, key, logged[key], want)
+			}
+		}
+		// Field của entry thắng global field khi trùng key
+		if got := records["cache warmed"]["version"]; got != "1.5.0-canary" {
+			t.Errorf("%s: Info version = %v, want entry field to win", output, got)
+		}
+		if got := records["cache warmed"]["service"]; got != "order-service" {
+			t.Errorf("%s: Info service = %v", output, got)
+		}
+	}
+}
+
+func TestWithGlobalFields(t *testing.T) {
+	withGlobalFieldsReset(t)
+
+	SetGlobalFields(map[string]interface{}{"env": "staging", "pid": "overridden"})
+	fields := map[string]interface{}{"env": "dev", "path": "/orders"}
+	merged := withGlobalFields(fields)
+	if merged["env"] != "dev" || merged["path"] != "/orders" || merged["pid"] != "overridden" || merged["hostname"] == nil {
+		t.Errorf("merged = %v", merged)
+	}
+	if len(fields) != 2 {
+		t.Errorf("caller map mutated: %v", fields)
+	}
+
+	SetHostFields(false)
+	SetGlobalFields(nil)
+	if merged := withGlobalFields(fields); len(merged) != 2 || merged["hostname"] != nil {
+		t.Errorf("merged with host fields disabled = %v", merged)
+	}
+}
+
+func TestSetGlobalFieldsCopiesMap(t *testing.T) {
+	withGlobalFieldsReset(t)
+
+	fields := map[string]interface{}{"service": "order-service"}
+	SetGlobalFields(fields)
+	fields["service"] = "changed"
+	if got := withGlobalFields(nil)["service"]; got != "order-service" {
+		t.Errorf("service = %v, want value at SetGlobalFields time", got)
+	}
+}
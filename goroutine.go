@@ -0,0 +1,99 @@
+package goerrorkit
+
+import "context"
+
+// goroutinePanicPath là giá trị "path" khi log panic/error từ goroutine
+const goroutinePanicPath = "goroutine"
+
+// onGoroutinePanic là hook optional, được gọi sau khi panic trong goroutine đã được log
+var onGoroutinePanic func(appErr *AppError)
+
+// SetOnGoroutinePanic đăng ký hook được gọi sau khi panic trong goroutine (Go, GoCtx, Recover) được log
+// Truyền nil để bỏ hook
+//
+// Example:
+//
+//	goerrorkit.SetOnGoroutinePanic(func(appErr *goerrorkit.AppError) {
+//	    alert.Send("goroutine panic: " + appErr.Message)
+//	})
+func SetOnGoroutinePanic(hook func(appErr *AppError)) {
+	onGoroutinePanic = hook
+}
+
+// Go chạy fn trong goroutine mới với recover riêng của goroutine đó
+// Panic được route qua HandlePanic và LogError (path "goroutine") nên location được capture chính xác
+// trong goroutine gây panic (không phải goroutine của request handler).
+//
+// LƯU Ý: Panic trong goroutine KHÔNG được quản lý (go func(){...}() thường) vẫn làm crash
+// process - đây là semantics của Go, Fiber middleware không thể recover được.
+// Go (hoặc defer Recover(...)) là pattern được hỗ trợ.
+//
+// Example:
+//
+//	app.Post("/orders", func(c *fiber.Ctx) error {
+//	    goerrorkit.Go(func() {
+//	        notifyWarehouse(orderID) // panic ở đây không crash server
+//	    })
+//	    return c.SendStatus(202)
+//	})
+func Go(fn func()) {
+	go func() {
+		defer Recover("")
+		fn()
+	}()
+}
+
+// SafeGo giống hệt Go
+//
+// Deprecated: dùng Go.
+func SafeGo(fn func()) {
+	Go(fn)
+}
+
+// GoCtx chạy fn trong goroutine mới với panic recovery
+// Request ID được lấy từ ctx (xem ContextWithRequestID)
+// Error do fn trả về cũng được convert sang AppError và log
+//
+// Example:
+//
+//	goerrorkit.GoCtx(c.UserContext(), func(ctx context.Context) error {
+//	    return syncInventory(ctx, orderID)
+//	})
+func GoCtx(ctx context.Context, fn func(ctx context.Context) error) {
+	go func() {
+		requestID := RequestIDFromContext(ctx)
+		defer Recover(requestID)
+
+		if err := fn(ctx); err != nil {
+			LogError(ConvertToAppError(err, requestID), goroutinePanicPath)
+		}
+	}()
+}
+
+// Recover recover panic trong goroutine do user tự quản lý
+// PHẢI được gọi trực tiếp bằng defer: defer goerrorkit.Recover("worker-1")
+//
+// Example:
+//
+//	go func() {
+//	    defer goerrorkit.Recover("worker-1")
+//	    for job := range jobs {
+//	        process(job)
+//	    }
+//	}()
+func Recover(requestID string) {
+	if r := recover(); r != nil {
+		handleGoroutinePanic(r, requestID)
+	}
+}
+
+// handleGoroutinePanic convert panic sang AppError, log, gọi hook (nếu có)
+// và re-panic nếu policy của SetRePanic yêu cầu
+func handleGoroutinePanic(r interface{}, requestID string) {
+	appErr := HandlePanic(r, requestID)
+	LogError(appErr, goroutinePanicPath)
+	if onGoroutinePanic != nil {
+		onGoroutinePanic(appErr)
+	}
+	rePanicIfFatal(getRePanic(), r)
+}
@@ -0,0 +1,137 @@
+package goerrorkit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// capturePanicHook đăng ký OnGoroutinePanic gửi AppError vào channel trả về
+func capturePanicHook(t *testing.T) <-chan *AppError {
+	t.Helper()
+	panics := make(chan *AppError, 1)
+	SetOnGoroutinePanic(func(appErr *AppError) { panics <- appErr })
+	t.Cleanup(func() { SetOnGoroutinePanic(nil) })
+	return panics
+}
+
+func waitPanic(t *testing.T, panics <-chan *AppError) *AppError {
+	t.Helper()
+	select {
+	case appErr := <-panics:
+		return appErr
+	case <-time.After(2 * time.Second):
+		t.Fatal("goroutine panic was not handled")
+		return nil
+	}
+}
+
+func TestGoRecoversPanic(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+	panics := capturePanicHook(t)
+
+	Go(func() {
+		var m map[string]int
+		m["boom"]++ // panic trong goroutine: process phải sống sót
+	})
+
+	appErr := waitPanic(t, panics)
+	if appErr.Type != PanicError || appErr.Details["panic_kind"] != "nil_map_assignment" {
+		t.Errorf("got %s %v", appErr.Type, appErr.Details["panic_kind"])
+	}
+
+	entry, ok := mem.Find("", "Panic recovered")
+	if !ok {
+		t.Fatalf("goroutine panic not logged: %v", mem.Entries())
+	}
+	if entry.Fields["path"] != "goroutine" {
+		t.Errorf("path = %v, want goroutine", entry.Fields["path"])
+	}
+}
+
+func TestGoCtxPanicUsesRequestID(t *testing.T) {
+	UseMemoryLogger()
+	defer SetLogger(nil)
+	panics := capturePanicHook(t)
+
+	ctx := ContextWithRequestID(context.Background(), "job-42")
+	GoCtx(ctx, func(ctx context.Context) error {
+		panic("inventory sync crashed")
+	})
+
+	if appErr := waitPanic(t, panics); appErr.RequestID != "job-42" {
+		t.Errorf("RequestID = %q, want job-42", appErr.RequestID)
+	}
+}
+
+func TestGoCtxLogsReturnedError(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	ctx := ContextWithRequestID(context.Background(), "job-43")
+	GoCtx(ctx, func(ctx context.Context) error {
+		return NewBusinessError(404, "Warehouse not found")
+	})
+
+	entry := waitForEntry(t, mem, "Warehouse not found")
+	if entry.Fields["request_id"] != "job-43" || entry.Fields["path"] != "goroutine" {
+		t.Errorf("fields = %v", entry.Fields)
+	}
+}
+
+func TestRecoverInUserGoroutine(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer Recover("worker-1")
+		panic(errors.New("queue closed"))
+	}()
+	wg.Wait()
+
+	entry, ok := mem.Find("", "queue closed")
+	if !ok {
+		t.Fatalf("panic not logged: %v", mem.Entries())
+	}
+	if entry.Fields["request_id"] != "worker-1" {
+		t.Errorf("request_id = %v, want worker-1", entry.Fields["request_id"])
+	}
+}
+
+func TestRecoverWithoutPanic(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	func() {
+		defer Recover("worker-1")
+	}()
+
+	if entries := mem.Entries(); len(entries) != 0 {
+		t.Errorf("Recover without panic logged %v", entries)
+	}
+}
+
+// crashInventory panic trong goroutine do Go/SafeGo chạy
+func crashInventory() {
+	var items []string
+	_ = items[3]
+}
+
+func TestGoPanicLocationInGoroutine(t *testing.T) {
+	UseMemoryLogger()
+	defer SetLogger(nil)
+	panics := capturePanicHook(t)
+
+	for name, spawn := range map[string]func(func()){"Go": Go, "SafeGo": SafeGo} {
+		spawn(crashInventory)
+		if got := waitPanic(t, panics).Details["function"]; got != "goerrorkit.crashInventory" {
+			t.Errorf("%s: function = %v, want the panicking function in the goroutine", name, got)
+		}
+	}
+}
@@ -1,12 +1,22 @@
 package goerrorkit
 
 import (
+	"errors"
 	"fmt"
+	"runtime"
+	"strings"
+	"time"
 )
 
 // HandlePanic xử lý panic và trả về AppError với stack trace chi tiết
 // Đây là core function để capture panic location chính xác
 //
+// Panic value được giữ nguyên ngữ nghĩa:
+//   - *AppError: trả về bản copy của error đó (giữ Type, Code, Data), Details["panic"] = true và call chain được gắn thêm
+//   - error: PanicError với error gốc trong Cause (errors.Is/errors.As hoạt động)
+//   - fmt.Stringer: PanicError, panic_value là String()
+//   - Giá trị khác (string, int, ...): PanicError "Panic recovered: <value>"
+//
 // Example (internal use):
 //
 //	defer func() {
@@ -16,25 +26,119 @@ import (
 //	    }
 //	}()
 func HandlePanic(r interface{}, requestID string) *AppError {
-	actualFile, actualLine, actualFunc := getActualPanicLocation()
-	callChain := formatStackTraceArray()
+	actualFile, actualLine, actualFunc, actualPath := getActualPanicLocation()
+	frames := captureStackFrames()
 
-	return &AppError{
+	// panic(appErr): dùng để điều khiển luồng trong code cũ - giữ nguyên type/code/data
+	if appErr, ok := r.(*AppError); ok && appErr != nil {
+		panicLocation := ""
+		if actualLine > 0 {
+			panicLocation = fmt.Sprintf("%s:%d", actualFile, actualLine)
+		}
+		return markPanickedAppError(appErr, requestID, frames, panicLocation)
+	}
+
+	appErr := &AppError{
 		Type:      PanicError,
-		Code:      500,
+		Code:      PanicError.DefaultHTTPStatus(),
 		Message:   fmt.Sprintf("Panic recovered: %v", r),
 		RequestID: requestID,
+		Frames:    frames,
+		CreatedAt: time.Now(),
 		Details: map[string]interface{}{
 			"panic_value": r,
+			"panic_type":  panicTypeName(r),
 			"function":    actualFunc,
 			"file":        fmt.Sprintf("%s:%d", actualFile, actualLine),
-			"call_chain":  callChain,
+			"call_chain":  formatCallChain(frames),
 		},
 	}
+
+	switch v := r.(type) {
+	case error:
+		// panic(err): giữ error gốc trong Cause để errors.Is/errors.As hoạt động
+		appErr.Cause = v
+		appErr.Details["panic_value"] = v.Error()
+	case fmt.Stringer:
+		appErr.Details["panic_value"] = v.String()
+	}
+
+	// Source snippet quanh dòng gây panic (opt-in qua StackTraceConfig.IncludeSource)
+	if cfg := getStackTraceConfig(); cfg.IncludeSource {
+		if snippet := readSourceSnippet(actualPath, actualLine, cfg.SourceContextLines); len(snippet) > 0 {
+			appErr.Details["source"] = snippet
+		}
+	}
+
+	// Phân loại runtime error phổ biến thành panic_kind dễ đọc
+	if rtErr, ok := r.(runtime.Error); ok {
+		appErr.Details["panic_kind"] = classifyRuntimeError(rtErr)
+	}
+
+	return appErr
+}
+
+// markPanickedAppError ghi chú AppError được recover từ panic: Details["panic"] = true,
+// vị trí panic và call chain. Type, Code, Data và vị trí tạo error (file, function) giữ nguyên
+func markPanickedAppError(appErr *AppError, requestID string, frames []StackFrame, panicLocation string) *AppError {
+	appErr = appErr.shallowCopy() // panic(sentinel) không được sửa sentinel dùng chung
+	if appErr.Details == nil {
+		appErr.Details = make(map[string]interface{})
+	}
+	appErr.Details["panic"] = true
+	appErr.Details["panic_type"] = panicTypeName(appErr)
+	if panicLocation != "" {
+		appErr.Details["panic_location"] = panicLocation
+	}
+	appErr.Details["call_chain"] = formatCallChain(frames)
+	appErr.Frames = frames
+
+	if appErr.RequestID == "" {
+		appErr.RequestID = requestID
+	}
+	if appErr.CreatedAt.IsZero() {
+		appErr.CreatedAt = time.Now()
+	}
+	return appErr
+}
+
+// panicTypeName trả về tên kiểu của panic value
+// runtime error được gom thành "runtime.Error" thay vì tên kiểu private (runtime.boundsError, ...)
+func panicTypeName(r interface{}) string {
+	if _, ok := r.(runtime.Error); ok {
+		return "runtime.Error"
+	}
+	return fmt.Sprintf("%T", r)
+}
+
+// classifyRuntimeError phân loại runtime.Error thành các loại phổ biến
+func classifyRuntimeError(err runtime.Error) string {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "nil pointer dereference"):
+		return "nil_pointer_dereference"
+	case strings.Contains(msg, "index out of range"):
+		return "index_out_of_range"
+	case strings.Contains(msg, "slice bounds out of range"):
+		return "slice_bounds_out_of_range"
+	case strings.Contains(msg, "divide by zero"):
+		return "divide_by_zero"
+	case strings.Contains(msg, "assignment to entry in nil map"):
+		return "nil_map_assignment"
+	case strings.Contains(msg, "interface conversion"):
+		return "type_assertion"
+	default:
+		return "runtime_error"
+	}
 }
 
 // ConvertToAppError chuyển đổi error thường thành AppError
-// Nếu đã là AppError thì chỉ update RequestID
+// Nếu đã là AppError (kể cả khi bị wrap bởi fmt.Errorf("...: %w") hoặc errors.Join)
+// thì trả về bản copy của AppError gốc; requestID chỉ được gán khi AppError chưa có RequestID
+// (ID gắn sớm qua WithRequestID/WrapCtx, ví dụ job ID, được giữ nguyên - giống HandlePanic).
+// Message của lớp wrap bên ngoài được lưu vào Details["context"] để không mất annotation của caller.
+// AppError gốc không bị sửa (có thể là sentinel package-level dùng chung giữa nhiều request).
+// Error thường được convert qua mapping đã đăng ký (xem RegisterErrorMapping) trước khi fallback về 500.
 //
 // Example (internal use):
 //
@@ -44,18 +148,61 @@ func HandlePanic(r interface{}, requestID string) *AppError {
 //	    return appErr
 //	}
 func ConvertToAppError(err error, requestID string) *AppError {
-	// Check nếu đã là AppError
-	if appErr, ok := err.(*AppError); ok {
-		appErr.RequestID = requestID
-		return appErr
+	// Check nếu đã là AppError (hỗ trợ cả error bị wrap)
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		annotated := appErr.shallowCopy()
+		if annotated.RequestID == "" {
+			annotated.RequestID = requestID
+		}
+		addWrapContext(annotated, err)
+		return annotated
+	}
+
+	// Mapping đã đăng ký (RegisterErrorMapping) và built-in (context, sql.ErrNoRows)
+	if mapped, ok := mapRegisteredError(err, requestID); ok {
+		return mapped
 	}
 
 	// Convert error thường thành AppError
 	return &AppError{
 		Type:      SystemError,
-		Code:      500,
+		Code:      SystemError.DefaultHTTPStatus(),
 		Message:   "Internal server error",
 		Cause:     err,
 		RequestID: requestID,
+		CreatedAt: time.Now(),
+	}
+}
+
+// shallowCopy trả về bản copy của e để annotate cho một request (Details được clone)
+// Data, Frames, Headers, ... được dùng chung vì chỉ đọc khi log/response
+func (e *AppError) shallowCopy() *AppError {
+	cp := *e
+	if e.Details != nil {
+		cp.Details = make(map[string]interface{}, len(e.Details)+1)
+		for k, v := range e.Details {
+			cp.Details[k] = v
+		}
+	}
+	return &cp
+}
+
+// addWrapContext lưu message của lớp wrap bên ngoài vào Details["context"]
+// Nếu đã có context trước đó thì context mới được prepend vào đầu
+// appErr phải là bản copy (shallowCopy) vì Details bị sửa trực tiếp
+func addWrapContext(appErr *AppError, err error) {
+	msg := err.Error()
+	if msg == appErr.Error() {
+		// Không bị wrap hoặc wrap không thêm message
+		return
+	}
+
+	if appErr.Details == nil {
+		appErr.Details = make(map[string]interface{})
+	}
+	if existing, ok := appErr.Details["context"].(string); ok && existing != "" && existing != msg {
+		msg = msg + " | " + existing
 	}
+	appErr.Details["context"] = msg
 }
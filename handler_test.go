@@ -0,0 +1,180 @@
+package goerrorkit
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestConvertToAppErrorSingleWrap(t *testing.T) {
+	notFound := NewBusinessError(404, "Order not found")
+	err := fmt.Errorf("processing order: %w", notFound)
+
+	appErr := ConvertToAppError(err, "req-1")
+
+	if appErr.Type != BusinessError || appErr.Code != 404 || appErr.Message != "Order not found" {
+		t.Fatalf("got %s %d %q, want BUSINESS 404 %q", appErr.Type, appErr.Code, appErr.Message, "Order not found")
+	}
+	if got := appErr.Details["context"]; got != "processing order: Order not found" {
+		t.Errorf("context = %v", got)
+	}
+	if appErr.RequestID != "req-1" {
+		t.Errorf("RequestID = %q, want req-1", appErr.RequestID)
+	}
+}
+
+func TestConvertToAppErrorDoubleWrap(t *testing.T) {
+	notFound := NewBusinessError(404, "Order not found")
+	err := fmt.Errorf("handler: %w", fmt.Errorf("repo: %w", notFound))
+
+	appErr := ConvertToAppError(err, "req-1")
+
+	if appErr.Code != 404 {
+		t.Fatalf("Code = %d, want 404", appErr.Code)
+	}
+	if got := appErr.Details["context"]; got != "handler: repo: Order not found" {
+		t.Errorf("context = %v", got)
+	}
+
+	// Wrap thêm một lớp quanh AppError đã convert: context mới được prepend
+	again := ConvertToAppError(fmt.Errorf("retry: %w", appErr), "req-1")
+	if got := again.Details["context"]; got != "retry: Order not found | handler: repo: Order not found" {
+		t.Errorf("context after re-wrap = %v", got)
+	}
+}
+
+func TestConvertToAppErrorJoin(t *testing.T) {
+	conflict := NewBusinessError(409, "Version conflict")
+	err := errors.Join(errors.New("cache miss"), conflict)
+
+	appErr := ConvertToAppError(err, "req-1")
+
+	if appErr.Type != BusinessError || appErr.Code != 409 {
+		t.Fatalf("got %s %d, want BUSINESS 409", appErr.Type, appErr.Code)
+	}
+	if got := appErr.Details["context"]; got != "cache miss\nVersion conflict" {
+		t.Errorf("context = %q", got)
+	}
+}
+
+func TestConvertToAppErrorUnwrapped(t *testing.T) {
+	appErr := ConvertToAppError(NewAuthError(401, "Unauthorized"), "req-1")
+	if _, ok := appErr.Details["context"]; ok {
+		t.Errorf("unexpected context for unwrapped AppError: %v", appErr.Details["context"])
+	}
+}
+
+func TestConvertToAppErrorDoesNotMutateSentinel(t *testing.T) {
+	sentinel := NewBusinessError(404, "Product not found")
+	detailsBefore := len(sentinel.Details)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := fmt.Errorf("request %d: %w", i, sentinel)
+			appErr := ConvertToAppError(err, fmt.Sprintf("req-%d", i))
+			if want := fmt.Sprintf("request %d: Product not found", i); appErr.Details["context"] != want {
+				t.Errorf("context = %v, want %q", appErr.Details["context"], want)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if sentinel.RequestID != "" {
+		t.Errorf("sentinel RequestID mutated: %q", sentinel.RequestID)
+	}
+	if _, ok := sentinel.Details["context"]; ok || len(sentinel.Details) != detailsBefore {
+		t.Errorf("sentinel Details mutated: %v", sentinel.Details)
+	}
+}
+
+func TestConvertToAppErrorPlainError(t *testing.T) {
+	appErr := ConvertToAppError(errors.New("boom"), "req-1")
+	if appErr.Type != SystemError || appErr.Code != 500 || appErr.Cause == nil {
+		t.Errorf("got %s %d cause=%v, want SYSTEM 500 with cause", appErr.Type, appErr.Code, appErr.Cause)
+	}
+}
+
+// panicValue gọi panic(v) và trả về AppError từ HandlePanic
+func panicValue(v interface{}) (appErr *AppError) {
+	defer func() { appErr = HandlePanic(recover(), "req-1") }()
+	panic(v)
+}
+
+type panicState struct{ step int }
+
+func (s panicState) String() string { return fmt.Sprintf("state at step %d", s.step) }
+
+func TestHandlePanicAppErrorValue(t *testing.T) {
+	original := NewBusinessError(409, "Order already paid").WithData(map[string]interface{}{"order_id": 42})
+	appErr := panicValue(original)
+
+	if appErr.Type != BusinessError || appErr.Code != 409 || appErr.Message != "Order already paid" {
+		t.Errorf("got %s %d %q, want original type, code and message", appErr.Type, appErr.Code, appErr.Message)
+	}
+	if appErr.Data["order_id"] != 42 || appErr.RequestID != "req-1" {
+		t.Errorf("data = %v, request_id = %q", appErr.Data, appErr.RequestID)
+	}
+	if appErr.Details["panic"] != true || appErr.Details["panic_type"] != "*goerrorkit.AppError" {
+		t.Errorf("details = %v, want panic noted", appErr.Details)
+	}
+	if chain, _ := appErr.Details["call_chain"].([]string); len(chain) == 0 || len(appErr.Frames) == 0 {
+		t.Errorf("call_chain = %v, want call chain attached", appErr.Details["call_chain"])
+	}
+	if location, _ := appErr.Details["panic_location"].(string); location == "" {
+		t.Error("panic_location missing")
+	}
+	if original.Details["panic"] != nil {
+		t.Errorf("original AppError mutated: %v", original.Details)
+	}
+}
+
+func TestHandlePanicErrorValue(t *testing.T) {
+	cause := &quotaError{limit: 5}
+	appErr := panicValue(fmt.Errorf("upload: %w", cause))
+
+	if appErr.Type != PanicError || appErr.Code != 500 {
+		t.Errorf("got %s %d, want 500 PanicError", appErr.Type, appErr.Code)
+	}
+	if appErr.Message != "Panic recovered: upload: quota 5 exceeded" {
+		t.Errorf("message = %q", appErr.Message)
+	}
+	var qe *quotaError
+	if !errors.As(appErr, &qe) || qe != cause {
+		t.Error("panic error chain not preserved as Cause")
+	}
+	if appErr.Details["panic_value"] != "upload: quota 5 exceeded" {
+		t.Errorf("panic_value = %v", appErr.Details["panic_value"])
+	}
+}
+
+func TestHandlePanicPlainValues(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     interface{}
+		message   string
+		valueKept interface{}
+		panicType string
+	}{
+		{"Stringer", panicState{step: 3}, "Panic recovered: state at step 3", "state at step 3", "goerrorkit.panicState"},
+		{"string", "boom", "Panic recovered: boom", "boom", "string"},
+		{"int", 42, "Panic recovered: 42", 42, "int"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appErr := panicValue(tt.value)
+			if appErr.Type != PanicError || appErr.Code != 500 || appErr.Cause != nil {
+				t.Errorf("got %s %d cause=%v, want 500 PanicError without cause", appErr.Type, appErr.Code, appErr.Cause)
+			}
+			if appErr.Message != tt.message {
+				t.Errorf("message = %q, want %q", appErr.Message, tt.message)
+			}
+			if appErr.Details["panic_value"] != tt.valueKept || appErr.Details["panic_type"] != tt.panicType {
+				t.Errorf("panic_value = %v, panic_type = %v", appErr.Details["panic_value"], appErr.Details["panic_type"])
+			}
+		})
+	}
+}
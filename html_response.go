@@ -0,0 +1,138 @@
+package goerrorkit
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sync"
+)
+
+// defaultHTMLErrorTemplate là trang lỗi tối giản cho browser
+const defaultHTMLErrorTemplate = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Code}} {{.Status}}</title></head>
+<body>
+<h1>{{.Code}} {{.Status}}</h1>
+<p>{{.Message}}</p>
+{{if .Ref}}<p><small>Reference: {{.Ref}}</small></p>{{end}}
+</body>
+</html>
+`
+
+// HTMLErrorPage là dữ liệu truyền vào HTML error template
+type HTMLErrorPage struct {
+	Code      int    // HTTP status code
+	Status    string // Status text (ví dụ "Not Found")
+	Message   string // AppError.Message
+	Type      string // ErrorType
+	RequestID string // Request ID
+	Ref       string // Reference code (xem AppError.Ref)
+}
+
+var (
+	htmlTemplateMu    sync.RWMutex
+	htmlErrorTemplate = template.Must(template.New("error").Parse(defaultHTMLErrorTemplate))
+)
+
+// SetHTMLErrorTemplate thay template trang lỗi HTML trả về cho browser (dữ liệu: HTMLErrorPage)
+// Truyền chuỗi rỗng để dùng lại template mặc định
+//
+// Example:
+//
+//	err := goerrorkit.SetHTMLErrorTemplate(`<h1>{{.Code}}</h1><p>{{.Message}}</p><p>Mã lỗi: {{.Ref}}</p>`)
+func SetHTMLErrorTemplate(tmpl string) error {
+	if tmpl == "" {
+		tmpl = defaultHTMLErrorTemplate
+	}
+	parsed, err := template.New("error").Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("goerrorkit: invalid HTML error template: %w", err)
+	}
+	htmlTemplateMu.Lock()
+	htmlErrorTemplate = parsed
+	htmlTemplateMu.Unlock()
+	return nil
+}
+
+// SetHTMLErrorTemplateParsed giống SetHTMLErrorTemplate nhưng nhận template đã parse
+// (template file, embed.FS, template có FuncMap riêng, ...). Truyền nil để dùng lại template mặc định
+//
+// Example:
+//
+//	tmpl := template.Must(template.ParseFS(templatesFS, "templates/error.html"))
+//	goerrorkit.SetHTMLErrorTemplateParsed(tmpl)
+func SetHTMLErrorTemplateParsed(tmpl *template.Template) {
+	if tmpl == nil {
+		tmpl = template.Must(template.New("error").Parse(defaultHTMLErrorTemplate))
+	}
+	htmlTemplateMu.Lock()
+	htmlErrorTemplate = tmpl
+	htmlTemplateMu.Unlock()
+}
+
+// getHTMLErrorTemplate trả về template hiện tại
+func getHTMLErrorTemplate() *template.Template {
+	htmlTemplateMu.RLock()
+	defer htmlTemplateMu.RUnlock()
+	return htmlErrorTemplate
+}
+
+// negotiatedResponseWriter chọn writer theo Accept header của request
+// Browser (ưu tiên text/html) nhận trang HTML, client ưu tiên text/plain nhận plain text,
+// còn lại (API client) dùng ResponseWriter đang được chọn.
+// Chỉ áp dụng khi HTTPContext implement HeaderGetter và BodySender
+func negotiatedResponseWriter(ctx HTTPContext) ResponseWriter {
+	hg, ok := ctx.(HeaderGetter)
+	if !ok {
+		return getResponseWriter()
+	}
+	if _, ok := ctx.(BodySender); !ok {
+		return getResponseWriter()
+	}
+
+	accept := hg.GetHeader("Accept")
+	switch {
+	case prefersMediaType(accept, "text/html"):
+		return ResponseWriterFunc(writeHTMLResponse)
+	case prefersMediaType(accept, "text/plain"):
+		return ResponseWriterFunc(writePlainTextResponse)
+	default:
+		return getResponseWriter()
+	}
+}
+
+// newHTMLErrorPage tạo dữ liệu trang lỗi từ AppError (message dịch theo Accept-Language)
+func newHTMLErrorPage(ctx HTTPContext, appErr *AppError) HTMLErrorPage {
+	return HTMLErrorPage{
+		Code:      appErr.Code,
+		Status:    http.StatusText(appErr.Code),
+		Message:   appErr.LocalizedMessage(requestLanguage(ctx)),
+		Type:      string(appErr.Type),
+		RequestID: appErr.RequestID,
+		Ref:       appErr.Ref(),
+	}
+}
+
+// writeHTMLResponse render trang lỗi HTML (ctx phải implement BodySender)
+func writeHTMLResponse(ctx HTTPContext, appErr *AppError) error {
+	var buf bytes.Buffer
+	if err := getHTMLErrorTemplate().Execute(&buf, newHTMLErrorPage(ctx, appErr)); err != nil {
+		return getResponseWriter().WriteError(ctx, appErr)
+	}
+	writeHeaders(ctx, appErr)
+	ctx.Status(appErr.Code)
+	return ctx.(BodySender).SendBody("text/html; charset=utf-8", buf.Bytes())
+}
+
+// writePlainTextResponse gửi message dạng plain text (ctx phải implement BodySender)
+func writePlainTextResponse(ctx HTTPContext, appErr *AppError) error {
+	page := newHTMLErrorPage(ctx, appErr)
+	body := fmt.Sprintf("%d %s: %s\n", page.Code, page.Status, page.Message)
+	if page.Ref != "" {
+		body += "Reference: " + page.Ref + "\n"
+	}
+	writeHeaders(ctx, appErr)
+	ctx.Status(appErr.Code)
+	return ctx.(BodySender).SendBody("text/plain; charset=utf-8", []byte(body))
+}
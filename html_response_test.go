@@ -0,0 +1,135 @@
+package goerrorkit
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+)
+
+func newBodyTestContext(accept string) *bodyTestContext {
+	ctx := &bodyTestContext{testContext: newTestContext("GET", "/orders/42")}
+	if accept != "" {
+		ctx.headers["Accept"] = accept
+	}
+	return ctx
+}
+
+func TestLogAndRespondNegotiatesAccept(t *testing.T) {
+	UseMemoryLogger()
+	defer SetLogger(nil)
+
+	const browser = "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"
+	tests := []struct {
+		accept      string
+		contentType string // "" → JSON qua ctx.JSON
+	}{
+		{"", ""},
+		{"*/*", ""},
+		{"application/json", ""},
+		{"application/json, text/html;q=0.5", ""},
+		{"text/html;q=0.5, application/json;q=0.5", ""},
+		{browser, "text/html; charset=utf-8"},
+		{"text/html", "text/html; charset=utf-8"},
+		{"text/plain", "text/plain; charset=utf-8"},
+		{"text/plain, application/json;q=0.1", "text/plain; charset=utf-8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			ctx := newBodyTestContext(tt.accept)
+			LogAndRespond(ctx, NewBusinessError(404, "Order not found"), "GET /orders/42")
+
+			if ctx.status != 404 {
+				t.Errorf("status = %d, want 404", ctx.status)
+			}
+			if ctx.contentType != tt.contentType {
+				t.Errorf("content type = %q, want %q", ctx.contentType, tt.contentType)
+			}
+			if tt.contentType == "" && (ctx.jsonCalls != 1 || ctx.response()["error"] != "Order not found") {
+				t.Errorf("body = %s, want JSON error", ctx.body)
+			}
+		})
+	}
+}
+
+func TestHTMLResponseEscapesMessage(t *testing.T) {
+	UseMemoryLogger()
+	defer SetLogger(nil)
+
+	ctx := newBodyTestContext("text/html")
+	appErr := NewBusinessError(400, `<script>alert("x")</script>`).WithRequestID("req-7")
+	LogAndRespond(ctx, appErr, "GET /orders/42")
+
+	body := string(ctx.body)
+	if strings.Contains(body, "<script>") {
+		t.Errorf("body contains unescaped message:\n%s", body)
+	}
+	for _, want := range []string{"&lt;script&gt;", "<h1>400 Bad Request</h1>", "Reference: " + appErr.Ref()} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body missing %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestPlainTextResponse(t *testing.T) {
+	UseMemoryLogger()
+	defer SetLogger(nil)
+
+	ctx := newBodyTestContext("text/plain")
+	appErr := NewBusinessError(404, "Order not found")
+	LogAndRespond(ctx, appErr, "GET /orders/42")
+
+	want := "404 Not Found: Order not found\nReference: " + appErr.Ref() + "\n"
+	if string(ctx.body) != want {
+		t.Errorf("body = %q, want %q", ctx.body, want)
+	}
+}
+
+func TestNegotiationRequiresBodySender(t *testing.T) {
+	UseMemoryLogger()
+	defer SetLogger(nil)
+
+	// testContext không implement BodySender: luôn trả JSON
+	ctx := newTestContext("GET", "/orders/42")
+	ctx.headers["Accept"] = "text/html"
+	LogAndRespond(ctx, NewBusinessError(404, "Order not found"), "GET /orders/42")
+
+	if ctx.jsonCalls != 1 {
+		t.Errorf("jsonCalls = %d, want JSON fallback", ctx.jsonCalls)
+	}
+}
+
+func TestSetHTMLErrorTemplate(t *testing.T) {
+	UseMemoryLogger()
+	defer SetLogger(nil)
+	defer SetHTMLErrorTemplateParsed(nil)
+
+	if err := SetHTMLErrorTemplate(`{{.Code}`); err == nil {
+		t.Error("invalid template accepted")
+	}
+
+	if err := SetHTMLErrorTemplate(`<p>{{.Code}} {{.Message}} ({{.RequestID}})</p>`); err != nil {
+		t.Fatal(err)
+	}
+	ctx := newBodyTestContext("text/html")
+	LogAndRespond(ctx, NewBusinessError(404, "A & B").WithRequestID("req-7"), "GET /orders/42")
+	if got := string(ctx.body); got != "<p>404 A &amp; B (req-7)</p>" {
+		t.Errorf("body = %q", got)
+	}
+
+	SetHTMLErrorTemplateParsed(template.Must(template.New("page").Funcs(template.FuncMap{
+		"upper": strings.ToUpper,
+	}).Parse(`<p>{{upper .Message}}</p>`)))
+	ctx = newBodyTestContext("text/html")
+	LogAndRespond(ctx, NewBusinessError(404, "not found"), "GET /orders/42")
+	if got := string(ctx.body); got != "<p>NOT FOUND</p>" {
+		t.Errorf("parsed template body = %q", got)
+	}
+
+	// nil → template mặc định
+	SetHTMLErrorTemplateParsed(nil)
+	ctx = newBodyTestContext("text/html")
+	LogAndRespond(ctx, NewBusinessError(404, "not found"), "GET /orders/42")
+	if !strings.Contains(string(ctx.body), "<!DOCTYPE html>") {
+		t.Errorf("body = %s, want default template", ctx.body)
+	}
+}
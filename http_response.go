@@ -0,0 +1,167 @@
+package goerrorkit
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// upstreamBodySnippetSize là số byte tối đa của response body được lưu vào Data
+const upstreamBodySnippetSize = 512
+
+// upstreamResponseHeaders là các response header của upstream được lưu vào Data
+var upstreamResponseHeaders = []string{"Content-Type", "Retry-After", "X-Request-Id", "WWW-Authenticate"}
+
+// UpstreamCodeMapper map status code của upstream sang HTTP code trả về client
+type UpstreamCodeMapper func(upstreamStatus int) int
+
+var (
+	upstreamCodeMu     sync.RWMutex
+	upstreamCodeMapper UpstreamCodeMapper = DefaultUpstreamCodeMapper
+)
+
+// DefaultUpstreamCodeMapper: 408/5xx (timeout, upstream lỗi) → 504, còn lại (4xx, ...) → 502
+func DefaultUpstreamCodeMapper(upstreamStatus int) int {
+	if upstreamStatus == http.StatusRequestTimeout || upstreamStatus >= 500 {
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusBadGateway
+}
+
+// SetUpstreamCodeMapper thay đổi cách NewExternalErrorFromResponse map status code của upstream
+// Truyền nil để dùng lại DefaultUpstreamCodeMapper
+//
+// Example:
+//
+//	goerrorkit.SetUpstreamCodeMapper(func(status int) int {
+//	    if status == 404 {
+//	        return 404 // forward not found của upstream
+//	    }
+//	    return goerrorkit.DefaultUpstreamCodeMapper(status)
+//	})
+func SetUpstreamCodeMapper(mapper UpstreamCodeMapper) {
+	if mapper == nil {
+		mapper = DefaultUpstreamCodeMapper
+	}
+	upstreamCodeMu.Lock()
+	upstreamCodeMapper = mapper
+	upstreamCodeMu.Unlock()
+}
+
+// getUpstreamCodeMapper trả về mapper hiện tại
+func getUpstreamCodeMapper() UpstreamCodeMapper {
+	upstreamCodeMu.RLock()
+	defer upstreamCodeMu.RUnlock()
+	return upstreamCodeMapper
+}
+
+// NewExternalErrorFromResponse tạo ExternalError từ *http.Response của downstream call
+// Data chứa: method, url (query nhạy cảm bị che), upstream_status, upstream_headers (một số header chọn lọc)
+// và upstream_body (tối đa 512 byte đầu). Body được đọc rồi khôi phục lại nên caller vẫn đọc được.
+// Code được map từ upstream status: 4xx → 502, 5xx/408 → 504 (xem SetUpstreamCodeMapper)
+//
+// Example:
+//
+//	resp, err := http.Get(inventoryURL)
+//	if err != nil {
+//	    return goerrorkit.NewExternalError(504, "Inventory service unreachable", err)
+//	}
+//	defer resp.Body.Close()
+//	if resp.StatusCode >= 400 {
+//	    return goerrorkit.NewExternalErrorFromResponse(resp, "Inventory service failed")
+//	}
+func NewExternalErrorFromResponse(resp *http.Response, msg string) *AppError {
+	file, line, function := getCallerInfo(1)
+	appErr := &AppError{
+		Type:      ExternalError,
+		Code:      http.StatusBadGateway,
+		Message:   msg,
+		CreatedAt: time.Now(),
+		Details: map[string]interface{}{
+			"function": function,
+			"file":     fmt.Sprintf("%s:%d", file, line),
+		},
+	}
+	if resp == nil {
+		return appErr
+	}
+
+	appErr.Code = getUpstreamCodeMapper()(resp.StatusCode)
+	appErr.Cause = fmt.Errorf("upstream responded %s", resp.Status)
+
+	data := map[string]interface{}{
+		"upstream_status": resp.StatusCode,
+	}
+	if req := resp.Request; req != nil {
+		data["method"] = req.Method
+		if req.URL != nil {
+			data["url"] = sanitizeURLQuery(req.URL)
+		}
+	}
+
+	headers := map[string]interface{}{}
+	for _, name := range upstreamResponseHeaders {
+		if v := resp.Header.Get(name); v != "" {
+			headers[name] = v
+		}
+	}
+	if len(headers) > 0 {
+		data["upstream_headers"] = headers
+	}
+
+	if snippet := peekResponseBody(resp, upstreamBodySnippetSize); len(snippet) > 0 {
+		data["upstream_body"] = string(snippet)
+	}
+
+	return appErr.WithData(data)
+}
+
+// peekResponseBody đọc tối đa n byte đầu của resp.Body rồi khôi phục body
+// (phần đã đọc được ghép lại trước phần còn lại, Close vẫn đóng body gốc)
+func peekResponseBody(resp *http.Response, n int64) []byte {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return nil
+	}
+	snippet, err := io.ReadAll(io.LimitReader(resp.Body, n))
+	resp.Body = &restoredBody{
+		Reader: io.MultiReader(bytes.NewReader(snippet), resp.Body),
+		Closer: resp.Body,
+	}
+	if err != nil {
+		return nil
+	}
+	return snippet
+}
+
+// sanitizeURLQuery trả về URL với giá trị query nhạy cảm (theo SetRedactor) và password trong userinfo bị che
+func sanitizeURLQuery(u *url.URL) string {
+	clean := *u
+	if clean.User != nil {
+		if _, hasPassword := clean.User.Password(); hasPassword {
+			clean.User = url.UserPassword(clean.User.Username(), redactedValue)
+		}
+	}
+	if clean.RawQuery != "" {
+		r := getRedactor()
+		query := clean.Query()
+		for key, values := range query {
+			if r.isSensitiveKey(key) {
+				for i := range values {
+					values[i] = r.mask
+				}
+			}
+		}
+		clean.RawQuery = query.Encode()
+	}
+	return clean.String()
+}
+
+// restoredBody ghép phần body đã đọc với phần còn lại, giữ Close của body gốc
+type restoredBody struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,108 @@
+package goerrorkit
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newUpstream tạo httptest server trả về status, content type và body cố định
+func newUpstream(t *testing.T, status int, contentType, body string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("X-Request-Id", "up-1")
+		w.Header().Set("Set-Cookie", "session=abc")
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestNewExternalErrorFromResponseJSON(t *testing.T) {
+	const body = `{"error":"sku not found","sku":"A1"}`
+	srv := newUpstream(t, http.StatusNotFound, "application/json", body)
+
+	resp, err := http.Get(srv.URL + "/items/A1?token=s3cr3t&page=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	appErr := NewExternalErrorFromResponse(resp, "Inventory service failed")
+	if appErr.Type != ExternalError || appErr.Code != http.StatusBadGateway {
+		t.Errorf("type = %s, code = %d, want EXTERNAL 502", appErr.Type, appErr.Code)
+	}
+	if appErr.Data["method"] != "GET" || appErr.Data["upstream_status"] != 404 || appErr.Data["upstream_body"] != body {
+		t.Errorf("Data = %v", appErr.Data)
+	}
+	url, _ := appErr.Data["url"].(string)
+	if strings.Contains(url, "s3cr3t") || !strings.Contains(url, "page=2") || !strings.Contains(url, "/items/A1") {
+		t.Errorf("url = %q, want token masked and rest kept", url)
+	}
+	headers, _ := appErr.Data["upstream_headers"].(map[string]interface{})
+	if headers["Content-Type"] != "application/json" || headers["X-Request-Id"] != "up-1" || headers["Set-Cookie"] != nil {
+		t.Errorf("upstream_headers = %v, want only selected headers", headers)
+	}
+
+	// Body vẫn đọc được sau khi tạo error
+	if got, _ := io.ReadAll(resp.Body); string(got) != body {
+		t.Errorf("body after = %q, want restored", got)
+	}
+}
+
+func TestNewExternalErrorFromResponseHTML(t *testing.T) {
+	body := "<html><body><h1>502 Bad Gateway</h1>" + strings.Repeat("<p>nginx</p>", 100) + "</body></html>"
+	srv := newUpstream(t, http.StatusServiceUnavailable, "text/html", body)
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	appErr := NewExternalErrorFromResponse(resp, "Payment gateway failed")
+	if appErr.Code != http.StatusGatewayTimeout {
+		t.Errorf("code = %d, want 504 for upstream 5xx", appErr.Code)
+	}
+	snippet, _ := appErr.Data["upstream_body"].(string)
+	if len(snippet) != upstreamBodySnippetSize || !strings.HasPrefix(body, snippet) {
+		t.Errorf("upstream_body has %d bytes, want first %d", len(snippet), upstreamBodySnippetSize)
+	}
+	if got, _ := io.ReadAll(resp.Body); string(got) != body {
+		t.Errorf("body after = %d bytes, want full %d restored", len(got), len(body))
+	}
+}
+
+func TestSetUpstreamCodeMapper(t *testing.T) {
+	defer SetUpstreamCodeMapper(nil)
+	SetUpstreamCodeMapper(func(status int) int {
+		if status == http.StatusNotFound {
+			return http.StatusNotFound
+		}
+		return DefaultUpstreamCodeMapper(status)
+	})
+
+	for status, want := range map[int]int{404: 404, 400: 502, 408: 504, 500: 504} {
+		resp := &http.Response{StatusCode: status, Status: http.StatusText(status), Header: http.Header{}, Body: http.NoBody}
+		if got := NewExternalErrorFromResponse(resp, "Upstream failed").Code; got != want {
+			t.Errorf("upstream %d → %d, want %d", status, got, want)
+		}
+	}
+
+	SetUpstreamCodeMapper(nil)
+	resp := &http.Response{StatusCode: 404, Header: http.Header{}, Body: http.NoBody}
+	if got := NewExternalErrorFromResponse(resp, "Upstream failed").Code; got != http.StatusBadGateway {
+		t.Errorf("after reset: code = %d, want 502", got)
+	}
+}
+
+func TestNewExternalErrorFromResponseNil(t *testing.T) {
+	appErr := NewExternalErrorFromResponse(nil, "Upstream unreachable")
+	if appErr.Type != ExternalError || appErr.Code != http.StatusBadGateway || appErr.Data != nil {
+		t.Errorf("appErr = %+v", appErr)
+	}
+}
@@ -0,0 +1,99 @@
+package goerrorkit
+
+import (
+	"strings"
+	"sync"
+)
+
+// Translator dịch message key sang ngôn ngữ lang (ví dụ "vi", "en-US")
+// Trả về (message, true) nếu có bản dịch, (_, false) để fallback về AppError.Message
+type Translator func(lang, key string, args ...interface{}) (string, bool)
+
+var (
+	translatorMu sync.RWMutex
+	translator   Translator
+)
+
+// SetTranslator thiết lập translator dùng để dịch message key trong response (nil để tắt)
+// Ngôn ngữ được lấy từ header Accept-Language của request; nếu không có bản dịch cho
+// tag đầy đủ ("vi-VN") thì thử ngôn ngữ gốc ("vi") trước khi fallback về Message
+//
+// Example:
+//
+//	goerrorkit.SetTranslator(func(lang, key string, args ...interface{}) (string, bool) {
+//	    msg, ok := messages[lang][key]
+//	    if !ok {
+//	        return "", false
+//	    }
+//	    return fmt.Sprintf(msg, args...), true
+//	})
+func SetTranslator(t Translator) {
+	translatorMu.Lock()
+	translator = t
+	translatorMu.Unlock()
+}
+
+// getTranslator trả về translator hiện tại (nil nếu chưa set)
+func getTranslator() Translator {
+	translatorMu.RLock()
+	defer translatorMu.RUnlock()
+	return translator
+}
+
+// WithMessageKey gắn translation key và args cho message trả về client
+// Message gốc vẫn được dùng làm fallback và luôn được log; log có thêm message_key/message_args
+// để grep được bất kể ngôn ngữ. Nếu Message đang rỗng thì dùng key làm Message
+//
+// Example:
+//
+//	return goerrorkit.NewBusinessError(404, "Product not found").
+//	    WithMessageKey("product.not_found", productID)
+func (e *AppError) WithMessageKey(key string, args ...interface{}) *AppError {
+	e.MessageKey = key
+	e.MessageArgs = args
+	if e.Message == "" {
+		e.Message = key
+	}
+	return e
+}
+
+// LocalizedMessage trả về message đã dịch sang lang (fallback về Message)
+func (e *AppError) LocalizedMessage(lang string) string {
+	if e.MessageKey == "" || lang == "" {
+		return e.Message
+	}
+	t := getTranslator()
+	if t == nil {
+		return e.Message
+	}
+	if msg, ok := t(lang, e.MessageKey, e.MessageArgs...); ok {
+		return msg
+	}
+	if base, _, found := strings.Cut(lang, "-"); found {
+		if msg, ok := t(base, e.MessageKey, e.MessageArgs...); ok {
+			return msg
+		}
+	}
+	return e.Message
+}
+
+// requestLanguage lấy ngôn ngữ ưu tiên nhất từ Accept-Language (rỗng nếu không xác định được)
+// "vi-VN,vi;q=0.9,en;q=0.8" → "vi-VN"
+func requestLanguage(ctx HTTPContext) string {
+	hg, ok := ctx.(HeaderGetter)
+	if !ok {
+		return ""
+	}
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(hg.GetHeader("Accept-Language"), ",") {
+		params := strings.Split(part, ";")
+		tag := strings.TrimSpace(params[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+		if q := parseQuality(params[1:]); q > bestQ {
+			best, bestQ = tag, q
+		}
+	}
+	return best
+}
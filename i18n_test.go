@@ -0,0 +1,112 @@
+package goerrorkit
+
+import (
+	"fmt"
+	"testing"
+)
+
+// bareContext chỉ có các method bắt buộc của HTTPContext (không có interface optional nào)
+type bareContext struct{ HTTPContext }
+
+// withTranslator set translator đọc từ messages[lang][key] cho một test
+func withTranslator(t *testing.T, messages map[string]map[string]string) {
+	t.Helper()
+	SetTranslator(func(lang, key string, args ...interface{}) (string, bool) {
+		msg, ok := messages[lang][key]
+		if !ok {
+			return "", false
+		}
+		return fmt.Sprintf(msg, args...), true
+	})
+	t.Cleanup(func() { SetTranslator(nil) })
+}
+
+func TestRequestLanguage(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", ""},
+		{"vi", "vi"},
+		{"vi-VN,vi;q=0.9,en;q=0.8", "vi-VN"},
+		{"en;q=0.5, vi;q=0.9", "vi"},
+		{" en-US ; q=0.7 , de", "de"},
+		{"*, en;q=0.1", "en"},
+		{"fr;q=0", ""},
+		{"en;q=0.8, vi;q=0.8", "en"},
+		{"en;q=abc", "en"},
+	}
+	for _, tt := range tests {
+		ctx := newTestContext("GET", "/orders/42")
+		ctx.headers["Accept-Language"] = tt.accept
+		if got := requestLanguage(ctx); got != tt.want {
+			t.Errorf("requestLanguage(%q) = %q, want %q", tt.accept, got, tt.want)
+		}
+	}
+
+	// HTTPContext không implement HeaderGetter: không xác định được ngôn ngữ
+	if got := requestLanguage(bareContext{newTestContext("GET", "/")}); got != "" {
+		t.Errorf("requestLanguage without HeaderGetter = %q, want empty", got)
+	}
+}
+
+func TestLocalizedMessage(t *testing.T) {
+	withTranslator(t, map[string]map[string]string{
+		"vi":    {"order.not_found": "Không tìm thấy đơn hàng %v"},
+		"en-US": {"order.not_found": "Order %v not found"},
+	})
+	appErr := NewBusinessError(404, "Order not found").WithMessageKey("order.not_found", 42)
+
+	tests := []struct {
+		lang string
+		want string
+	}{
+		{"vi", "Không tìm thấy đơn hàng 42"},
+		{"vi-VN", "Không tìm thấy đơn hàng 42"}, // fallback về ngôn ngữ gốc
+		{"en-US", "Order 42 not found"},
+		{"en-GB", "Order not found"}, // không có "en" → Message
+		{"fr", "Order not found"},
+		{"", "Order not found"},
+	}
+	for _, tt := range tests {
+		if got := appErr.LocalizedMessage(tt.lang); got != tt.want {
+			t.Errorf("LocalizedMessage(%q) = %q, want %q", tt.lang, got, tt.want)
+		}
+	}
+
+	// Key không có trong bảng dịch → Message
+	missing := NewBusinessError(404, "Product not found").WithMessageKey("product.not_found")
+	if got := missing.LocalizedMessage("vi"); got != "Product not found" {
+		t.Errorf("missing key = %q, want Message fallback", got)
+	}
+	// Không có MessageKey → Message
+	if got := NewBusinessError(404, "Plain").LocalizedMessage("vi"); got != "Plain" {
+		t.Errorf("no key = %q, want Message", got)
+	}
+}
+
+func TestLocalizedMessageWithoutTranslator(t *testing.T) {
+	SetTranslator(nil)
+	appErr := NewBusinessError(404, "").WithMessageKey("order.not_found")
+	if got := appErr.LocalizedMessage("vi"); got != "order.not_found" {
+		t.Errorf("LocalizedMessage = %q, want key used as Message", got)
+	}
+}
+
+func TestLogAndRespondTranslatesFromAcceptLanguage(t *testing.T) {
+	withTranslator(t, map[string]map[string]string{
+		"vi": {"order.not_found": "Không tìm thấy đơn hàng %v"},
+	})
+
+	ctx := newTestContext("GET", "/orders/42")
+	ctx.headers["Accept-Language"] = "vi-VN,vi;q=0.9,en;q=0.8"
+	appErr := NewBusinessError(404, "Order not found").WithMessageKey("order.not_found", 42)
+	LogAndRespond(ctx, appErr, "GET /orders/42")
+
+	if got := ctx.response()["error"]; got != "Không tìm thấy đơn hàng 42" {
+		t.Errorf("error = %v, want Vietnamese message", got)
+	}
+	if appErr.Message != "Order not found" {
+		t.Errorf("Message = %q, want untranslated message kept for logs", appErr.Message)
+	}
+}
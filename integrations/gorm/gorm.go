@@ -0,0 +1,80 @@
+package gorm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/techmaster-vietnam/goerrorkit"
+	goerrorkitpgx "github.com/techmaster-vietnam/goerrorkit/integrations/pgx"
+	gormio "gorm.io/gorm"
+)
+
+// MapError chuyển lỗi của GORM thành AppError theo mapping chuẩn:
+//   - gorm.ErrRecordNotFound → 404 BusinessError "<entity> not found"
+//   - gorm.ErrDuplicatedKey → 409 BusinessError
+//   - gorm.ErrForeignKeyViolated → 422 BusinessError
+//   - Lỗi PostgreSQL (driver pgx) → mapping của integrations/pgx (unique, foreign key, serialization failure)
+//
+// entity (ví dụ "User") được đưa vào message và Data["entity"], error gốc được giữ trong Cause
+// Error khác được đóng gói bằng goerrorkit.Wrap (500 SystemError). Trả về nil nếu err == nil
+//
+// ErrDuplicatedKey/ErrForeignKeyViolated chỉ được GORM trả về khi bật gorm.Config{TranslateError: true}
+//
+// Example:
+//
+//	var user User
+//	if err := db.First(&user, id).Error; err != nil {
+//	    return goerrorkitgorm.MapError(err, "User")
+//	}
+func MapError(err error, entity string) *goerrorkit.AppError {
+	if err == nil {
+		return nil
+	}
+	if appErr, ok := mapError(err, entity); ok {
+		return appErr.WithCaller(1)
+	}
+	return goerrorkit.Wrap(err).WithCaller(1)
+}
+
+// Mapper là goerrorkit.ErrorMapper dùng cùng mapping với MapError (entity = "Record")
+// Trả về (nil, false) với error không thuộc các trường hợp trên để ConvertToAppError xử lý tiếp
+//
+// Example:
+//
+//	goerrorkit.RegisterErrorMapper(goerrorkitgorm.Mapper)
+func Mapper(err error) (*goerrorkit.AppError, bool) {
+	return mapError(err, "")
+}
+
+// Register đăng ký Mapper vào registry của ConvertToAppError
+// Handler có thể return thẳng lỗi GORM, middleware sẽ tự convert
+func Register() {
+	goerrorkit.RegisterErrorMapper(Mapper)
+}
+
+// mapError thực hiện mapping, (nil, false) nếu err không được map
+func mapError(err error, entity string) (*goerrorkit.AppError, bool) {
+	if entity == "" {
+		entity = "Record"
+	}
+
+	var appErr *goerrorkit.AppError
+	switch {
+	case errors.Is(err, gormio.ErrRecordNotFound):
+		appErr = goerrorkit.NewBusinessError(404, fmt.Sprintf("%s not found", entity))
+	case errors.Is(err, gormio.ErrDuplicatedKey):
+		appErr = goerrorkit.NewBusinessError(409, fmt.Sprintf("%s already exists", entity))
+	case errors.Is(err, gormio.ErrForeignKeyViolated):
+		appErr = goerrorkit.NewBusinessError(422, fmt.Sprintf("%s references a resource that does not exist", entity))
+	default:
+		// GORM + PostgreSQL (không bật TranslateError) trả về *pgconn.PgError
+		mapped, ok := goerrorkitpgx.Mapper(err)
+		if !ok {
+			return nil, false
+		}
+		appErr = mapped
+	}
+
+	appErr.Cause = err
+	return appErr.WithField("entity", entity), true
+}
@@ -0,0 +1,86 @@
+package gorm
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/techmaster-vietnam/goerrorkit"
+	goerrorkitpgx "github.com/techmaster-vietnam/goerrorkit/integrations/pgx"
+	gormio "gorm.io/gorm"
+)
+
+func TestMapErrorSentinels(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		code    int
+		message string
+	}{
+		{"record not found", gormio.ErrRecordNotFound, 404, "User not found"},
+		{"duplicated key", gormio.ErrDuplicatedKey, 409, "User already exists"},
+		{"foreign key violated", gormio.ErrForeignKeyViolated, 422, "User references a resource that does not exist"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fmt.Errorf("repo: %w", tt.err)
+			appErr := MapError(err, "User")
+
+			if appErr.Type != goerrorkit.BusinessError || appErr.Code != tt.code || appErr.Message != tt.message {
+				t.Errorf("got %s %d %q, want BusinessError %d %q", appErr.Type, appErr.Code, appErr.Message, tt.code, tt.message)
+			}
+			if appErr.Data["entity"] != "User" {
+				t.Errorf("data = %v, want entity User", appErr.Data)
+			}
+			if !errors.Is(appErr, tt.err) {
+				t.Error("mapped error does not unwrap to the GORM error")
+			}
+		})
+	}
+}
+
+func TestMapErrorPgError(t *testing.T) {
+	pgErr := &pgconn.PgError{Code: goerrorkitpgx.CodeUniqueViolation, ConstraintName: "users_email_key", TableName: "users"}
+	appErr := MapError(pgErr, "User")
+
+	if appErr.Code != 409 {
+		t.Errorf("code = %d, want 409", appErr.Code)
+	}
+	for key, want := range map[string]interface{}{"entity": "User", "constraint": "users_email_key", "table": "users", "sqlstate": "23505"} {
+		if appErr.Data[key] != want {
+			t.Errorf("data[%s] = %v, want %v", key, appErr.Data[key], want)
+		}
+	}
+
+	retry := MapError(&pgconn.PgError{Code: goerrorkitpgx.CodeSerializationFailure}, "Order")
+	if retry.Code != 503 || !retry.Retryable {
+		t.Errorf("serialization failure = %d retryable=%v, want retryable 503", retry.Code, retry.Retryable)
+	}
+}
+
+func TestMapErrorUnknown(t *testing.T) {
+	if MapError(nil, "User") != nil {
+		t.Error("MapError(nil) != nil")
+	}
+
+	cause := errors.New("invalid transaction")
+	appErr := MapError(cause, "User")
+	if appErr.Type != goerrorkit.SystemError || appErr.Code != 500 || !errors.Is(appErr, cause) {
+		t.Errorf("got %s %d, want wrapped SystemError", appErr.Type, appErr.Code)
+	}
+	if _, ok := Mapper(cause); ok {
+		t.Error("Mapper matched unknown error")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register()
+	defer goerrorkit.ResetErrorMappings()
+
+	// Mapper dùng entity mặc định "Record"
+	appErr := goerrorkit.ConvertToAppError(gormio.ErrRecordNotFound, "req-1")
+	if appErr.Code != 404 || appErr.Message != "Record not found" || appErr.RequestID != "req-1" {
+		t.Errorf("ConvertToAppError = %d %q (request %q)", appErr.Code, appErr.Message, appErr.RequestID)
+	}
+}
@@ -0,0 +1,102 @@
+package pgx
+
+import (
+	"errors"
+
+	pgxv5 "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/techmaster-vietnam/goerrorkit"
+)
+
+// SQLSTATE codes được map (https://www.postgresql.org/docs/current/errcodes-appendix.html)
+const (
+	CodeUniqueViolation      = "23505"
+	CodeForeignKeyViolation  = "23503"
+	CodeSerializationFailure = "40001"
+	CodeDeadlockDetected     = "40P01"
+)
+
+// MapError chuyển lỗi của pgx thành AppError theo mapping chuẩn:
+//   - pgx.ErrNoRows → 404 BusinessError
+//   - 23505 unique_violation → 409 BusinessError
+//   - 23503 foreign_key_violation → 422 BusinessError
+//   - 40001 serialization_failure, 40P01 deadlock_detected → 503 SystemError, Retryable
+//
+// Data chứa sqlstate, constraint, table (nếu có), error gốc được giữ trong Cause
+// Error khác được đóng gói bằng goerrorkit.Wrap (500 SystemError). Trả về nil nếu err == nil
+//
+// Example:
+//
+//	if err := pool.QueryRow(ctx, query, id).Scan(&user.Name); err != nil {
+//	    return goerrorkitpgx.MapError(err)
+//	}
+func MapError(err error) *goerrorkit.AppError {
+	if err == nil {
+		return nil
+	}
+	if appErr, ok := mapError(err); ok {
+		return appErr.WithCaller(1)
+	}
+	return goerrorkit.Wrap(err).WithCaller(1)
+}
+
+// Mapper là goerrorkit.ErrorMapper dùng cùng mapping với MapError
+// Trả về (nil, false) với error không thuộc các trường hợp trên để ConvertToAppError xử lý tiếp
+//
+// Example:
+//
+//	goerrorkit.RegisterErrorMapper(goerrorkitpgx.Mapper)
+func Mapper(err error) (*goerrorkit.AppError, bool) {
+	return mapError(err)
+}
+
+// Register đăng ký Mapper vào registry của ConvertToAppError
+// Handler có thể return thẳng lỗi pgx, middleware sẽ tự convert
+func Register() {
+	goerrorkit.RegisterErrorMapper(Mapper)
+}
+
+// mapError thực hiện mapping, (nil, false) nếu err không được map
+func mapError(err error) (*goerrorkit.AppError, bool) {
+	if errors.Is(err, pgxv5.ErrNoRows) {
+		appErr := goerrorkit.NewBusinessError(404, "Resource not found")
+		appErr.Cause = err
+		return appErr, true
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return nil, false
+	}
+
+	var appErr *goerrorkit.AppError
+	switch pgErr.Code {
+	case CodeUniqueViolation:
+		appErr = goerrorkit.NewBusinessError(409, "Resource already exists")
+	case CodeForeignKeyViolation:
+		appErr = goerrorkit.NewBusinessError(422, "Referenced resource does not exist")
+	case CodeSerializationFailure, CodeDeadlockDetected:
+		appErr = goerrorkit.WrapWithMessage(err, "Database conflict, please retry").WithStatus(503)
+		appErr.Retryable = true
+	default:
+		return nil, false
+	}
+
+	appErr.Cause = err
+	appErr.WithData(pgErrorData(pgErr))
+	return appErr, true
+}
+
+// pgErrorData trích các thông tin hữu ích (không nhạy cảm) của PgError để log
+func pgErrorData(pgErr *pgconn.PgError) map[string]interface{} {
+	data := map[string]interface{}{
+		"sqlstate": pgErr.Code,
+	}
+	if pgErr.ConstraintName != "" {
+		data["constraint"] = pgErr.ConstraintName
+	}
+	if pgErr.TableName != "" {
+		data["table"] = pgErr.TableName
+	}
+	return data
+}
@@ -0,0 +1,95 @@
+package pgx
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	pgxv5 "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/techmaster-vietnam/goerrorkit"
+)
+
+func TestMapErrorSQLState(t *testing.T) {
+	tests := []struct {
+		name      string
+		pgErr     *pgconn.PgError
+		errType   goerrorkit.ErrorType
+		code      int
+		retryable bool
+	}{
+		{"unique violation", &pgconn.PgError{Code: CodeUniqueViolation, ConstraintName: "users_email_key", TableName: "users"},
+			goerrorkit.BusinessError, 409, false},
+		{"foreign key violation", &pgconn.PgError{Code: CodeForeignKeyViolation, ConstraintName: "orders_user_id_fkey", TableName: "orders"},
+			goerrorkit.BusinessError, 422, false},
+		{"serialization failure", &pgconn.PgError{Code: CodeSerializationFailure},
+			goerrorkit.SystemError, 503, true},
+		{"deadlock detected", &pgconn.PgError{Code: CodeDeadlockDetected, TableName: "inventory"},
+			goerrorkit.SystemError, 503, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fmt.Errorf("insert: %w", tt.pgErr)
+			appErr := MapError(err)
+
+			if appErr.Type != tt.errType || appErr.Code != tt.code || appErr.Retryable != tt.retryable {
+				t.Errorf("got %s %d retryable=%v, want %s %d retryable=%v",
+					appErr.Type, appErr.Code, appErr.Retryable, tt.errType, tt.code, tt.retryable)
+			}
+			if appErr.Data["sqlstate"] != tt.pgErr.Code {
+				t.Errorf("sqlstate = %v, want %s", appErr.Data["sqlstate"], tt.pgErr.Code)
+			}
+			if constraint, ok := appErr.Data["constraint"]; ok != (tt.pgErr.ConstraintName != "") || (ok && constraint != tt.pgErr.ConstraintName) {
+				t.Errorf("constraint = %v, want %q", constraint, tt.pgErr.ConstraintName)
+			}
+			if table, ok := appErr.Data["table"]; ok != (tt.pgErr.TableName != "") || (ok && table != tt.pgErr.TableName) {
+				t.Errorf("table = %v, want %q", table, tt.pgErr.TableName)
+			}
+
+			var pgErr *pgconn.PgError
+			if !errors.As(appErr, &pgErr) || pgErr != tt.pgErr {
+				t.Error("mapped error does not unwrap to the PgError")
+			}
+		})
+	}
+}
+
+func TestMapErrorNoRows(t *testing.T) {
+	appErr := MapError(fmt.Errorf("find user: %w", pgxv5.ErrNoRows))
+	if appErr.Type != goerrorkit.BusinessError || appErr.Code != 404 {
+		t.Errorf("got %s %d, want 404 BusinessError", appErr.Type, appErr.Code)
+	}
+	if !errors.Is(appErr, pgxv5.ErrNoRows) {
+		t.Error("mapped error does not unwrap to ErrNoRows")
+	}
+}
+
+func TestMapErrorUnknown(t *testing.T) {
+	if MapError(nil) != nil {
+		t.Error("MapError(nil) != nil")
+	}
+
+	// SQLSTATE không được map (check_violation) và error thường → Wrap (500)
+	for _, err := range []error{
+		&pgconn.PgError{Code: "23514"},
+		errors.New("connection refused"),
+	} {
+		appErr := MapError(err)
+		if appErr.Type != goerrorkit.SystemError || appErr.Code != 500 || !errors.Is(appErr, err) {
+			t.Errorf("MapError(%v) = %s %d, want wrapped SystemError", err, appErr.Type, appErr.Code)
+		}
+		if _, ok := Mapper(err); ok {
+			t.Errorf("Mapper(%v) matched, want fall through", err)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register()
+	defer goerrorkit.ResetErrorMappings()
+
+	appErr := goerrorkit.ConvertToAppError(&pgconn.PgError{Code: CodeUniqueViolation}, "req-1")
+	if appErr.Code != 409 || appErr.RequestID != "req-1" {
+		t.Errorf("ConvertToAppError = %d (request %q), want 409 via registered mapper", appErr.Code, appErr.RequestID)
+	}
+}
@@ -0,0 +1,123 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	validatorv10 "github.com/go-playground/validator/v10"
+	"github.com/techmaster-vietnam/goerrorkit"
+)
+
+// FromValidationErrors chuyển lỗi của validate.Struct thành một ValidationError duy nhất
+// Mỗi field lỗi được thêm bằng AddFieldError với meta: tag, param, value
+// (value bị che theo goerrorkit.SetRedactor nếu tên field nhạy cảm, ví dụ "password")
+// Error không phải validator.ValidationErrors được đóng gói bằng goerrorkit.Wrap
+// Trả về nil nếu err == nil
+//
+// Tên field lấy từ FieldError.Field(), nên tôn trọng RegisterTagNameFunc của validator
+// (xem RegisterJSONTagNames để dùng tên trong json tag)
+//
+// Example:
+//
+//	validate := validator.New()
+//	goerrorkitvalidator.RegisterJSONTagNames(validate)
+//
+//	app.Post("/users", func(c *fiber.Ctx) error {
+//	    var req CreateUserRequest
+//	    if err := c.BodyParser(&req); err != nil {
+//	        return goerrorkit.NewValidationError("Invalid body", nil)
+//	    }
+//	    if err := validate.Struct(req); err != nil {
+//	        return goerrorkitvalidator.FromValidationErrors(err)
+//	    }
+//	    // ...
+//	})
+func FromValidationErrors(err error) *goerrorkit.AppError {
+	if err == nil {
+		return nil
+	}
+
+	var verrs validatorv10.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return goerrorkit.Wrap(err).WithCaller(1)
+	}
+
+	appErr := goerrorkit.NewValidationErrors(summaryMessage(len(verrs))).WithCaller(1)
+	appErr.Cause = err
+	for _, fe := range verrs {
+		appErr.AddFieldError(fe.Field(), fieldMessage(fe), map[string]interface{}{
+			"tag":   fe.Tag(),
+			"param": fe.Param(),
+			"value": goerrorkit.RedactValue(fe.Field(), fe.Value()),
+		})
+	}
+	return appErr
+}
+
+// RegisterJSONTagNames cấu hình validator dùng tên trong json tag làm tên field
+// (field có json:"-" giữ tên struct field)
+//
+// Example:
+//
+//	validate := validator.New()
+//	goerrorkitvalidator.RegisterJSONTagNames(validate)
+func RegisterJSONTagNames(v *validatorv10.Validate) {
+	v.RegisterTagNameFunc(jsonTagName)
+}
+
+// jsonTagName trả về tên field theo json tag, rỗng nếu không có (validator dùng tên struct field)
+func jsonTagName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// summaryMessage tạo message tổng hợp theo số field lỗi
+func summaryMessage(count int) string {
+	if count == 1 {
+		return "Validation failed: 1 invalid field"
+	}
+	return fmt.Sprintf("Validation failed: %d invalid fields", count)
+}
+
+// fieldMessage tạo message cho một field theo validation tag phổ biến
+func fieldMessage(fe validatorv10.FieldError) string {
+	field := fe.Field()
+	switch fe.Tag() {
+	case "required":
+		return fmt.Sprintf("%s is required", field)
+	case "email":
+		return fmt.Sprintf("%s must be a valid email address", field)
+	case "min":
+		if unit := lengthUnit(fe.Kind()); unit != "" {
+			return fmt.Sprintf("%s must contain at least %s %s", field, fe.Param(), unit)
+		}
+		return fmt.Sprintf("%s must be at least %s", field, fe.Param())
+	case "max":
+		if unit := lengthUnit(fe.Kind()); unit != "" {
+			return fmt.Sprintf("%s must contain at most %s %s", field, fe.Param(), unit)
+		}
+		return fmt.Sprintf("%s must be at most %s", field, fe.Param())
+	case "oneof":
+		return fmt.Sprintf("%s must be one of [%s]", field, fe.Param())
+	}
+	if fe.Param() != "" {
+		return fmt.Sprintf("%s failed on '%s=%s' validation", field, fe.Tag(), fe.Param())
+	}
+	return fmt.Sprintf("%s failed on '%s' validation", field, fe.Tag())
+}
+
+// lengthUnit trả về đơn vị khi min/max áp dụng cho độ dài (string, slice, map) thay vì giá trị
+func lengthUnit(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "items"
+	}
+	return ""
+}
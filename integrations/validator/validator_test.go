@@ -0,0 +1,138 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	validatorv10 "github.com/go-playground/validator/v10"
+	"github.com/techmaster-vietnam/goerrorkit"
+)
+
+type signupRequest struct {
+	Email    string   `json:"email" validate:"required,email"`
+	Name     string   `json:"name" validate:"min=3"`
+	Age      int      `json:"age" validate:"min=18,max=120"`
+	Tags     []string `json:"tags" validate:"max=2"`
+	Password string   `json:"password" validate:"min=8"`
+	Internal string   `json:"-" validate:"required"`
+}
+
+func validSignup() signupRequest {
+	return signupRequest{
+		Email:    "an@example.com",
+		Name:     "Nguyen An",
+		Age:      30,
+		Tags:     []string{"a"},
+		Password: "correct-horse",
+		Internal: "x",
+	}
+}
+
+func newTestValidate() *validatorv10.Validate {
+	v := validatorv10.New()
+	RegisterJSONTagNames(v)
+	return v
+}
+
+func TestFromValidationErrorsTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(r *signupRequest)
+		field   string
+		tag     string
+		param   string
+		value   interface{}
+		message string
+	}{
+		{"required", func(r *signupRequest) { r.Email = "" }, "email", "required", "", "", "email is required"},
+		{"email", func(r *signupRequest) { r.Email = "not-an-email" }, "email", "email", "", "not-an-email", "email must be a valid email address"},
+		{"min string", func(r *signupRequest) { r.Name = "An" }, "name", "min", "3", "An", "name must contain at least 3 characters"},
+		{"min number", func(r *signupRequest) { r.Age = 16 }, "age", "min", "18", 16, "age must be at least 18"},
+		{"max number", func(r *signupRequest) { r.Age = 200 }, "age", "max", "120", 200, "age must be at most 120"},
+		{"max slice", func(r *signupRequest) { r.Tags = []string{"a", "b", "c"} }, "tags", "max", "2", nil, "tags must contain at most 2 items"},
+		{"json dash keeps struct name", func(r *signupRequest) { r.Internal = "" }, "Internal", "required", "", "", "Internal is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validSignup()
+			tt.mutate(&req)
+
+			appErr := FromValidationErrors(newTestValidate().Struct(req))
+			if appErr == nil {
+				t.Fatal("FromValidationErrors = nil")
+			}
+			if appErr.Type != goerrorkit.ValidationError || appErr.Code != 400 {
+				t.Errorf("type = %s, code = %d", appErr.Type, appErr.Code)
+			}
+			if appErr.Message != "Validation failed: 1 invalid field" {
+				t.Errorf("message = %q", appErr.Message)
+			}
+			if len(appErr.FieldErrors) != 1 {
+				t.Fatalf("field errors = %+v, want 1", appErr.FieldErrors)
+			}
+
+			fe := appErr.FieldErrors[0]
+			if fe.Field != tt.field || fe.Message != tt.message {
+				t.Errorf("field = %q message = %q, want %q %q", fe.Field, fe.Message, tt.field, tt.message)
+			}
+			if fe.Meta["tag"] != tt.tag || fe.Meta["param"] != tt.param {
+				t.Errorf("meta = %v, want tag %q param %q", fe.Meta, tt.tag, tt.param)
+			}
+			if tt.value != nil && fe.Meta["value"] != tt.value {
+				t.Errorf("value = %#v, want %#v", fe.Meta["value"], tt.value)
+			}
+		})
+	}
+}
+
+func TestFromValidationErrorsSummaryAndRedaction(t *testing.T) {
+	req := validSignup()
+	req.Email = ""
+	req.Age = 16
+	req.Password = "short"
+
+	appErr := FromValidationErrors(newTestValidate().Struct(req))
+
+	if appErr.Message != "Validation failed: 3 invalid fields" {
+		t.Errorf("message = %q", appErr.Message)
+	}
+	var verrs validatorv10.ValidationErrors
+	if !errors.As(appErr, &verrs) || len(verrs) != 3 {
+		t.Error("validator errors not kept as Cause")
+	}
+
+	var password goerrorkit.FieldError
+	for _, fe := range appErr.FieldErrors {
+		if fe.Field == "password" {
+			password = fe
+		}
+	}
+	if password.Field == "" {
+		t.Fatalf("password field missing: %+v", appErr.FieldErrors)
+	}
+	if password.Meta["value"] != "[REDACTED]" {
+		t.Errorf("password value = %v, want redacted", password.Meta["value"])
+	}
+
+	// Vị trí lỗi trỏ về caller, không phải package validator
+	if file, _ := appErr.Details["file"].(string); !strings.HasPrefix(file, "validator_test.go:") {
+		t.Errorf("file = %q, want caller location", file)
+	}
+}
+
+func TestFromValidationErrorsNonValidatorError(t *testing.T) {
+	if FromValidationErrors(nil) != nil {
+		t.Error("FromValidationErrors(nil) != nil")
+	}
+
+	cause := errors.New("decode body: unexpected EOF")
+	appErr := FromValidationErrors(fmt.Errorf("bind: %w", cause))
+	if appErr == nil || appErr.Type != goerrorkit.SystemError || len(appErr.FieldErrors) != 0 {
+		t.Fatalf("got %+v, want wrapped system error", appErr)
+	}
+	if !errors.Is(appErr, cause) {
+		t.Error("wrapped error does not unwrap to cause")
+	}
+}
@@ -0,0 +1,87 @@
+package goerrorkit
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// validLogLevels là các log level chuẩn của goerrorkit
+var validLogLevels = []string{"trace", "debug", "info", "warn", "error", "panic"}
+
+// logLevelAliases map các cách viết phổ biến sang log level chuẩn
+var logLevelAliases = map[string]string{
+	"warning":  "warn",
+	"err":      "error",
+	"fatal":    "panic",
+	"critical": "panic",
+}
+
+// ParseLevel chuẩn hóa log level string (không phân biệt hoa thường, hỗ trợ alias như "warning")
+// Trả về error mô tả rõ các giá trị hợp lệ nếu level không hợp lệ
+// Dùng được để validate config file trước khi gọi InitLogger
+//
+// Example:
+//
+//	level, err := goerrorkit.ParseLevel("WARNING") // "warn", nil
+//	_, err = goerrorkit.ParseLevel("verbose")      // error: invalid log level "verbose" ...
+func ParseLevel(level string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(level))
+	if alias, ok := logLevelAliases[normalized]; ok {
+		normalized = alias
+	}
+	for _, valid := range validLogLevels {
+		if normalized == valid {
+			return normalized, nil
+		}
+	}
+	return "", fmt.Errorf("invalid log level %q (valid: %s; aliases: warning, err, fatal, critical)",
+		level, strings.Join(validLogLevels, ", "))
+}
+
+// typeLogLevels override log level mặc định theo ErrorType (set bởi SetDefaultLogLevels)
+var (
+	typeLogLevelsMu sync.RWMutex
+	typeLogLevels   map[ErrorType]string
+)
+
+// SetDefaultLogLevels override log level mặc định theo ErrorType
+// Được GetLogLevel dùng trước mapping built-in; .Level() trên từng error vẫn được ưu tiên hơn.
+// ErrorType không có trong map dùng mapping built-in. Truyền nil để reset về mặc định.
+// Level được chuẩn hóa qua ParseLevel; nếu có level không hợp lệ thì không thay đổi gì và trả về error
+//
+// Example:
+//
+//	// BusinessError 4xx là noise → info; ExternalError thường retry thành công → warn
+//	err := goerrorkit.SetDefaultLogLevels(map[goerrorkit.ErrorType]string{
+//	    goerrorkit.BusinessError: "info",
+//	    goerrorkit.ExternalError: "warn",
+//	})
+//
+//	goerrorkit.SetDefaultLogLevels(nil) // reset
+func SetDefaultLogLevels(levels map[ErrorType]string) error {
+	var normalized map[ErrorType]string
+	if len(levels) > 0 {
+		normalized = make(map[ErrorType]string, len(levels))
+		for errType, level := range levels {
+			parsed, err := ParseLevel(level)
+			if err != nil {
+				return fmt.Errorf("log level for %s: %w", errType, err)
+			}
+			normalized[errType] = parsed
+		}
+	}
+
+	typeLogLevelsMu.Lock()
+	typeLogLevels = normalized
+	typeLogLevelsMu.Unlock()
+	return nil
+}
+
+// defaultLogLevelFor trả về level override cho ErrorType (nếu có)
+func defaultLogLevelFor(errType ErrorType) (string, bool) {
+	typeLogLevelsMu.RLock()
+	defer typeLogLevelsMu.RUnlock()
+	level, ok := typeLogLevels[errType]
+	return level, ok
+}
@@ -0,0 +1,91 @@
+package goerrorkit
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	cases := map[string]string{
+		"warn":      "warn",
+		" WARNING ": "warn",
+		"Err":       "error",
+		"fatal":     "panic",
+		"critical":  "panic",
+		"trace":     "trace",
+	}
+	for in, want := range cases {
+		if got, err := ParseLevel(in); err != nil || got != want {
+			t.Errorf("ParseLevel(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("ParseLevel(verbose) = nil error")
+	}
+}
+
+func TestSetDefaultLogLevels(t *testing.T) {
+	defer SetDefaultLogLevels(nil)
+
+	if err := SetDefaultLogLevels(map[ErrorType]string{BusinessError: "INFO", ExternalError: "warning"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		err  *AppError
+		want string
+	}{
+		{"configured type", NewBusinessError(404, "Order not found"), "info"},
+		{"alias normalized", NewExternalError(502, "Gateway down", errors.New("timeout")), "warn"},
+		{"unconfigured type keeps built-in", NewValidationError("Invalid email", nil), "warn"},
+		{"unknown type falls back to error", &AppError{Type: ErrorType("CUSTOM")}, "error"},
+		{".Level() wins", NewBusinessError(409, "Conflict").Level("error"), "error"},
+	}
+	for _, tt := range tests {
+		if got := tt.err.GetLogLevel(); got != tt.want {
+			t.Errorf("%s: GetLogLevel() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	// Reset về mapping built-in
+	if err := SetDefaultLogLevels(nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := NewBusinessError(404, "Order not found").GetLogLevel(); got != "error" {
+		t.Errorf("after reset: BusinessError level = %q, want error", got)
+	}
+}
+
+func TestSetDefaultLogLevelsInvalid(t *testing.T) {
+	defer SetDefaultLogLevels(nil)
+	if err := SetDefaultLogLevels(map[ErrorType]string{BusinessError: "info"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Level không hợp lệ: trả về error và giữ nguyên cấu hình cũ
+	err := SetDefaultLogLevels(map[ErrorType]string{ExternalError: "warn", SystemError: "loud"})
+	if err == nil {
+		t.Fatal("SetDefaultLogLevels with invalid level = nil error")
+	}
+	if got := NewBusinessError(404, "Order not found").GetLogLevel(); got != "info" {
+		t.Errorf("BusinessError level = %q after failed update, want info kept", got)
+	}
+	if got := NewExternalError(502, "Gateway down", nil).GetLogLevel(); got != "error" {
+		t.Errorf("ExternalError level = %q after failed update, want built-in error", got)
+	}
+}
+
+func TestSetDefaultLogLevelsApplied(t *testing.T) {
+	defer SetDefaultLogLevels(nil)
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	if err := SetDefaultLogLevels(map[ErrorType]string{BusinessError: "info"}); err != nil {
+		t.Fatal(err)
+	}
+	LogError(NewBusinessError(404, "Order not found"), "GET /orders/1")
+	if _, ok := mem.Find("info", "Order not found"); !ok {
+		t.Errorf("BusinessError not logged at info: %v", mem.Entries())
+	}
+}
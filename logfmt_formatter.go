@@ -0,0 +1,129 @@
+package goerrorkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// logfmtFormatter là logrus.Formatter ghi log dạng logfmt (LoggerOptions.Format = "logfmt")
+//   - Map lồng nhau được flatten bằng key có dấu chấm: data.product_id=123
+//   - call_chain được nối bằng " <- "
+//   - Value có khoảng trắng, dấu "=", dấu nháy hoặc ký tự điều khiển (xuống dòng, ...) được quote
+type logfmtFormatter struct {
+	timestampFormat string
+	names           map[string]string // logrus.FieldKeyTime/Level/Msg → tên field (từ FieldMap)
+}
+
+// newLogfmtFormatter tạo logfmt formatter (timestampFormat rỗng → RFC3339)
+func newLogfmtFormatter(timestampFormat string, fieldMap logrus.FieldMap) *logfmtFormatter {
+	if timestampFormat == "" {
+		timestampFormat = time.RFC3339
+	}
+	names := make(map[string]string, len(fieldMap))
+	for k, v := range fieldMap {
+		names[string(k)] = v
+	}
+	return &logfmtFormatter{timestampFormat: timestampFormat, names: names}
+}
+
+// Format implements logrus.Formatter
+func (f *logfmtFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	var b bytes.Buffer
+	writeLogfmtPair(&b, f.fieldName(logrus.FieldKeyTime, "timestamp"), entry.Time.Format(f.timestampFormat))
+	writeLogfmtPair(&b, f.fieldName(logrus.FieldKeyLevel, "level"), entry.Level.String())
+	writeLogfmtPair(&b, f.fieldName(logrus.FieldKeyMsg, "message"), entry.Message)
+
+	flat := make(map[string]string, len(entry.Data))
+	for k, v := range entry.Data {
+		flattenLogfmt(k, v, flat)
+	}
+	keys := make([]string, 0, len(flat))
+	for k := range flat {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		writeLogfmtPair(&b, k, flat[k])
+	}
+
+	b.WriteByte('\n')
+	return b.Bytes(), nil
+}
+
+// fieldName trả về tên field sau khi áp dụng LoggerOptions.FieldMap
+func (f *logfmtFormatter) fieldName(key, fallback string) string {
+	if name, ok := f.names[key]; ok && name != "" {
+		return name
+	}
+	return fallback
+}
+
+// flattenLogfmt flatten value vào out với key có dấu chấm cho map lồng nhau
+func flattenLogfmt(key string, v interface{}, out map[string]string) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, nested := range val {
+			flattenLogfmt(key+"."+k, nested, out)
+		}
+	case map[string]string:
+		for k, nested := range val {
+			out[key+"."+k] = nested
+		}
+	case []string:
+		if key == "call_chain" {
+			out[key] = strings.Join(val, " <- ")
+		} else {
+			out[key] = strings.Join(val, ", ")
+		}
+	case string:
+		out[key] = val
+	case error:
+		out[key] = val.Error()
+	case fmt.Stringer:
+		out[key] = val.String()
+	case nil:
+		out[key] = ""
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		out[key] = fmt.Sprint(val)
+	default:
+		if encoded, err := json.Marshal(val); err == nil {
+			out[key] = string(encoded)
+		} else {
+			out[key] = fmt.Sprint(val)
+		}
+	}
+}
+
+// writeLogfmtPair ghi "key=value" (cách nhau bởi một khoảng trắng), quote value khi cần
+func writeLogfmtPair(b *bytes.Buffer, key, value string) {
+	if b.Len() > 0 {
+		b.WriteByte(' ')
+	}
+	b.WriteString(key)
+	b.WriteByte('=')
+	if needsLogfmtQuote(value) {
+		b.WriteString(strconv.Quote(value))
+	} else {
+		b.WriteString(value)
+	}
+}
+
+// needsLogfmtQuote kiểm tra value rỗng hoặc chứa ký tự cần quote
+func needsLogfmtQuote(value string) bool {
+	if value == "" {
+		return true
+	}
+	for _, r := range value {
+		if r <= ' ' || r == '=' || r == '"' || r == 0x7f || r == '�' {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package goerrorkit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// formatLogfmt format một entry với thời gian cố định
+func formatLogfmt(t *testing.T, fieldMap logrus.FieldMap, msg string, fields logrus.Fields) string {
+	t.Helper()
+	entry := &logrus.Entry{
+		Time:    time.Date(2025, 11, 28, 9, 30, 0, 0, time.UTC),
+		Level:   logrus.ErrorLevel,
+		Message: msg,
+		Data:    fields,
+	}
+	out, err := newLogfmtFormatter("", fieldMap).Format(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestLogfmtQuoting(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"plain", "db-down", `cause=db-down`},
+		{"space", "db down", `cause="db down"`},
+		{"newline", "line1\nline2", `cause="line1\nline2"`},
+		{"tab", "a\tb", `cause="a\tb"`},
+		{"equals", "a=b", `cause="a=b"`},
+		{"quote", `say "hi"`, `cause="say \"hi\""`},
+		{"empty", "", `cause=""`},
+		{"unicode", "không_tìm_thấy", `cause=không_tìm_thấy`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := formatLogfmt(t, nil, "x", logrus.Fields{"cause": tt.value})
+			if !strings.Contains(out, " "+tt.want+"\n") {
+				t.Errorf("output = %q, want %s", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogfmtMessageWithNewlineStaysOnOneLine(t *testing.T) {
+	out := formatLogfmt(t, nil, "Internal server error\nat handler", nil)
+
+	want := `timestamp=2025-11-28T09:30:00Z level=error message="Internal server error\nat handler"` + "\n"
+	if out != want {
+		t.Errorf("output = %q, want %q", out, want)
+	}
+}
+
+func TestLogfmtFlattensFields(t *testing.T) {
+	out := formatLogfmt(t, nil, "Panic recovered: boom", logrus.Fields{
+		"error_type": "PANIC",
+		"pid":        4242,
+		"cause":      errors.New("db down"),
+		"data": map[string]interface{}{
+			"product_id": 123,
+			"customer":   map[string]interface{}{"tier": "gold"},
+			"tags":       []int{1, 2},
+		},
+		"headers":    map[string]string{"X-Tenant": "acme"},
+		"call_chain": []string{"main.handler (main.go:42)", "main.service (service.go:10)"},
+	})
+
+	want := `timestamp=2025-11-28T09:30:00Z level=error message="Panic recovered: boom"` +
+		` call_chain="main.handler (main.go:42) <- main.service (service.go:10)"` +
+		` cause="db down" data.customer.tier=gold data.product_id=123 data.tags=[1,2]` +
+		` error_type=PANIC headers.X-Tenant=acme pid=4242` + "\n"
+	if out != want {
+		t.Errorf("output =\n%s\nwant\n%s", out, want)
+	}
+}
+
+func TestLogfmtFieldMap(t *testing.T) {
+	fieldMap, err := logrusFieldMap(map[string]string{"timestamp": "ts", "message": "msg"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := formatLogfmt(t, fieldMap, "Order not found", nil)
+	if want := "ts=2025-11-28T09:30:00Z level=error msg=\"Order not found\"\n"; out != want {
+		t.Errorf("output = %q, want %q", out, want)
+	}
+}
+
+func TestResolveLogFormat(t *testing.T) {
+	tests := []struct {
+		opts    LoggerOptions
+		want    string
+		wantErr bool
+	}{
+		{LoggerOptions{}, "text", false},
+		{LoggerOptions{JSONFormat: true}, "json", false},
+		{LoggerOptions{Format: "logfmt", JSONFormat: true}, "logfmt", false},
+		{LoggerOptions{Format: " ECS "}, "ecs", false},
+		{LoggerOptions{Format: "text", JSONFormat: true}, "text", false},
+		{LoggerOptions{Format: "yaml", JSONFormat: true}, "json", true},
+	}
+	for _, tt := range tests {
+		got, err := resolveLogFormat(tt.opts)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("resolveLogFormat(Format=%q, JSONFormat=%v) = %q, %v; want %q (error %v)",
+				tt.opts.Format, tt.opts.JSONFormat, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestLogfmtFileOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.log")
+	logger, console := newTestLogrusLogger(t, LoggerOptions{
+		ConsoleOutput: true,
+		FileOutput:    true,
+		FilePath:      path,
+		Format:        "logfmt",
+		LogLevel:      "error",
+		FileLogLevel:  "error",
+	})
+
+	logger.Error("Order not found", map[string]interface{}{"data": map[string]interface{}{"product_id": 123}})
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, out := range map[string]string{"file": string(content), "console": console.String()} {
+		if strings.Count(out, "\n") != 1 || !strings.Contains(out, `message="Order not found" data.product_id=123`) {
+			t.Errorf("%s output = %q, want one logfmt record", name, out)
+		}
+	}
+}
@@ -1,5 +1,15 @@
 package goerrorkit
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
 // Logger interface cho phép user tùy chỉnh logging implementation
 // Default implementation sẽ dùng logrus, nhưng user có thể dùng zap, zerolog, etc.
 type Logger interface {
@@ -26,9 +36,29 @@ type Logger interface {
 	Panic(msg string, fields map[string]interface{})
 }
 
+// MonitorLogger là interface optional cho Logger hỗ trợ AppError.MonitorOnly()
+// Monitor phải ghi entry vào file/persistent sink bất kể level filter của sink đó
+type MonitorLogger interface {
+	Monitor(level string, msg string, fields map[string]interface{})
+}
+
 // defaultLogger là logger mặc định (sẽ được set từ config package)
 var defaultLogger Logger
 
+// includeComponent bật/tắt việc thêm field "component" (package phát sinh lỗi) vào log
+var includeComponent = false
+
+// SetIncludeComponent bật/tắt field "component" trong log của LogError
+// Log pipeline/metrics collector có thể dùng field này làm label với cardinality thấp
+//
+// Example:
+//
+//	goerrorkit.SetIncludeComponent(true)
+//	// log: {"component": "orders", "function": "orders.(*Service).Checkout", ...}
+func SetIncludeComponent(enabled bool) {
+	includeComponent = enabled
+}
+
 // SetLogger cho phép user set custom logger implementation
 //
 // Example:
@@ -39,11 +69,140 @@ func SetLogger(l Logger) {
 	defaultLogger = l
 }
 
+// IsDebugBuild cho biết binary có được build với -tags=debug không
+// Production build (không có tag): Debug/Trace của LogrusLogger và goerrorkit.Debug/Trace là no-op
+//
+// Example:
+//
+//	if !goerrorkit.IsDebugBuild() {
+//	    log.Println("debug/trace logs disabled (production build)")
+//	}
+func IsDebugBuild() bool {
+	return debugBuild
+}
+
 // GetLogger trả về logger hiện tại
 func GetLogger() Logger {
 	return defaultLogger
 }
 
+// levelAdjuster là interface optional cho Logger hỗ trợ đổi level tại runtime
+type levelAdjuster interface {
+	SetConsoleLevel(level string) error
+	SetFileLevel(level string) error
+	Levels() (console, file string)
+}
+
+// errLevelAdjustUnsupported được trả về khi logger hiện tại không hỗ trợ đổi level tại runtime
+var errLevelAdjustUnsupported = errors.New("goerrorkit: current logger does not support runtime log level changes")
+
+// SetConsoleLogLevel đổi log level của console tại runtime, không cần InitLogger lại
+// An toàn khi gọi đồng thời với logging (ví dụ từ admin endpoint)
+//
+// Example:
+//
+//	admin.Post("/log-level", func(c *fiber.Ctx) error {
+//	    return goerrorkit.SetConsoleLogLevel(c.Query("level"))
+//	})
+func SetConsoleLogLevel(level string) error {
+	if la, ok := defaultLogger.(levelAdjuster); ok {
+		return la.SetConsoleLevel(level)
+	}
+	return errLevelAdjustUnsupported
+}
+
+// SetFileLogLevel đổi log level của file tại runtime, không cần InitLogger lại
+// Với LoggerOptions.FileOutputs nhiều sink, trả về error - dùng SetFileSinkLogLevel
+func SetFileLogLevel(level string) error {
+	if la, ok := defaultLogger.(levelAdjuster); ok {
+		return la.SetFileLevel(level)
+	}
+	return errLevelAdjustUnsupported
+}
+
+// sinkLevelAdjuster là interface optional cho Logger có nhiều file sink (LoggerOptions.FileOutputs)
+type sinkLevelAdjuster interface {
+	SetFileSinkLevel(path, level string) error
+	FileSinkLevels() map[string]string
+}
+
+// SetFileSinkLogLevel đổi MinLevel của một file sink (LoggerOptions.FileOutputs) tại runtime,
+// các sink khác giữ nguyên (SetFileLogLevel trả về error khi có nhiều sink)
+//
+// Example:
+//
+//	// Tạm ghi cả info vào warnings.log, errors.log vẫn chỉ nhận error
+//	goerrorkit.SetFileSinkLogLevel("logs/warnings.log", "info")
+func SetFileSinkLogLevel(path, level string) error {
+	if la, ok := defaultLogger.(sinkLevelAdjuster); ok {
+		return la.SetFileSinkLevel(path, level)
+	}
+	return errLevelAdjustUnsupported
+}
+
+// GetFileSinkLogLevels trả về MinLevel hiện tại của từng file sink theo Path
+// (nil nếu logger không hỗ trợ)
+func GetFileSinkLogLevels() map[string]string {
+	if la, ok := defaultLogger.(sinkLevelAdjuster); ok {
+		return la.FileSinkLevels()
+	}
+	return nil
+}
+
+// GetLogLevels trả về log level hiện tại của console và file
+// Trả về chuỗi rỗng cho output không bật hoặc logger không hỗ trợ
+func GetLogLevels() (console, file string) {
+	if la, ok := defaultLogger.(levelAdjuster); ok {
+		return la.Levels()
+	}
+	return "", ""
+}
+
+// FlushLogs emit summary đang chờ của sampling/dedup rồi chờ các log entry đang buffer
+// (AsyncFile) được ghi xong
+// Logger không hỗ trợ flush thì trả về nil ngay
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	defer cancel()
+//	goerrorkit.FlushLogs(ctx)
+func FlushLogs(ctx context.Context) error {
+	flushSummaries()
+	if f, ok := defaultLogger.(interface{ Flush(context.Context) error }); ok {
+		return f.Flush(ctx)
+	}
+	return nil
+}
+
+// FlushLogger giống FlushLogs nhưng chờ tới khi buffer được ghi hết (không timeout)
+// Dùng trước os.Exit sau lỗi fatal để không mất record cuối cùng
+//
+// Example:
+//
+//	goerrorkit.LogError(appErr, "startup")
+//	goerrorkit.FlushLogger()
+//	os.Exit(1)
+func FlushLogger() error {
+	return FlushLogs(context.Background())
+}
+
+// CloseLogger emit summary đang chờ của sampling/dedup, drain buffer và đóng các output
+// (file log) của logger hiện tại
+// Nên gọi khi shutdown application; logger cần InitLogger lại nếu muốn ghi file tiếp
+//
+// Example:
+//
+//	goerrorkit.InitLogger(goerrorkit.DefaultLoggerOptions())
+//	defer goerrorkit.CloseLogger()
+func CloseLogger() error {
+	flushSummaries()
+	if c, ok := defaultLogger.(interface{ Close() error }); ok {
+		return c.Close()
+	}
+	return nil
+}
+
 // ============================================================================
 // Convenience Functions - Wrapper methods để gọi trực tiếp
 // ============================================================================
@@ -52,7 +211,7 @@ func GetLogger() Logger {
 // Shorthand cho GetLogger().Error(msg, fields)
 func Error(msg string, fields map[string]interface{}) {
 	if defaultLogger != nil {
-		defaultLogger.Error(msg, fields)
+		defaultLogger.Error(msg, withGlobalFields(fields))
 	}
 }
 
@@ -60,25 +219,25 @@ func Error(msg string, fields map[string]interface{}) {
 // Shorthand cho GetLogger().Info(msg, fields)
 func Info(msg string, fields map[string]interface{}) {
 	if defaultLogger != nil {
-		defaultLogger.Info(msg, fields)
+		defaultLogger.Info(msg, withGlobalFields(fields))
 	}
 }
 
 // Debug logs debug level message
 // Shorthand cho GetLogger().Debug(msg, fields)
-// Lưu ý: Chỉ hoạt động khi build với tag -tags=debug
+// Lưu ý: Chỉ hoạt động khi build với tag -tags=debug; production build là no-op với mọi Logger
 func Debug(msg string, fields map[string]interface{}) {
-	if defaultLogger != nil {
-		defaultLogger.Debug(msg, fields)
+	if debugBuild && defaultLogger != nil {
+		defaultLogger.Debug(msg, withGlobalFields(fields))
 	}
 }
 
 // Trace logs trace level message
 // Shorthand cho GetLogger().Trace(msg, fields)
-// Lưu ý: Chỉ hoạt động khi build với tag -tags=debug
+// Lưu ý: Chỉ hoạt động khi build với tag -tags=debug; production build là no-op với mọi Logger
 func Trace(msg string, fields map[string]interface{}) {
-	if defaultLogger != nil {
-		defaultLogger.Trace(msg, fields)
+	if debugBuild && defaultLogger != nil {
+		defaultLogger.Trace(msg, withGlobalFields(fields))
 	}
 }
 
@@ -86,7 +245,7 @@ func Trace(msg string, fields map[string]interface{}) {
 // Shorthand cho GetLogger().Warn(msg, fields)
 func Warn(msg string, fields map[string]interface{}) {
 	if defaultLogger != nil {
-		defaultLogger.Warn(msg, fields)
+		defaultLogger.Warn(msg, withGlobalFields(fields))
 	}
 }
 
@@ -94,75 +253,431 @@ func Warn(msg string, fields map[string]interface{}) {
 // Shorthand cho GetLogger().Panic(msg, fields)
 func Panic(msg string, fields map[string]interface{}) {
 	if defaultLogger != nil {
-		defaultLogger.Panic(msg, fields)
+		defaultLogger.Panic(msg, withGlobalFields(fields))
 	}
 }
 
 // LogError xử lý logging cho AppError
 // Sử dụng appropriate log level dựa trên error.GetLogLevel()
 func LogError(appErr *AppError, requestPath string) {
+	logErrorWithFields(appErr, requestPath, nil)
+}
+
+// logErrorWithFields giống LogError nhưng thêm extra fields (ví dụ thông tin response)
+func logErrorWithFields(appErr *AppError, requestPath string, extra map[string]interface{}) {
 	if defaultLogger == nil {
 		// Nếu chưa set logger, skip logging
 		return
 	}
 
+	// Logger (hoặc RedactFunc, hook, ...) của bên thứ ba panic không được làm crash request
+	defer recoverLoggerPanic(appErr)
+
+	// Error được đánh dấu SkipLogging (ví dụ client hủy request)
+	if appErr.skipLog {
+		return
+	}
+
+	// Sampling/rate limiting (nếu được bật qua LoggerOptions.Sampling hoặc SetSampling)
+	if s := getSampler(); s != nil {
+		allowed, summaries := s.allow(appErr, appErr.GetLogLevel())
+		emitSamplingSummaries(summaries)
+		if !allowed {
+			return
+		}
+	}
+
+	// Dedup error giống nhau trong window (nếu được bật qua LoggerOptions.DedupWindow hoặc SetDedup)
+	// Entry lặp lại vẫn được ghi ra console nếu bật KeepConsole
+	consoleOnly := false
+	if d := getDeduper(); d != nil {
+		allowed, summaries := d.allow(appErr, appErr.GetLogLevel())
+		emitDedupSummaries(summaries)
+		if !allowed {
+			if _, ok := defaultLogger.(ConsoleLogger); !d.opts.KeepConsole || !ok {
+				return
+			}
+			consoleOnly = true
+		}
+	}
+
 	// Chuẩn bị log fields với metadata cơ bản
 	fields := map[string]interface{}{
 		"error_type": string(appErr.Type),
+		"severity":   string(appErr.GetSeverity()),
 		"path":       requestPath,
 	}
 
+	// Thêm request ID để correlate log với response trả về client
+	if appErr.RequestID != "" {
+		fields["request_id"] = appErr.RequestID
+	}
+
+	// Application error code (machine-readable)
+	if appErr.ErrCode != "" {
+		fields["error_code"] = appErr.ErrCode
+	}
+
+	// Reference code ngắn để support đối chiếu với response client nhận được
+	fields["ref"] = appErr.Ref()
+
+	// SLA tier để ops ưu tiên xử lý (gold/silver/bronze)
+	if appErr.Tier != "" {
+		fields["sla_tier"] = appErr.Tier
+	}
+
+	// Thời điểm tạo error - hữu ích khi phân tích latency/thứ tự với async logging
+	if !appErr.CreatedAt.IsZero() {
+		fields["created_at"] = appErr.CreatedAt.Format(time.RFC3339Nano)
+	}
+
+	// Redact dữ liệu nhạy cảm (copy-on-write, không sửa AppError gốc)
+	redactor := getRedactor()
+
 	// Thêm metadata hệ thống từ Details (function, file, stack trace)
 	for k, v := range appErr.Details {
-		fields[k] = v
+		fields[k] = redactor.redactValue(k, v)
+	}
+
+	// Thêm component (package) nếu được bật
+	if includeComponent {
+		fields["component"] = appErr.Component()
 	}
 
 	// Thêm dữ liệu đặc thù vào trường "data" riêng biệt (nếu có)
-	if len(appErr.Data) > 0 {
-		fields["data"] = appErr.Data
+	// Field errors (AddFieldError) được log trong data.fields
+	if appErr.HasData() || len(appErr.FieldErrors) > 0 {
+		data := redactor.redactMap(appErr.Data)
+		if len(appErr.FieldErrors) > 0 {
+			if data == nil {
+				data = make(map[string]interface{}, 1)
+			}
+			data["fields"] = redactor.redactValue("fields", fieldErrorMaps(appErr.FieldErrors))
+		}
+		// Payload quá lớn (file, body, ...) bị thay bằng placeholder (SetMaxDataBytes)
+		fields["data"] = limitData(data)
 	}
 
-	// Thêm cause nếu có
-	if appErr.Cause != nil {
+	// Thêm cause nếu có (nhiều cause từ WithCauses được log dạng mảng)
+	if len(appErr.Causes) > 0 {
+		causes := make([]string, len(appErr.Causes))
+		for i, c := range appErr.Causes {
+			causes[i] = c.Error()
+		}
+		fields["causes"] = causes
+	} else if appErr.Cause != nil {
 		fields["cause"] = appErr.Cause.Error()
+		// Cause bị wrap nhiều lớp: log từng lớp để không phải tự dựng lại chain
+		if chain := causeChain(appErr.Cause); len(chain) > 1 {
+			fields["cause_chain"] = chain
+		}
+	}
+
+	// Translation key luôn được log (không dịch) để grep được bất kể ngôn ngữ client
+	if appErr.MessageKey != "" {
+		fields["message_key"] = appErr.MessageKey
+		if len(appErr.MessageArgs) > 0 {
+			fields["message_args"] = appErr.MessageArgs
+		}
+	}
+
+	for k, v := range extra {
+		fields[k] = v
+	}
+
+	// Entry bị dedup nhưng vẫn hiện trên console
+	if consoleOnly {
+		defaultLogger.(ConsoleLogger).LogConsole(appErr.GetLogLevel(), appErr.Message, withGlobalFields(fields))
+		return
+	}
+
+	// MonitorOnly: ghi vào file sink bất kể FileLogLevel (nếu logger hỗ trợ)
+	if appErr.monitor {
+		fields["monitor_only"] = true
+		if ml, ok := defaultLogger.(MonitorLogger); ok {
+			ml.Monitor(appErr.GetLogLevel(), appErr.Message, withGlobalFields(fields))
+			return
+		}
 	}
 
 	// Log với level phù hợp (trace, debug, info, warn, error, panic)
-	logLevel := appErr.GetLogLevel()
+	logAtLevel(appErr.GetLogLevel(), appErr.Message, fields)
+}
+
+// maxCauseChainDepth giới hạn số lớp của cause_chain (chống chain vòng hoặc quá sâu)
+const maxCauseChainDepth = 10
+
+// causeChain đi theo Unwrap() error từ err, mỗi lớp gồm message
+// (kèm type và error_code nếu là AppError). Dừng ở error join (Unwrap() []error)
+func causeChain(err error) []map[string]interface{} {
+	var chain []map[string]interface{}
+	for err != nil && len(chain) < maxCauseChainDepth {
+		link := map[string]interface{}{"message": err.Error()}
+		if appErr, ok := err.(*AppError); ok {
+			link["type"] = string(appErr.Type)
+			if appErr.ErrCode != "" {
+				link["error_code"] = appErr.ErrCode
+			}
+		}
+		chain = append(chain, link)
+		err = errors.Unwrap(err)
+	}
+	return chain
+}
+
+// recoverLoggerPanic recover panic xảy ra trong lúc log và ghi tạm ra stderr
+// Đảm bảo lỗi của logging không bao giờ làm crash server
+func recoverLoggerPanic(appErr *AppError) {
+	if r := recover(); r != nil {
+		fmt.Fprintf(os.Stderr, "goerrorkit: logger panicked: %v (original error: [%s] %s, request_id=%s)\n",
+			r, appErr.Type, appErr.Message, appErr.RequestID)
+	}
+}
+
+// logAtLevel gọi method tương ứng của defaultLogger theo level string
+func logAtLevel(logLevel string, msg string, fields map[string]interface{}) {
+	fields = withGlobalFields(fields)
+
 	switch logLevel {
 	case "panic":
-		defaultLogger.Panic(appErr.Message, fields)
+		defaultLogger.Panic(msg, fields)
 	case "error":
-		defaultLogger.Error(appErr.Message, fields)
+		defaultLogger.Error(msg, fields)
 	case "warn":
-		defaultLogger.Warn(appErr.Message, fields)
+		defaultLogger.Warn(msg, fields)
 	case "info":
-		defaultLogger.Info(appErr.Message, fields)
-	case "debug":
-		defaultLogger.Debug(appErr.Message, fields)
-	case "trace":
-		defaultLogger.Trace(appErr.Message, fields)
+		defaultLogger.Info(msg, fields)
+	case "debug", "trace":
+		// Production build: Debug/Trace là no-op, fallback sang Warn để error không bị mất
+		if !debugBuild {
+			defaultLogger.Warn(msg, fields)
+		} else if logLevel == "debug" {
+			defaultLogger.Debug(msg, fields)
+		} else {
+			defaultLogger.Trace(msg, fields)
+		}
 	default:
 		// Default fallback to error
-		defaultLogger.Error(appErr.Message, fields)
+		defaultLogger.Error(msg, fields)
 	}
 }
 
+var (
+	authActionsMu sync.RWMutex
+
+	// authActions map HTTP code của AuthError sang hint "auth_action" trong response
+	authActions = map[int]string{
+		401: "login",
+		403: "contact_admin",
+	}
+)
+
+// SetAuthActions thay thế mapping HTTP code → auth_action của AuthError
+// Mặc định: 401 → "login", 403 → "contact_admin"
+//
+// Example:
+//
+//	goerrorkit.SetAuthActions(map[int]string{
+//	    401: "refresh_token",
+//	    403: "request_access",
+//	})
+func SetAuthActions(actions map[int]string) {
+	copied := make(map[int]string, len(actions))
+	for code, action := range actions {
+		copied[code] = action
+	}
+
+	authActionsMu.Lock()
+	authActions = copied
+	authActionsMu.Unlock()
+}
+
+// authAction trả về hint auth_action cho HTTP code của AuthError
+func authAction(code int) (string, bool) {
+	authActionsMu.RLock()
+	defer authActionsMu.RUnlock()
+	action, ok := authActions[code]
+	return action, ok
+}
+
 // FormatErrorResponse tạo response data cho client
 // Chỉ trả về thông tin cần thiết, không expose internal details
+// Cause chỉ được trả về khi CauseExposurePolicy cho phép (xem SetCauseExposurePolicy)
+// Object "debug" chỉ được thêm khi bật SetDebugResponses(true)
 func FormatErrorResponse(appErr *AppError) map[string]interface{} {
-	return map[string]interface{}{
-		"error": appErr.Message,
+	return FormatLocalizedErrorResponse(appErr, "")
+}
+
+// FormatLocalizedErrorResponse giống FormatErrorResponse nhưng dịch message sang lang
+// nếu error có MessageKey và đã SetTranslator (fallback về Message)
+func FormatLocalizedErrorResponse(appErr *AppError, lang string) map[string]interface{} {
+	response := map[string]interface{}{
+		"error": appErr.LocalizedMessage(lang),
 		"type":  string(appErr.Type),
 	}
+
+	// Application error code để client branch logic (độc lập với HTTP status)
+	if appErr.ErrCode != "" {
+		response["code"] = appErr.ErrCode
+	}
+
+	// Hint cho client biết cần làm gì với AuthError (re-login vs xin quyền)
+	if appErr.Type == AuthError {
+		if action, ok := authAction(appErr.Code); ok {
+			response["auth_action"] = action
+		}
+	}
+
+	// Trả request ID về client để đối chiếu với log
+	if appErr.RequestID != "" {
+		response["request_id"] = appErr.RequestID
+	}
+
+	// Reference code để user báo cho support (cùng giá trị với field "ref" trong log)
+	response["ref"] = appErr.Ref()
+
+	// Lỗi theo từng field (NewValidationErrors + AddFieldError)
+	if len(appErr.FieldErrors) > 0 {
+		response["fields"] = appErr.FieldErrors
+	}
+
+	if shouldExposeCause(appErr) {
+		response["cause"] = appErr.Cause.Error()
+	}
+
+	// Debug info (file, function, call_chain, data, cause) - chỉ khi SetDebugResponses(true)
+	if IsDebugResponsesEnabled() {
+		debugInfo := map[string]interface{}{}
+		for k, v := range appErr.Details {
+			debugInfo[k] = v
+		}
+		if appErr.HasData() {
+			debugInfo["data"] = appErr.Data
+		}
+		if appErr.Cause != nil {
+			debugInfo["cause"] = appErr.Cause.Error()
+		}
+		if len(appErr.Frames) > 0 {
+			debugInfo["frames"] = appErr.Frames
+		}
+		if !appErr.CreatedAt.IsZero() {
+			debugInfo["created_at"] = appErr.CreatedAt.Format(time.RFC3339Nano)
+		}
+		response["debug"] = debugInfo
+	}
+
+	return response
 }
 
 // LogAndRespond xử lý logging và gửi response (framework agnostic)
 // Đây là helper function cho adapters
+// Log có thêm response_content_type và response_size của error response
 func LogAndRespond(ctx HTTPContext, appErr *AppError, requestPath string) {
-	// 1. Log error
-	LogError(appErr, requestPath)
+	// Connection đã upgrade (WebSocket) hoặc điều kiện của SetSkipResponseWhen: chỉ log
+	// Connection chưa upgrade/hijack vẫn được set status code (không có body)
+	if shouldSkipResponse(ctx) {
+		if !IsUpgradedConnection(ctx) {
+			ctx.Status(appErr.Code)
+		}
+		logRequestError(ctx, appErr, requestPath, nil)
+		return
+	}
+
+	// 1. Send response: browser nhận trang HTML (xem SetHTMLErrorTemplate),
+	// API client nhận format của ResponseWriter đang được chọn (xem SetResponseWriter)
+	recorder := newResponseRecorder(ctx)
+	negotiatedResponseWriter(ctx).WriteError(recorder, appErr)
+
+	// 2. Log error (bỏ qua path trong SetExcludedPaths) kèm thông tin response
+	logRequestError(ctx, appErr, requestPath, recorder.fields())
+}
+
+// LogAndRespondWith giống LogAndRespond nhưng response body do formatter tạo (luôn JSON)
+// formatter == nil hoặc connection đã upgrade (xem SetSkipResponseWhen) → giống LogAndRespond
+// Dùng cho adapter có option custom response body (ví dụ Config.Formatter của adapters/fiber)
+//
+// Example:
+//
+//	goerrorkit.LogAndRespondWith(ctx, appErr, requestPath, func(appErr *goerrorkit.AppError) interface{} {
+//	    return map[string]interface{}{"code": appErr.Code, "msg": appErr.Message}
+//	})
+func LogAndRespondWith(ctx HTTPContext, appErr *AppError, requestPath string, formatter func(appErr *AppError) interface{}) {
+	if formatter == nil || shouldSkipResponse(ctx) {
+		LogAndRespond(ctx, appErr, requestPath)
+		return
+	}
+
+	recorder := newResponseRecorder(ctx)
+	writeHeaders(recorder, appErr)
+	recorder.Status(appErr.Code).JSON(formatter(appErr))
+	logRequestError(ctx, appErr, requestPath, recorder.fields())
+}
+
+// writeHeaders gửi AppError.Headers nếu HTTPContext hỗ trợ HeaderSetter
+func writeHeaders(ctx HTTPContext, appErr *AppError) {
+	if len(appErr.Headers) == 0 {
+		return
+	}
+	if hs, ok := ctx.(HeaderSetter); ok {
+		for k, v := range appErr.Headers {
+			// Headers có thể được gán trực tiếp (không qua WithHeader) nên sanitize lại trước khi gửi
+			if validHeaderKey(k) {
+				hs.SetHeader(k, sanitizeHeaderValue(v))
+			}
+		}
+	}
+}
+
+// validHeaderKey kiểm tra header key không rỗng và không chứa ký tự điều khiển, khoảng trắng hay ':'
+func validHeaderKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if c := key[i]; c <= ' ' || c == ':' || c >= 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+// sanitizeHeaderValue loại bỏ CR/LF (và NUL) khỏi header value để chống header injection
+func sanitizeHeaderValue(value string) string {
+	if !strings.ContainsAny(value, "\r\n\x00") {
+		return value
+	}
+	return strings.NewReplacer("\r", "", "\n", "", "\x00", "").Replace(value)
+}
+
+// WriteError convert một error bất kỳ sang AppError rồi log và gửi response (framework agnostic)
+// An toàn khi truyền vào *AppError (hoặc AppError bị wrap). Request ID được lấy từ AppError
+// nếu có, ngược lại từ ctx.GetLocal("requestid").
+// Nếu ctx implement RequestContextGetter, request bị client hủy được convert thành 499 (xem ConvertToAppErrorCtx)
+// Hữu ích khi cần xử lý lỗi ngay giữa handler mà không return lên middleware chain
+//
+// Example:
+//
+//	app.Get("/export", func(c *fiber.Ctx) error {
+//	    ctx := goerrorkitfiber.NewFiberContext(c)
+//	    if err := validate(c); err != nil {
+//	        goerrorkit.WriteError(ctx, err, c.Method()+" "+c.Path())
+//	        return nil
+//	    }
+//	    // ...
+//	})
+func WriteError(ctx HTTPContext, err error, requestPath string) {
+	if err == nil {
+		return
+	}
+
+	requestID := inheritRequestID(err)
+	if requestID == "" {
+		requestID = "unknown"
+		if rid, ok := ctx.GetLocal("requestid").(string); ok {
+			requestID = rid
+		}
+	}
 
-	// 2. Send response
-	ctx.Status(appErr.Code).JSON(FormatErrorResponse(appErr))
+	appErr := ConvertToAppErrorCtx(requestContext(ctx), err, requestID)
+	LogAndRespond(ctx, appErr, requestPath)
 }
@@ -0,0 +1,133 @@
+package goerrorkit
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Biến môi trường cho InitLoggerFromEnv / LoggerOptionsFromEnv
+const (
+	envFileLogLevel = "GOERRORKIT_FILE_LOG_LEVEL"
+	envLogFile      = "GOERRORKIT_LOG_FILE"
+	envFormat       = "GOERRORKIT_FORMAT"
+	envJSON         = "GOERRORKIT_JSON"
+	envConsole      = "GOERRORKIT_CONSOLE"
+	envFile         = "GOERRORKIT_FILE"
+	envMaxSize      = "GOERRORKIT_MAX_SIZE"
+	envMaxBackups   = "GOERRORKIT_MAX_BACKUPS"
+	envMaxAge       = "GOERRORKIT_MAX_AGE"
+)
+
+// LoggerOptionsFromEnv đọc LoggerOptions từ biến môi trường, fallback về DefaultLoggerOptions:
+//
+//	GOERRORKIT_LOG_LEVEL       → LogLevel
+//	GOERRORKIT_FILE_LOG_LEVEL  → FileLogLevel
+//	GOERRORKIT_LOG_FILE        → FilePath
+//	GOERRORKIT_JSON            → JSONFormat (true/false/1/0)
+//	GOERRORKIT_FORMAT          → Format (json/text/ecs/logfmt)
+//	GOERRORKIT_CONSOLE         → ConsoleOutput
+//	GOERRORKIT_FILE            → FileOutput
+//	GOERRORKIT_MAX_SIZE        → MaxFileSize (MB)
+//	GOERRORKIT_MAX_BACKUPS     → MaxBackups
+//	GOERRORKIT_MAX_AGE         → MaxAge (ngày)
+//
+// Giá trị không hợp lệ bị bỏ qua (giữ mặc định) và được gộp vào error trả về.
+//
+// Example:
+//
+//	opts, err := goerrorkit.LoggerOptionsFromEnv()
+//	if err != nil {
+//	    log.Fatalf("invalid logger env: %v", err)
+//	}
+//	opts.ServiceName = "order-service"
+//	goerrorkit.InitLogger(opts)
+func LoggerOptionsFromEnv() (LoggerOptions, error) {
+	opts := DefaultLoggerOptions()
+	var errs []error
+
+	if v := os.Getenv(logLevelEnvVar); v != "" {
+		opts.LogLevel = v
+	}
+	if v := os.Getenv(envFileLogLevel); v != "" {
+		opts.FileLogLevel = v
+	}
+	if v := os.Getenv(envLogFile); v != "" {
+		opts.FilePath = v
+	}
+	if v := os.Getenv(envFormat); v != "" {
+		opts.Format = v
+	}
+
+	for _, b := range []struct {
+		name   string
+		target *bool
+	}{
+		{envJSON, &opts.JSONFormat},
+		{envConsole, &opts.ConsoleOutput},
+		{envFile, &opts.FileOutput},
+	} {
+		if err := envBool(b.name, b.target); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, n := range []struct {
+		name   string
+		target *int
+	}{
+		{envMaxSize, &opts.MaxFileSize},
+		{envMaxBackups, &opts.MaxBackups},
+		{envMaxAge, &opts.MaxAge},
+	} {
+		if err := envInt(n.name, n.target); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return opts, errors.Join(errs...)
+}
+
+// InitLoggerFromEnv khởi tạo logger từ biến môi trường (xem LoggerOptionsFromEnv)
+// Giá trị không hợp lệ được cảnh báo ra stderr và thay bằng mặc định, giống InitLogger
+//
+// Example:
+//
+//	// GOERRORKIT_LOG_FILE=/var/log/app/errors.log GOERRORKIT_CONSOLE=false ./app
+//	goerrorkit.InitLoggerFromEnv()
+func InitLoggerFromEnv() {
+	opts, err := LoggerOptionsFromEnv()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "goerrorkit: invalid logger environment: %v\n", err)
+	}
+	InitLogger(opts)
+}
+
+// envBool đọc biến môi trường dạng bool vào target (không đổi nếu biến rỗng hoặc sai)
+func envBool(name string, target *bool) error {
+	v := os.Getenv(name)
+	if v == "" {
+		return nil
+	}
+	parsed, err := strconv.ParseBool(v)
+	if err != nil {
+		return fmt.Errorf("%s: invalid bool %q", name, v)
+	}
+	*target = parsed
+	return nil
+}
+
+// envInt đọc biến môi trường dạng số nguyên không âm vào target (không đổi nếu biến rỗng hoặc sai)
+func envInt(name string, target *int) error {
+	v := os.Getenv(name)
+	if v == "" {
+		return nil
+	}
+	parsed, err := strconv.Atoi(v)
+	if err != nil || parsed < 0 {
+		return fmt.Errorf("%s: invalid non-negative integer %q", name, v)
+	}
+	*target = parsed
+	return nil
+}
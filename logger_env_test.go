@@ -0,0 +1,116 @@
+package goerrorkit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// clearLoggerEnv đặt rỗng mọi biến GOERRORKIT_* (rỗng = không set) để test không phụ thuộc môi trường
+func clearLoggerEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{
+		logLevelEnvVar, envFileLogLevel, envLogFile, envFormat, envJSON, envConsole,
+		envFile, envMaxSize, envMaxBackups, envMaxAge,
+	} {
+		t.Setenv(name, "")
+	}
+}
+
+func TestLoggerOptionsFromEnvDefaults(t *testing.T) {
+	clearLoggerEnv(t)
+
+	opts, err := LoggerOptionsFromEnv()
+	if err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	if !reflect.DeepEqual(opts, DefaultLoggerOptions()) {
+		t.Errorf("opts = %+v, want DefaultLoggerOptions()", opts)
+	}
+}
+
+func TestLoggerOptionsFromEnv(t *testing.T) {
+	tests := []struct {
+		name, value string
+		got         func(LoggerOptions) interface{}
+		want        interface{}
+	}{
+		{logLevelEnvVar, "debug", func(o LoggerOptions) interface{} { return o.LogLevel }, "debug"},
+		{envFileLogLevel, "warn", func(o LoggerOptions) interface{} { return o.FileLogLevel }, "warn"},
+		{envLogFile, "/var/log/app/errors.log", func(o LoggerOptions) interface{} { return o.FilePath }, "/var/log/app/errors.log"},
+		{envFormat, "ecs", func(o LoggerOptions) interface{} { return o.Format }, "ecs"},
+		{envJSON, "0", func(o LoggerOptions) interface{} { return o.JSONFormat }, false},
+		{envConsole, "false", func(o LoggerOptions) interface{} { return o.ConsoleOutput }, false},
+		{envFile, "FALSE", func(o LoggerOptions) interface{} { return o.FileOutput }, false},
+		{envMaxSize, "50", func(o LoggerOptions) interface{} { return o.MaxFileSize }, 50},
+		{envMaxBackups, "0", func(o LoggerOptions) interface{} { return o.MaxBackups }, 0},
+		{envMaxAge, "7", func(o LoggerOptions) interface{} { return o.MaxAge }, 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearLoggerEnv(t)
+			t.Setenv(tt.name, tt.value)
+
+			opts, err := LoggerOptionsFromEnv()
+			if err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			if got := tt.got(opts); got != tt.want {
+				t.Errorf("%s=%q → %v, want %v", tt.name, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoggerOptionsFromEnvInvalidValues(t *testing.T) {
+	clearLoggerEnv(t)
+	t.Setenv(envConsole, "yes-please")
+	t.Setenv(envMaxSize, "big")
+	t.Setenv(envMaxAge, "-1")
+	t.Setenv(envMaxBackups, "3")
+
+	opts, err := LoggerOptionsFromEnv()
+	if err == nil {
+		t.Fatal("expected error for invalid values")
+	}
+	for _, name := range []string{envConsole, envMaxSize, envMaxAge} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("err = %q, want it to mention %s", err, name)
+		}
+	}
+	def := DefaultLoggerOptions()
+	if opts.ConsoleOutput != def.ConsoleOutput || opts.MaxFileSize != def.MaxFileSize || opts.MaxAge != def.MaxAge {
+		t.Errorf("invalid values should keep defaults: %+v", opts)
+	}
+	if opts.MaxBackups != 3 {
+		t.Errorf("MaxBackups = %d, valid values must still apply", opts.MaxBackups)
+	}
+}
+
+func TestInitLoggerFromEnv(t *testing.T) {
+	clearLoggerEnv(t)
+	path := filepath.Join(t.TempDir(), "env.log")
+	t.Setenv(envLogFile, path)
+	t.Setenv(envConsole, "false")
+	t.Setenv(envMaxSize, "big")
+	defer SetLogger(nil)
+
+	warning := captureStderr(t, InitLoggerFromEnv)
+
+	if !strings.Contains(warning, "invalid logger environment") || !strings.Contains(warning, envMaxSize) {
+		t.Errorf("stderr = %q, want warning about %s", warning, envMaxSize)
+	}
+
+	LogError(NewSystemError(errors.New("db down")), "GET /orders")
+	CloseLogger()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("log file from %s not written: %v", envLogFile, err)
+	}
+	if !strings.Contains(string(data), "db down") {
+		t.Errorf("env.log = %q, want the logged error", data)
+	}
+}
@@ -0,0 +1,90 @@
+package goerrorkit
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+// captureStderr chạy fn và trả về những gì được ghi ra os.Stderr
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr = w
+	defer func() { os.Stderr = stderr }()
+
+	fn()
+	w.Close()
+	out, _ := io.ReadAll(r)
+	return string(out)
+}
+
+// panickingLogger giả lập Logger của bên thứ ba (ví dụ hook Sentry lỗi) panic khi log
+type panickingLogger struct{ *MemoryLogger }
+
+func (panickingLogger) Error(msg string, fields map[string]interface{}) {
+	panic("sentry hook: nil client")
+}
+
+// panickingFormatter là logrus formatter panic khi format entry
+type panickingFormatter struct{}
+
+func (panickingFormatter) Format(*logrus.Entry) ([]byte, error) {
+	panic("formatter: unsupported field")
+}
+
+func TestLogErrorRecoversLoggerPanic(t *testing.T) {
+	console := logrus.New()
+	console.SetOutput(io.Discard)
+	console.SetFormatter(panickingFormatter{})
+
+	tests := []struct {
+		name   string
+		logger Logger
+		want   string
+	}{
+		{"custom logger", panickingLogger{NewMemoryLogger()}, "sentry hook: nil client"},
+		{"logrus formatter", &LogrusLogger{consoleLogger: console}, "formatter: unsupported field"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetLogger(tt.logger)
+			defer SetLogger(nil)
+
+			appErr := NewSystemError(errors.New("db down"))
+			appErr.RequestID = "req-42"
+			stderr := captureStderr(t, func() { LogError(appErr, "GET /orders") })
+
+			for _, want := range []string{"logger panicked: " + tt.want, "[SYSTEM] Internal server error", "request_id=req-42"} {
+				if !strings.Contains(stderr, want) {
+					t.Errorf("stderr = %q, want %q", stderr, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLogErrorRecoversRedactFuncPanic(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+	SetRedactFunc(func(key string, value interface{}) (interface{}, bool) { panic("redact hook") })
+	defer SetRedactFunc(nil)
+
+	stderr := captureStderr(t, func() {
+		LogError(NewSystemError(nil).WithData(map[string]interface{}{"order_id": 42}), "GET /orders")
+	})
+	if !strings.Contains(stderr, "logger panicked: redact hook") {
+		t.Errorf("stderr = %q", stderr)
+	}
+	if len(mem.Entries()) != 0 {
+		t.Errorf("entries = %v, want none after the hook panicked", mem.Entries())
+	}
+}
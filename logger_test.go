@@ -0,0 +1,113 @@
+package goerrorkit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// openHandles đếm file descriptor của process đang trỏ tới path (Linux: /proc/self/fd)
+func openHandles(t *testing.T, path string) int {
+	t.Helper()
+	fds, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		t.Skip("/proc/self/fd not available")
+	}
+	n := 0
+	for _, fd := range fds {
+		if target, err := os.Readlink(filepath.Join("/proc/self/fd", fd.Name())); err == nil && target == path {
+			n++
+		}
+	}
+	return n
+}
+
+func initFileLogger(t *testing.T, path string) {
+	t.Helper()
+	if err := InitLoggerE(LoggerOptions{FileOutput: true, FilePath: path, JSONFormat: true, FileLogLevel: "error"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInitLoggerClosesPreviousLogger(t *testing.T) {
+	dir := t.TempDir()
+	first, second := filepath.Join(dir, "first.log"), filepath.Join(dir, "second.log")
+	defer SetLogger(nil)
+
+	initFileLogger(t, first)
+	LogError(NewSystemError(errors.New("db down")), "GET /orders")
+	if got := openHandles(t, first); got != 1 {
+		t.Fatalf("first.log handles = %d, want 1 while logger is active", got)
+	}
+
+	initFileLogger(t, second)
+	defer CloseLogger()
+	if got := openHandles(t, first); got != 0 {
+		t.Errorf("first.log handles = %d after second InitLogger, want 0", got)
+	}
+	if got := readFile(t, first); !strings.Contains(got, "db down") {
+		t.Errorf("first.log = %q, want record written before re-init", got)
+	}
+}
+
+// closeRecordingLogger là custom logger có Close, ghi nhận số lần bị đóng
+type closeRecordingLogger struct {
+	*MemoryLogger
+	closed int
+}
+
+func (l *closeRecordingLogger) Close() error {
+	l.closed++
+	return nil
+}
+
+func TestInitLoggerDoesNotCloseCustomLogger(t *testing.T) {
+	custom := &closeRecordingLogger{MemoryLogger: NewMemoryLogger()}
+	SetLogger(custom)
+
+	initFileLogger(t, filepath.Join(t.TempDir(), "errors.log"))
+	defer func() {
+		_ = CloseLogger()
+		SetLogger(nil)
+	}()
+
+	// Logger set qua SetLogger thuộc về caller: InitLogger không đóng nó
+	if custom.closed != 0 {
+		t.Errorf("custom logger closed %d times by InitLogger", custom.closed)
+	}
+}
+
+func TestCloseLoggerClosesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.log")
+	initFileLogger(t, path)
+	defer SetLogger(nil)
+
+	LogError(NewSystemError(errors.New("db down")), "GET /orders")
+	if err := FlushLogger(); err != nil {
+		t.Fatalf("FlushLogger = %v", err)
+	}
+	if got := readFile(t, path); !strings.Contains(got, "db down") {
+		t.Errorf("errors.log after FlushLogger = %q", got)
+	}
+
+	if err := CloseLogger(); err != nil {
+		t.Fatalf("CloseLogger = %v", err)
+	}
+	if got := openHandles(t, path); got != 0 {
+		t.Errorf("errors.log handles = %d after CloseLogger, want 0", got)
+	}
+}
+
+func TestCloseLoggerWithoutCloser(t *testing.T) {
+	UseMemoryLogger()
+	defer SetLogger(nil)
+
+	if err := FlushLogger(); err != nil {
+		t.Errorf("FlushLogger = %v, want nil for logger without Flush", err)
+	}
+	if err := CloseLogger(); err != nil {
+		t.Errorf("CloseLogger = %v, want nil for logger without Close", err)
+	}
+}
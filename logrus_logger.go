@@ -1,18 +1,173 @@
 package goerrorkit
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
-	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 // LogrusLogger implement Logger interface sử dụng logrus
 // Hỗ trợ dual-level logging: console và file có thể có log level khác nhau
 type LogrusLogger struct {
-	consoleLogger *logrus.Logger // Logger cho console
-	fileLogger    *logrus.Logger // Logger cho file (có thể nil nếu không dùng file)
+	consoleLogger *logrus.Logger    // Logger cho console
+	files         []*fileSinkLogger // File output (FilePath hoặc FileOutputs), rỗng nếu không dùng file
+}
+
+// Monitor implements MonitorLogger
+// Console vẫn tuân theo LogLevel, còn file luôn được ghi bất kể FileLogLevel
+// Với FileOutputs: ghi vào các sink có khoảng level chứa entry, không có thì ghi vào sink đầu tiên
+func (l *LogrusLogger) Monitor(level string, msg string, fields map[string]interface{}) {
+	lvl := entryLevel(level)
+	if l.consoleLogger != nil {
+		l.consoleLogger.WithFields(fields).Log(lvl, msg)
+	}
+	written := false
+	for _, f := range l.files {
+		if f.accepts(lvl) {
+			f.monitor.WithFields(fields).Log(lvl, msg)
+			written = true
+		}
+	}
+	if !written && len(l.files) > 0 {
+		l.files[0].monitor.WithFields(fields).Log(lvl, msg)
+	}
+}
+
+// logFiles ghi entry vào mọi file sink có khoảng level phù hợp
+func (l *LogrusLogger) logFiles(lvl logrus.Level, msg string, fields map[string]interface{}) {
+	for _, f := range l.files {
+		f.log(lvl, msg, fields)
+	}
+}
+
+// LogConsole implements ConsoleLogger - chỉ ghi ra console (dùng cho entry bị dedup ở file)
+func (l *LogrusLogger) LogConsole(level string, msg string, fields map[string]interface{}) {
+	if l.consoleLogger != nil {
+		l.consoleLogger.WithFields(fields).Log(entryLevel(level), msg)
+	}
+}
+
+// entryLevel convert level string của goerrorkit sang logrus.Level để ghi entry
+func entryLevel(level string) logrus.Level {
+	lvl, err := parseLogrusLevel(level)
+	if err != nil || lvl < logrus.ErrorLevel {
+		lvl = logrus.ErrorLevel // Không bao giờ panic/fatal thật
+	}
+	if !debugBuild && lvl > logrus.InfoLevel {
+		lvl = logrus.WarnLevel // Production build: debug/trace fallback sang warn
+	}
+	return lvl
+}
+
+// SetConsoleLevel thay đổi log level của console tại runtime (atomic, không cần InitLogger lại)
+func (l *LogrusLogger) SetConsoleLevel(level string) error {
+	lvl, err := parseLogrusLevel(level)
+	if err != nil {
+		return err
+	}
+	if l.consoleLogger != nil {
+		l.consoleLogger.SetLevel(lvl)
+	}
+	return nil
+}
+
+// SetFileLevel thay đổi log level của file tại runtime (atomic, không cần InitLogger lại)
+// Với FileOutputs nhiều sink, trả về error để không xoá mất khoảng level riêng của từng sink
+// (dùng SetFileSinkLevel)
+func (l *LogrusLogger) SetFileLevel(level string) error {
+	lvl, err := parseLogrusLevel(level)
+	if err != nil {
+		return err
+	}
+	if len(l.files) > 1 {
+		return fmt.Errorf("goerrorkit: logger has %d file sinks, use SetFileSinkLevel to change one sink", len(l.files))
+	}
+	for _, f := range l.files {
+		f.logger.SetLevel(lvl)
+	}
+	return nil
+}
+
+// SetFileSinkLevel thay đổi MinLevel của sink có Path = path (FileOutputs) tại runtime
+// Các sink khác giữ nguyên level
+func (l *LogrusLogger) SetFileSinkLevel(path, level string) error {
+	lvl, err := parseLogrusLevel(level)
+	if err != nil {
+		return err
+	}
+	for _, f := range l.files {
+		if f.path == path {
+			if lvl < f.maxLevel {
+				return fmt.Errorf("goerrorkit: level %q is more severe than MaxLevel of file sink %q", level, path)
+			}
+			f.logger.SetLevel(lvl)
+			return nil
+		}
+	}
+	return fmt.Errorf("goerrorkit: no file sink with path %q", path)
+}
+
+// Levels trả về log level hiện tại của console và file ("" nếu output đó không bật)
+// Với FileOutputs nhiều sink, file là MinLevel ít nghiêm trọng nhất (level thấp nhất còn được
+// ghi vào ít nhất một file) - dùng FileSinkLevels để xem level của từng sink
+func (l *LogrusLogger) Levels() (console, file string) {
+	if l.consoleLogger != nil {
+		console = logrusLevelName(l.consoleLogger.GetLevel())
+	}
+	if len(l.files) > 0 {
+		fileLevel := l.files[0].logger.GetLevel()
+		for _, f := range l.files[1:] {
+			if lvl := f.logger.GetLevel(); lvl > fileLevel {
+				fileLevel = lvl
+			}
+		}
+		file = logrusLevelName(fileLevel)
+	}
+	return console, file
+}
+
+// FileSinkLevels trả về MinLevel hiện tại của từng file sink theo Path
+func (l *LogrusLogger) FileSinkLevels() map[string]string {
+	levels := make(map[string]string, len(l.files))
+	for _, f := range l.files {
+		levels[f.path] = logrusLevelName(f.logger.GetLevel())
+	}
+	return levels
+}
+
+// logrusLevelName convert logrus.Level sang tên level của goerrorkit ("warning" → "warn")
+func logrusLevelName(level logrus.Level) string {
+	if name, err := ParseLevel(level.String()); err == nil {
+		return name
+	}
+	return level.String()
+}
+
+// Flush chờ các file log entry đang nằm trong async buffer được ghi xuống disk
+// No-op nếu không bật AsyncFile
+func (l *LogrusLogger) Flush(ctx context.Context) error {
+	var errs []error
+	for _, f := range l.files {
+		if f.async != nil {
+			errs = append(errs, f.async.Flush(ctx))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close drain async buffer (nếu bật AsyncFile) và đóng file log của mọi file sink
+// Gọi khi shutdown hoặc trước os.Exit để record cuối cùng được ghi xuống disk
+func (l *LogrusLogger) Close() error {
+	var errs []error
+	for _, f := range l.files {
+		errs = append(errs, f.close())
+	}
+	return errors.Join(errs...)
 }
 
 // Error implements Logger
@@ -20,9 +175,7 @@ func (l *LogrusLogger) Error(msg string, fields map[string]interface{}) {
 	if l.consoleLogger != nil {
 		l.consoleLogger.WithFields(fields).Error(msg)
 	}
-	if l.fileLogger != nil {
-		l.fileLogger.WithFields(fields).Error(msg)
-	}
+	l.logFiles(logrus.ErrorLevel, msg, fields)
 }
 
 // Info implements Logger
@@ -30,9 +183,7 @@ func (l *LogrusLogger) Info(msg string, fields map[string]interface{}) {
 	if l.consoleLogger != nil {
 		l.consoleLogger.WithFields(fields).Info(msg)
 	}
-	if l.fileLogger != nil {
-		l.fileLogger.WithFields(fields).Info(msg)
-	}
+	l.logFiles(logrus.InfoLevel, msg, fields)
 }
 
 // Debug và Trace methods được implement trong:
@@ -44,9 +195,7 @@ func (l *LogrusLogger) Warn(msg string, fields map[string]interface{}) {
 	if l.consoleLogger != nil {
 		l.consoleLogger.WithFields(fields).Warn(msg)
 	}
-	if l.fileLogger != nil {
-		l.fileLogger.WithFields(fields).Warn(msg)
-	}
+	l.logFiles(logrus.WarnLevel, msg, fields)
 }
 
 // Panic implements Logger
@@ -54,9 +203,7 @@ func (l *LogrusLogger) Panic(msg string, fields map[string]interface{}) {
 	if l.consoleLogger != nil {
 		l.consoleLogger.WithFields(fields).Error(msg) // Log as Error, not Panic (không muốn panic thật)
 	}
-	if l.fileLogger != nil {
-		l.fileLogger.WithFields(fields).Error(msg)
-	}
+	l.logFiles(logrus.ErrorLevel, msg, fields)
 }
 
 // LoggerOptions cấu hình cho logger
@@ -65,6 +212,7 @@ type LoggerOptions struct {
 	ConsoleOutput bool
 
 	// FileOutput - Log ra file hay không
+	// Nếu ghi file lỗi (disk full, ...), record được ghi ra stdout kèm cảnh báo một lần ra stderr
 	FileOutput bool
 
 	// FilePath - Đường dẫn file log
@@ -73,6 +221,12 @@ type LoggerOptions struct {
 	// JSONFormat - Dùng JSON format hay text format
 	JSONFormat bool
 
+	// Format - Format log: "json", "text" (chỉ console, file vẫn JSON), "ecs" (Elastic Common Schema)
+	// hoặc "logfmt" (ecs và logfmt áp dụng cho cả console và file). Rỗng → theo JSONFormat
+	// (true → "json", false → "text")
+	// Với "ecs", FieldMap bị bỏ qua vì tên field do ECS quy định
+	Format string
+
 	// MaxFileSize - Kích thước tối đa của file log (MB) trước khi rotate
 	MaxFileSize int
 
@@ -82,16 +236,112 @@ type LoggerOptions struct {
 	// MaxAge - Số ngày giữ file log cũ
 	MaxAge int
 
+	// RotateDaily - Rotate file log mỗi ngày (0h giờ local) bất kể kích thước,
+	// mỗi ngày một file: FilePath "logs/errors.log" → "logs/errors-2025-11-28.log"
+	// MaxFileSize vẫn rotate trong ngày; MaxBackups giới hạn số backup của từng ngày,
+	// MaxAge áp dụng cho mọi file của các ngày cũ
+	RotateDaily bool
+
+	// RotateInterval - Rotate file log theo khoảng thời gian cố định (VD: time.Hour → "errors-2025-11-28T10-00.log")
+	// Bị bỏ qua khi RotateDaily = true. 0: chỉ rotate theo kích thước
+	RotateInterval time.Duration
+
+	// DirPerm - Permission khi tạo thư mục chứa file log (mặc định 0755)
+	// VD: 0700 cho môi trường nhạy cảm
+	DirPerm os.FileMode
+
 	// LogLevel - Level tối thiểu để log ra console (trace, debug, info, warn, error, panic)
 	// LƯU Ý: trace và debug chỉ hoạt động khi build với -tags=debug
 	//        Production build sẽ bỏ qua hoàn toàn (zero overhead)
 	LogLevel string
 
+	// FileOutputs - Nhiều file log, mỗi file một khoảng level và format riêng
+	// (VD: warn vào warnings.log, error/panic vào errors.log). Khác rỗng → thay cho FileOutput,
+	// FilePath và FileLogLevel. Rotation (MaxFileSize, MaxBackups, MaxAge, RotateDaily, ...) áp dụng cho từng file
+	// Xem FileSink
+	FileOutputs []FileSink
+
 	// FileLogLevel - Level tối thiểu để log ra file (trace, debug, info, warn, error, panic)
 	// Mặc định sẽ dùng "error" để chỉ log các lỗi nghiêm trọng vào file
 	// VD: FileLogLevel = "error" -> chỉ log error và panic vào file, bỏ qua warn
 	// LƯU Ý: trace và debug chỉ hoạt động khi build với -tags=debug
 	FileLogLevel string
+
+	// AsyncFile - Ghi file log bất đồng bộ qua bounded buffer, drain bởi background goroutine
+	// Giữ LogError khỏi hot path (ghi file + lumberjack rotation). Console vẫn ghi đồng bộ.
+	// Khi buffer đầy, entry CŨ NHẤT bị bỏ (drop-oldest) - LogError không bao giờ bị block.
+	// Gọi goerrorkit.FlushLogs(ctx) hoặc goerrorkit.CloseLogger() khi shutdown để không mất log.
+	AsyncFile bool
+
+	// AsyncBufferSize - Số log entry tối đa trong async buffer (mặc định 1024)
+	AsyncBufferSize int
+
+	// Sampling - Sampling/rate limiting cho LogError theo log level
+	// nil: giữ cấu hình hiện tại của SetSampling (mặc định log tất cả). Xem SamplingOptions
+	Sampling *SamplingOptions
+
+	// ServiceName - Tên service, được thêm vào MỌI log record dưới field "service.name"
+	// Dùng cho shared logging platform để phân biệt log giữa các service
+	ServiceName string
+
+	// DedupWindow - Gộp các error giống nhau (cùng fingerprint) trong khoảng thời gian này:
+	// lần đầu được log ngay, các lần lặp lại bị suppress và một summary record
+	// "repeated N times between ... and ..." được log khi window kết thúc
+	// 0: giữ cấu hình hiện tại của SetDedup (mặc định tắt)
+	// Dùng SetDedup để cấu hình chi tiết (MaxKeys, KeyFunc, Clock) hoặc SetDedup(nil) để tắt
+	DedupWindow time.Duration
+
+	// DedupKeepConsole - Khi bật DedupWindow, console vẫn nhận mọi entry (chỉ file bị dedup)
+	DedupKeepConsole bool
+
+	// IncludeClientInfo - Thêm client_ip và user_agent vào log của request error
+	// false: giữ cấu hình hiện tại của SetIncludeClientInfo (mặc định tắt)
+	IncludeClientInfo bool
+
+	// FilePrettyPrint - Ghi file log dạng JSON nhiều dòng (indent)
+	// Mặc định false: mỗi record là một dòng JSON (phù hợp Filebeat, Fluent Bit, Vector, ...)
+	FilePrettyPrint bool
+
+	// ConsoleCompact - Console JSON/ECS một dòng mỗi record thay vì nhiều dòng (indent)
+	// Mặc định false: console giữ JSON nhiều dòng dễ đọc như trước, kể cả khi LoggerOptions
+	// được khai báo trực tiếp không qua DefaultLoggerOptions
+	ConsoleCompact bool
+
+	// PrettyConsole - Console dạng dễ đọc cho develop khi format là "text": dòng đầu gồm level (có màu),
+	// [error_type] error_code và message; call_chain in thành block nhiều dòng, data được flatten
+	// Không ảnh hưởng JSON/ECS/logfmt và file log
+	PrettyConsole bool
+
+	// TimestampFormat - Layout thời gian (time.Format) cho cả console và file
+	// Mặc định time.RFC3339 cho JSON, "2006-01-02 15:04:05" cho text format
+	TimestampFormat string
+
+	// FieldMap - Đổi tên field chuẩn: "timestamp", "level", "message"
+	// VD: map[string]string{"timestamp": "ts", "message": "msg"} cho ECS/Loki pipeline
+	FieldMap map[string]string
+}
+
+// logLevelEnvVar là biến môi trường override LoggerOptions.LogLevel
+const logLevelEnvVar = "GOERRORKIT_LOG_LEVEL"
+
+// staticFieldsHook là logrus hook thêm các field cố định vào mọi log record
+type staticFieldsHook struct {
+	fields logrus.Fields
+}
+
+// Levels implements logrus.Hook - áp dụng cho tất cả levels
+func (h *staticFieldsHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements logrus.Hook - không ghi đè field đã có trong entry
+func (h *staticFieldsHook) Fire(entry *logrus.Entry) error {
+	for k, v := range h.fields {
+		if _, exists := entry.Data[k]; !exists {
+			entry.Data[k] = v
+		}
+	}
+	return nil
 }
 
 // DefaultLoggerOptions trả về cấu hình mặc định
@@ -129,10 +379,84 @@ func DefaultLoggerOptions() LoggerOptions {
 //	    JSONFormat: true,
 //	    LogLevel: "debug",     // Development: log debug, Production: no-op
 //	    FileLogLevel: "error", // File chỉ log error và panic
+//	    ServiceName: "order-service", // Field "service.name" trên mọi log record
 //	})
 func InitLogger(opts LoggerOptions) {
+	logrusLogger, err := newLogrusLogger(opts)
+	if err != nil {
+		// Convenience API: vẫn khởi tạo logger với giá trị fallback, chỉ cảnh báo ra stderr
+		// Dùng InitLoggerE nếu muốn fail fast khi cấu hình sai
+		fmt.Fprintf(os.Stderr, "goerrorkit: logger misconfiguration: %v\n", err)
+	}
+	installLogger(logrusLogger, opts)
+}
+
+// InitLoggerE giống InitLogger nhưng trả về error khi cấu hình sai
+// (không tạo được thư mục log, file log không ghi được, log level hoặc FieldMap không hợp lệ,
+// không bật output nào). Khi có error, logger hiện tại KHÔNG bị thay thế.
+//
+// Example:
+//
+//	if err := goerrorkit.InitLoggerE(opts); err != nil {
+//	    log.Fatalf("cannot init logger: %v", err) // fail fast khi thiếu log volume
+//	}
+func InitLoggerE(opts LoggerOptions) error {
+	logrusLogger, err := newLogrusLogger(opts)
+	if err != nil {
+		logrusLogger.Close()
+		return err
+	}
+	installLogger(logrusLogger, opts)
+	return nil
+}
+
+// installLogger set logger và các cấu hình đi kèm vào goerrorkit
+// LogrusLogger cũ (từ lần InitLogger trước) được đóng để không leak file handle;
+// custom logger set qua SetLogger không bị đóng
+func installLogger(logrusLogger *LogrusLogger, opts LoggerOptions) {
+	prev, _ := defaultLogger.(*LogrusLogger)
+	SetLogger(logrusLogger)
+	if prev != nil && prev != logrusLogger {
+		if err := prev.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "goerrorkit: cannot close previous logger: %v\n", err)
+		}
+	}
+	// Chỉ override cấu hình được set rõ ràng, không reset SetSampling/SetDedup/SetIncludeClientInfo
+	// mà user đã gọi trước InitLogger
+	if opts.Sampling != nil {
+		SetSampling(opts.Sampling)
+	}
+	if opts.DedupWindow > 0 {
+		SetDedup(&DedupOptions{Window: opts.DedupWindow, KeepConsole: opts.DedupKeepConsole})
+	}
+	if opts.IncludeClientInfo {
+		SetIncludeClientInfo(true)
+	}
+
+	if logrusLogger.consoleLogger != nil {
+		logrusLogger.consoleLogger.Info("✓ GoErrorKit logger initialized")
+	}
+}
+
+// newLogrusLogger tạo LogrusLogger từ options
+// Luôn trả về logger dùng được (với giá trị fallback), kèm error gom tất cả lỗi cấu hình
+func newLogrusLogger(opts LoggerOptions) (*LogrusLogger, error) {
 	var consoleLogger *logrus.Logger
-	var fileLogger *logrus.Logger
+	var errs []error
+
+	if !opts.ConsoleOutput && !opts.FileOutput && len(opts.FileOutputs) == 0 {
+		errs = append(errs, errors.New("no log output enabled (ConsoleOutput and FileOutput are both false)"))
+	}
+
+	fieldMap, err := logrusFieldMap(opts.FieldMap)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("FieldMap: %w", err))
+	}
+
+	format, err := resolveLogFormat(opts)
+	if err != nil {
+		errs = append(errs, err)
+	}
 
 	// Khởi tạo console logger
 	if opts.ConsoleOutput {
@@ -140,84 +464,159 @@ func InitLogger(opts LoggerOptions) {
 		consoleLogger.SetOutput(os.Stdout)
 
 		// Cấu hình formatter cho console
-		if opts.JSONFormat {
-			consoleLogger.SetFormatter(&logrus.JSONFormatter{
-				TimestampFormat: time.RFC3339,
-				PrettyPrint:     true,
-				FieldMap: logrus.FieldMap{
-					logrus.FieldKeyTime:  "timestamp",
-					logrus.FieldKeyLevel: "level",
-					logrus.FieldKeyMsg:   "message",
-				},
-			})
-		} else {
-			consoleLogger.SetFormatter(&logrus.TextFormatter{
-				ForceColors:     true,
-				FullTimestamp:   true,
-				TimestampFormat: "2006-01-02 15:04:05",
-			})
+		switch format {
+		case logFormatECS:
+			consoleLogger.SetFormatter(newECSFormatter(opts.TimestampFormat, !opts.ConsoleCompact))
+		case logFormatLogfmt:
+			consoleLogger.SetFormatter(newLogfmtFormatter(opts.TimestampFormat, fieldMap))
+		case logFormatJSON:
+			consoleLogger.SetFormatter(newJSONFormatter(opts.TimestampFormat, !opts.ConsoleCompact, fieldMap))
+		default:
+			if opts.PrettyConsole {
+				consoleLogger.SetFormatter(newPrettyConsoleFormatter(opts.TimestampFormat))
+			} else {
+				consoleLogger.SetFormatter(newTextFormatter(opts.TimestampFormat, fieldMap))
+			}
 		}
 
-		// Set log level cho console
-		level, err := logrus.ParseLevel(opts.LogLevel)
-		if err != nil {
-			level = logrus.WarnLevel // Default warn cho console
+		// Set log level cho console (rỗng → mặc định warn)
+		// Env GOERRORKIT_LOG_LEVEL override LogLevel (ví dụ bật debug tạm thời cho một pod)
+		logLevel := opts.LogLevel
+		if envLevel := os.Getenv(logLevelEnvVar); envLevel != "" {
+			logLevel = envLevel
+		}
+		level := logrus.WarnLevel
+		if logLevel != "" {
+			parsed, err := parseLogrusLevel(logLevel)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("LogLevel: %w", err))
+			} else {
+				level = parsed
+			}
 		}
 		consoleLogger.SetLevel(level)
 	}
 
-	// Khởi tạo file logger
-	if opts.FileOutput {
-		// Tạo thư mục logs nếu chưa có
-		if err := os.MkdirAll("logs", 0755); err != nil {
-			if consoleLogger != nil {
-				consoleLogger.Errorf("Cannot create logs directory: %v", err)
-			}
-		}
+	// Khởi tạo file logger (FilePath/FileLogLevel hoặc từng sink của FileOutputs)
+	if opts.RotateInterval < 0 {
+		errs = append(errs, fmt.Errorf("RotateInterval must not be negative, got %s", opts.RotateInterval))
+		opts.RotateInterval = 0
+	}
+	var files []*fileSinkLogger
+	for _, sink := range fileSinks(opts) {
+		f, sinkErrs := newFileSinkLogger(opts, sink, format, fieldMap)
+		errs = append(errs, sinkErrs...)
+		files = append(files, f)
+	}
 
-		fileLogger = logrus.New()
-		logFile := &lumberjack.Logger{
-			Filename:   opts.FilePath,
-			MaxSize:    opts.MaxFileSize,
-			MaxBackups: opts.MaxBackups,
-			MaxAge:     opts.MaxAge,
-			Compress:   true,
-			LocalTime:  true,
+	// Thêm field cố định (service.name) vào mọi log record
+	if opts.ServiceName != "" {
+		hook := &staticFieldsHook{fields: logrus.Fields{"service.name": opts.ServiceName}}
+		if consoleLogger != nil {
+			consoleLogger.AddHook(hook)
 		}
-		fileLogger.SetOutput(logFile)
-
-		// Cấu hình formatter cho file (luôn dùng JSON)
-		fileLogger.SetFormatter(&logrus.JSONFormatter{
-			TimestampFormat: time.RFC3339,
-			PrettyPrint:     true,
-			FieldMap: logrus.FieldMap{
-				logrus.FieldKeyTime:  "timestamp",
-				logrus.FieldKeyLevel: "level",
-				logrus.FieldKeyMsg:   "message",
-			},
-		})
-
-		// Set log level cho file
-		fileLogLevel := opts.FileLogLevel
-		if fileLogLevel == "" {
-			fileLogLevel = "error" // Default error cho file
+		for _, f := range files {
+			f.logger.AddHook(hook)
 		}
-		fileLevel, err := logrus.ParseLevel(fileLogLevel)
-		if err != nil {
-			fileLevel = logrus.ErrorLevel
-		}
-		fileLogger.SetLevel(fileLevel)
 	}
 
-	// Wrap và set vào goerrorkit
+	// Monitor logger dùng chung output/formatter/hooks với file logger nhưng không lọc level
+	for _, f := range files {
+		f.monitor = logrus.New()
+		f.monitor.SetOutput(f.logger.Out)
+		f.monitor.SetFormatter(f.logger.Formatter)
+		f.monitor.ReplaceHooks(f.logger.Hooks)
+		f.monitor.SetLevel(logrus.TraceLevel)
+	}
+
 	logrusLogger := &LogrusLogger{
 		consoleLogger: consoleLogger,
-		fileLogger:    fileLogger,
+		files:         files,
 	}
-	SetLogger(logrusLogger)
+	return logrusLogger, errors.Join(errs...)
+}
+
+// Giá trị của LoggerOptions.Format
+const (
+	logFormatJSON   = "json"
+	logFormatText   = "text"
+	logFormatECS    = "ecs"
+	logFormatLogfmt = "logfmt"
+)
+
+// resolveLogFormat trả về format log từ LoggerOptions.Format (rỗng → theo JSONFormat)
+// Format không hợp lệ trả về error và fallback theo JSONFormat
+func resolveLogFormat(opts LoggerOptions) (string, error) {
+	fallback := logFormatText
+	if opts.JSONFormat {
+		fallback = logFormatJSON
+	}
+
+	switch format := strings.ToLower(strings.TrimSpace(opts.Format)); format {
+	case "":
+		return fallback, nil
+	case logFormatJSON, logFormatText, logFormatECS, logFormatLogfmt:
+		return format, nil
+	default:
+		return fallback, fmt.Errorf("Format: unknown log format %q (supported: json, text, ecs, logfmt)", opts.Format)
+	}
+}
+
+// logrusFieldMap tạo logrus.FieldMap từ tên field mặc định (timestamp, level, message)
+// và LoggerOptions.FieldMap. Key không hỗ trợ hoặc tên rỗng trả về error (giữ tên mặc định)
+func logrusFieldMap(overrides map[string]string) (logrus.FieldMap, error) {
+	fieldMap := logrus.FieldMap{
+		logrus.FieldKeyTime:  "timestamp",
+		logrus.FieldKeyLevel: "level",
+		logrus.FieldKeyMsg:   "message",
+	}
+	renamed := make(logrus.FieldMap, len(fieldMap))
+	for key, name := range fieldMap {
+		renamed[key] = name
+	}
+
+	var errs []error
+	for name, newName := range overrides {
+		found := false
+		for key, defaultName := range fieldMap {
+			if defaultName == name {
+				found = true
+				if newName == "" {
+					errs = append(errs, fmt.Errorf("empty name for field %q", name))
+				} else {
+					renamed[key] = newName
+				}
+			}
+		}
+		if !found {
+			errs = append(errs, fmt.Errorf("unknown field %q (supported: timestamp, level, message)", name))
+		}
+	}
+	return renamed, errors.Join(errs...)
+}
 
-	if consoleLogger != nil {
-		consoleLogger.Info("✓ GoErrorKit logger initialized")
+// newTextFormatter tạo text formatter có màu cho console (timestampFormat rỗng → "2006-01-02 15:04:05")
+func newTextFormatter(timestampFormat string, fieldMap logrus.FieldMap) *logrus.TextFormatter {
+	if timestampFormat == "" {
+		timestampFormat = "2006-01-02 15:04:05"
+	}
+	return &logrus.TextFormatter{
+		ForceColors:     true,
+		FullTimestamp:   true,
+		TimestampFormat: timestampFormat,
+		FieldMap:        fieldMap,
+	}
+}
+
+// newJSONFormatter tạo JSON formatter dùng chung cho console và file
+func newJSONFormatter(timestampFormat string, prettyPrint bool, fieldMap logrus.FieldMap) *logrus.JSONFormatter {
+	if timestampFormat == "" {
+		timestampFormat = time.RFC3339
+	}
+	return &logrus.JSONFormatter{
+		TimestampFormat: timestampFormat,
+		PrettyPrint:     prettyPrint,
+		FieldMap:        fieldMap,
 	}
 }
 
@@ -229,3 +628,12 @@ func InitLogger(opts LoggerOptions) {
 func InitDefaultLogger() {
 	InitLogger(DefaultLoggerOptions())
 }
+
+// parseLogrusLevel chuẩn hóa level qua ParseLevel rồi convert sang logrus.Level
+func parseLogrusLevel(level string) (logrus.Level, error) {
+	normalized, err := ParseLevel(level)
+	if err != nil {
+		return 0, err
+	}
+	return logrus.ParseLevel(normalized)
+}
@@ -3,24 +3,28 @@
 
 package goerrorkit
 
+import "github.com/sirupsen/logrus"
+
+// debugBuild cho biết binary được build với -tags=debug
+// Debug/Trace logs hoạt động đầy đủ trong mode này
+const debugBuild = true
+
 // Debug implements Logger - CHỈ hoạt động khi build với -tags=debug
 // Logs debug level message với fields
 func (l *LogrusLogger) Debug(msg string, fields map[string]interface{}) {
-	if l.consoleLogger != nil {
+	// Kiểm tra level trước để tránh allocation WithFields khi message sẽ bị bỏ qua
+	if l.consoleLogger != nil && l.consoleLogger.IsLevelEnabled(logrus.DebugLevel) {
 		l.consoleLogger.WithFields(fields).Debug(msg)
 	}
-	if l.fileLogger != nil {
-		l.fileLogger.WithFields(fields).Debug(msg)
-	}
+	l.logFiles(logrus.DebugLevel, msg, fields)
 }
 
 // Trace implements Logger - CHỈ hoạt động khi build với -tags=debug
 // Logs trace level message với fields (chi tiết nhất, dùng cho deep debugging)
 func (l *LogrusLogger) Trace(msg string, fields map[string]interface{}) {
-	if l.consoleLogger != nil {
+	// Kiểm tra level trước để tránh allocation WithFields khi message sẽ bị bỏ qua
+	if l.consoleLogger != nil && l.consoleLogger.IsLevelEnabled(logrus.TraceLevel) {
 		l.consoleLogger.WithFields(fields).Trace(msg)
 	}
-	if l.fileLogger != nil {
-		l.fileLogger.WithFields(fields).Trace(msg)
-	}
+	l.logFiles(logrus.TraceLevel, msg, fields)
 }
@@ -0,0 +1,73 @@
+//go:build debug
+// +build debug
+
+package goerrorkit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestIsDebugBuildDebug(t *testing.T) {
+	if !IsDebugBuild() {
+		t.Fatal("IsDebugBuild() = false in a build with -tags=debug")
+	}
+}
+
+func TestDebugBuildDebugTraceRecorded(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	Debug("cache state", map[string]interface{}{"size": 3})
+	Trace("entering handler", nil)
+
+	if _, ok := mem.Find("debug", "cache state"); !ok {
+		t.Errorf("Debug not recorded: %v", mem.Entries())
+	}
+	if _, ok := mem.Find("trace", "entering handler"); !ok {
+		t.Errorf("Trace not recorded: %v", mem.Entries())
+	}
+}
+
+func TestDebugBuildLogrusLoggerRespectsLevel(t *testing.T) {
+	logger, console := newTestLogrusLogger(t, LoggerOptions{ConsoleOutput: true, LogLevel: "debug"})
+
+	logger.Debug("debug message", nil)
+	logger.Trace("trace message", nil)
+
+	if !strings.Contains(console.String(), "debug message") {
+		t.Errorf("console = %q, want debug message", console.String())
+	}
+	if strings.Contains(console.String(), "trace message") {
+		t.Errorf("console = %q, trace is below LogLevel debug", console.String())
+	}
+}
+
+func TestDebugBuildDebugLevelErrorKeepsLevel(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	LogError(NewBusinessError(400, "Cart is empty").Level("debug"), "POST /checkout")
+	LogError(NewBusinessError(400, "Coupon expired").Level("trace"), "POST /checkout")
+
+	if _, ok := mem.Find("debug", "Cart is empty"); !ok {
+		t.Errorf("debug error not logged at debug: %v", mem.Entries())
+	}
+	if _, ok := mem.Find("trace", "Coupon expired"); !ok {
+		t.Errorf("trace error not logged at trace: %v", mem.Entries())
+	}
+	if lvl := entryLevel("debug"); lvl != logrus.DebugLevel {
+		t.Errorf("entryLevel(debug) = %s, want debug", lvl)
+	}
+}
+
+func TestDebugBuildServiceNameOnDebugTrace(t *testing.T) {
+	logger, console, path := newServiceNameLogger(t)
+
+	logger.Debug("debug message", nil)
+	logger.Trace("trace message", nil)
+
+	assertServiceName(t, logger, console, path, "debug message", "trace message")
+}
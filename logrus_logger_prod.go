@@ -3,6 +3,10 @@
 
 package goerrorkit
 
+// debugBuild cho biết binary được build với -tags=debug
+// Production build: Debug/Trace là no-op nên LogError sẽ fallback sang Warn
+const debugBuild = false
+
 // Debug implements Logger - PRODUCTION MODE: No-op
 // Không làm gì cả trong production build để tối ưu performance
 // Code này sẽ được compiler optimize away hoàn toàn
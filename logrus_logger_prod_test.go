@@ -0,0 +1,61 @@
+//go:build !debug
+// +build !debug
+
+package goerrorkit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestIsDebugBuildProduction(t *testing.T) {
+	if IsDebugBuild() {
+		t.Fatal("IsDebugBuild() = true in a build without -tags=debug")
+	}
+}
+
+func TestProductionDebugTraceRecordNothing(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	Debug("cache state", map[string]interface{}{"size": 3})
+	Trace("entering handler", nil)
+
+	if entries := mem.Entries(); len(entries) != 0 {
+		t.Errorf("Debug/Trace recorded %v in production build", entries)
+	}
+}
+
+func TestProductionLogrusLoggerDebugTraceNoOp(t *testing.T) {
+	logger, console := newTestLogrusLogger(t, LoggerOptions{ConsoleOutput: true, LogLevel: "trace"})
+
+	logger.Debug("debug message", nil)
+	logger.Trace("trace message", nil)
+	if console.Len() != 0 {
+		t.Errorf("console = %q, want nothing from Debug/Trace", console.String())
+	}
+
+	logger.Info("info message", nil)
+	if !strings.Contains(console.String(), "info message") {
+		t.Errorf("console = %q, want info message", console.String())
+	}
+}
+
+func TestProductionDebugLevelErrorFallsBackToWarn(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	LogError(NewBusinessError(400, "Cart is empty").Level("debug"), "POST /checkout")
+	LogError(NewBusinessError(400, "Coupon expired").Level("trace"), "POST /checkout")
+
+	for _, msg := range []string{"Cart is empty", "Coupon expired"} {
+		if _, ok := mem.Find("warn", msg); !ok {
+			t.Errorf("%q not logged at warn: %v", msg, mem.Entries())
+		}
+	}
+	if lvl := entryLevel("debug"); lvl != logrus.WarnLevel {
+		t.Errorf("entryLevel(debug) = %s, want warning", lvl)
+	}
+}
@@ -0,0 +1,542 @@
+package goerrorkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestLogrusLogger tạo LogrusLogger ghi file vào thư mục tạm, console ghi vào buffer trả về
+func newTestLogrusLogger(t *testing.T, opts LoggerOptions) (*LogrusLogger, *bytes.Buffer) {
+	t.Helper()
+	logger, err := newLogrusLogger(opts)
+	if err != nil {
+		t.Fatalf("newLogrusLogger: %v", err)
+	}
+	t.Cleanup(func() { logger.Close() })
+
+	console := &bytes.Buffer{}
+	if logger.consoleLogger != nil {
+		logger.consoleLogger.SetOutput(console)
+	}
+	return logger, console
+}
+
+func TestFileLogCompactJSONWithPrettyConsole(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.log")
+	logger, console := newTestLogrusLogger(t, LoggerOptions{
+		ConsoleOutput: true,
+		FileOutput:    true,
+		FilePath:      path,
+		JSONFormat:    true,
+		LogLevel:      "warn",
+		FileLogLevel:  "error",
+	})
+
+	fields := map[string]interface{}{
+		"error_type": "SYSTEM",
+		"data":       map[string]interface{}{"order_id": 42, "items": []string{"a", "b"}},
+	}
+	logger.Error("Internal server error", fields)
+	logger.Error("Second error", fields)
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("file has %d lines, want one JSON object per line:\n%s", len(lines), content)
+	}
+	for _, line := range lines {
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Errorf("line is not a JSON object: %q: %v", line, err)
+		}
+	}
+
+	if strings.Count(console.String(), "\n") <= 2 {
+		t.Errorf("console output is not pretty printed:\n%s", console.String())
+	}
+}
+
+func TestConsolePrettyByDefault(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   LoggerOptions
+		pretty bool
+	}{
+		{"literal json", LoggerOptions{ConsoleOutput: true, JSONFormat: true, LogLevel: "error"}, true},
+		{"literal ecs", LoggerOptions{ConsoleOutput: true, Format: "ecs", LogLevel: "error"}, true},
+		{"compact json", LoggerOptions{ConsoleOutput: true, JSONFormat: true, ConsoleCompact: true, LogLevel: "error"}, false},
+		{"compact ecs", LoggerOptions{ConsoleOutput: true, Format: "ecs", ConsoleCompact: true, LogLevel: "error"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, console := newTestLogrusLogger(t, tt.opts)
+			logger.Error("Internal server error", map[string]interface{}{"error_type": "SYSTEM"})
+
+			lines := strings.Count(console.String(), "\n")
+			if tt.pretty && lines <= 2 {
+				t.Errorf("console = %q, want pretty printed JSON", console.String())
+			}
+			if !tt.pretty && lines != 1 {
+				t.Errorf("console = %q, want one line", console.String())
+			}
+		})
+	}
+}
+
+func TestFilePrettyPrint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.log")
+	logger, _ := newTestLogrusLogger(t, LoggerOptions{
+		FileOutput:      true,
+		FilePath:        path,
+		JSONFormat:      true,
+		FilePrettyPrint: true,
+	})
+
+	logger.Error("Internal server error", map[string]interface{}{"error_type": "SYSTEM"})
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(string(content), "\n") < 3 {
+		t.Errorf("FilePrettyPrint did not indent file JSON:\n%s", content)
+	}
+}
+
+// newServiceNameLogger tạo logger JSON (console + file) với ServiceName và mọi level được bật
+func newServiceNameLogger(t *testing.T) (*LogrusLogger, *bytes.Buffer, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "errors.log")
+	logger, console := newTestLogrusLogger(t, LoggerOptions{
+		ConsoleOutput: true,
+		FileOutput:    true,
+		FilePath:      path,
+		JSONFormat:    true,
+		LogLevel:      "trace",
+		FileLogLevel:  "trace",
+		ServiceName:   "order-service",
+	})
+	return logger, console, path
+}
+
+// jsonRecords parse chuỗi record JSON (compact hoặc pretty print) thành message → record
+func jsonRecords(t *testing.T, output string) map[string]map[string]interface{} {
+	t.Helper()
+	records := make(map[string]map[string]interface{})
+	dec := json.NewDecoder(strings.NewReader(output))
+	for dec.More() {
+		var record map[string]interface{}
+		if err := dec.Decode(&record); err != nil {
+			t.Fatalf("output is not a sequence of JSON objects: %q: %v", output, err)
+		}
+		msg, _ := record["message"].(string)
+		records[msg] = record
+	}
+	return records
+}
+
+// assertServiceName kiểm tra mọi message trong msgs có service.name trên cả console và file
+func assertServiceName(t *testing.T, logger *LogrusLogger, console *bytes.Buffer, path string, msgs ...string) {
+	t.Helper()
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for output, records := range map[string]map[string]map[string]interface{}{
+		"console": jsonRecords(t, console.String()),
+		"file":    jsonRecords(t, string(content)),
+	} {
+		for _, msg := range msgs {
+			record, ok := records[msg]
+			if !ok {
+				t.Errorf("%s: %q not logged", output, msg)
+				continue
+			}
+			if record["service.name"] != "order-service" {
+				t.Errorf("%s: %q service.name = %v", output, msg, record["service.name"])
+			}
+		}
+	}
+}
+
+func TestServiceNameOnAllLevels(t *testing.T) {
+	logger, console, path := newServiceNameLogger(t)
+
+	logger.Info("info message", nil)
+	logger.Warn("warn message", map[string]interface{}{"error_type": "BUSINESS"})
+	logger.Error("error message", map[string]interface{}{"error_type": "SYSTEM"})
+	logger.Panic("panic message", map[string]interface{}{"error_type": "PANIC"})
+
+	assertServiceName(t, logger, console, path, "info message", "warn message", "error message", "panic message")
+}
+
+func TestServiceNameDoesNotOverrideField(t *testing.T) {
+	logger, console := newTestLogrusLogger(t, LoggerOptions{
+		ConsoleOutput: true,
+		JSONFormat:    true,
+		ServiceName:   "order-service",
+	})
+
+	logger.Error("proxied", map[string]interface{}{"service.name": "payment-service"})
+
+	if got := jsonRecords(t, console.String())["proxied"]["service.name"]; got != "payment-service" {
+		t.Errorf("service.name = %v, want field from entry kept", got)
+	}
+}
+
+func TestInitLoggerCreatesLogDirFromFilePath(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "var", "log", "app")
+	InitLogger(LoggerOptions{FileOutput: true, FilePath: filepath.Join(dir, "errors.log"), DirPerm: 0700})
+	defer SetLogger(nil)
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		t.Fatalf("log directory not created: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0700 {
+		t.Errorf("log directory perm = %o, want 0700", perm)
+	}
+}
+
+func TestInitLoggerEReportsMisconfiguration(t *testing.T) {
+	dir := t.TempDir()
+	// File thường chặn việc tạo thư mục log cùng tên
+	blocked := filepath.Join(dir, "blocked")
+	if err := os.WriteFile(blocked, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		opts LoggerOptions
+		want string
+	}{
+		{"no output", LoggerOptions{}, "no log output enabled"},
+		{"invalid LogLevel", LoggerOptions{ConsoleOutput: true, LogLevel: "verbose"}, "LogLevel"},
+		{"invalid FileLogLevel", LoggerOptions{FileOutput: true, FilePath: filepath.Join(dir, "errors.log"), FileLogLevel: "loud"}, "FileLogLevel"},
+		{"empty FilePath", LoggerOptions{FileOutput: true}, "FilePath is empty"},
+		{"log directory", LoggerOptions{FileOutput: true, FilePath: filepath.Join(blocked, "errors.log")}, "cannot create log directory"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mem := UseMemoryLogger()
+			defer SetLogger(nil)
+
+			err := InitLoggerE(tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("InitLoggerE() = %v, want error containing %q", err, tt.want)
+			}
+			if GetLogger() != mem {
+				t.Error("logger replaced despite configuration error")
+			}
+		})
+	}
+}
+
+func TestInitLoggerEInstallsLogger(t *testing.T) {
+	defer SetLogger(nil)
+
+	opts := LoggerOptions{ConsoleOutput: true, FileOutput: true, FilePath: filepath.Join(t.TempDir(), "errors.log")}
+	if err := InitLoggerE(opts); err != nil {
+		t.Fatalf("InitLoggerE() = %v", err)
+	}
+	if _, ok := GetLogger().(*LogrusLogger); !ok {
+		t.Errorf("logger = %T, want *LogrusLogger", GetLogger())
+	}
+}
+
+func TestInitLoggerEAcceptsLevelAliases(t *testing.T) {
+	defer SetLogger(nil)
+
+	opts := LoggerOptions{
+		ConsoleOutput: true,
+		FileOutput:    true,
+		FilePath:      filepath.Join(t.TempDir(), "errors.log"),
+		LogLevel:      "WARNING",
+		FileLogLevel:  "Err",
+	}
+	if err := InitLoggerE(opts); err != nil {
+		t.Fatalf("InitLoggerE() = %v, want aliases accepted", err)
+	}
+	if console, file := GetLogger().(*LogrusLogger).Levels(); console != "warn" || file != "error" {
+		t.Errorf("levels = %s/%s, want warn/error", console, file)
+	}
+}
+
+func TestMonitorOnlyReachesFileBelowFileLogLevel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.log")
+	logger, console := newTestLogrusLogger(t, LoggerOptions{
+		ConsoleOutput: true,
+		FileOutput:    true,
+		FilePath:      path,
+		JSONFormat:    true,
+		LogLevel:      "error",
+		FileLogLevel:  "error",
+	})
+	SetLogger(logger)
+	defer SetLogger(nil)
+
+	ctx := newTestContext("GET", "/quotes")
+	LogAndRespond(ctx, NewSystemError(errors.New("upstream timeout")).MonitorOnly(), "GET /quotes")
+	LogError(NewSystemError(errors.New("cache miss storm")).Level("info").MonitorOnly(), "GET /quotes")
+	LogError(NewSystemError(errors.New("plain warning")).Level("warn"), "GET /quotes")
+
+	if ctx.status != 500 {
+		t.Errorf("status = %d, want code kept at 500", ctx.status)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var levels []string
+	for _, line := range strings.Split(strings.TrimRight(string(content), "\n"), "\n") {
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("line is not a JSON object: %q", line)
+		}
+		if record["monitor_only"] != true {
+			t.Errorf("record without monitor_only reached file below FileLogLevel: %v", record)
+		}
+		levels = append(levels, record["level"].(string))
+	}
+	if strings.Join(levels, ",") != "warning,info" {
+		t.Errorf("file levels = %v, want warning,info", levels)
+	}
+	// Console vẫn tuân theo LogLevel: không page
+	if console.Len() != 0 {
+		t.Errorf("console = %q, want nothing below LogLevel error", console.String())
+	}
+}
+
+func TestRuntimeLogLevels(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.log")
+	logger, console := newTestLogrusLogger(t, LoggerOptions{
+		ConsoleOutput: true,
+		FileOutput:    true,
+		FilePath:      path,
+		JSONFormat:    true,
+		LogLevel:      "error",
+		FileLogLevel:  "error",
+	})
+	SetLogger(logger)
+	defer SetLogger(nil)
+
+	Warn("before change", nil)
+	if err := SetConsoleLogLevel("warn"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetFileLogLevel("info"); err != nil {
+		t.Fatal(err)
+	}
+	if c, f := GetLogLevels(); c != "warn" || f != "info" {
+		t.Errorf("GetLogLevels() = %q, %q, want warn, info", c, f)
+	}
+	Warn("after change", nil)
+	Info("info after change", nil)
+
+	// Level không hợp lệ: trả về error, level giữ nguyên
+	if err := SetConsoleLogLevel("verbose"); err == nil {
+		t.Error("SetConsoleLogLevel(verbose) = nil, want error")
+	}
+	if c, _ := GetLogLevels(); c != "warn" {
+		t.Errorf("console level = %q after invalid change, want warn", c)
+	}
+
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+	consoleRecords := jsonRecords(t, console.String())
+	if _, ok := consoleRecords["before change"]; ok {
+		t.Error("console logged warn while LogLevel was error")
+	}
+	if _, ok := consoleRecords["after change"]; !ok {
+		t.Errorf("console missing warn after SetConsoleLogLevel: %q", console.String())
+	}
+	if _, ok := consoleRecords["info after change"]; ok {
+		t.Error("console logged info below its warn level")
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileRecords := jsonRecords(t, string(content))
+	if _, ok := fileRecords["info after change"]; !ok || len(fileRecords) != 2 {
+		t.Errorf("file records = %v, want warn and info after SetFileLogLevel", fileRecords)
+	}
+}
+
+func TestRuntimeLogLevelsUnsupportedLogger(t *testing.T) {
+	UseMemoryLogger()
+	defer SetLogger(nil)
+
+	if err := SetConsoleLogLevel("info"); !errors.Is(err, errLevelAdjustUnsupported) {
+		t.Errorf("SetConsoleLogLevel = %v, want errLevelAdjustUnsupported", err)
+	}
+	if err := SetFileLogLevel("info"); !errors.Is(err, errLevelAdjustUnsupported) {
+		t.Errorf("SetFileLogLevel = %v, want errLevelAdjustUnsupported", err)
+	}
+	if c, f := GetLogLevels(); c != "" || f != "" {
+		t.Errorf("GetLogLevels() = %q, %q, want empty", c, f)
+	}
+}
+
+func TestLogLevelEnvOverride(t *testing.T) {
+	t.Setenv(logLevelEnvVar, "info")
+	logger, _ := newTestLogrusLogger(t, LoggerOptions{ConsoleOutput: true, LogLevel: "error"})
+
+	if c, _ := logger.Levels(); c != "info" {
+		t.Errorf("console level = %q, want %s from env", c, "info")
+	}
+}
+
+func TestRuntimeLogLevelsConcurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.log")
+	logger, _ := newTestLogrusLogger(t, LoggerOptions{
+		ConsoleOutput: true,
+		FileOutput:    true,
+		FilePath:      path,
+		JSONFormat:    true,
+	})
+	SetLogger(logger)
+	defer SetLogger(nil)
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					LogError(NewValidationError("Invalid email", nil).Level("warn"), "POST /signup")
+					Info("tick", nil)
+				}
+			}
+		}()
+	}
+	levels := []string{"info", "warn", "error"}
+	for i := 0; i < 200; i++ {
+		_ = SetConsoleLogLevel(levels[i%len(levels)])
+		_ = SetFileLogLevel(levels[(i+1)%len(levels)])
+		_, _ = GetLogLevels()
+	}
+	close(stop)
+	wg.Wait()
+}
+
+func TestFileLogFieldMapAndTimestampFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.log")
+	logger, console := newTestLogrusLogger(t, LoggerOptions{
+		ConsoleOutput:   true,
+		FileOutput:      true,
+		FilePath:        path,
+		JSONFormat:      true,
+		ConsoleCompact:  true,
+		LogLevel:        "error",
+		FileLogLevel:    "error",
+		TimestampFormat: "2006-01-02",
+		FieldMap:        map[string]string{"timestamp": "ts", "message": "msg"},
+	})
+
+	before := time.Now().Format("2006-01-02")
+	logger.Error("Internal server error\nsecond line", map[string]interface{}{"error_type": "SYSTEM"})
+	logger.Error("Upstream failed", map[string]interface{}{"data": map[string]interface{}{"items": []string{"a", "b"}}})
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	after := time.Now().Format("2006-01-02")
+	lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("file has %d lines, want one record per line:\n%s", len(lines), content)
+	}
+	for i, line := range lines {
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("line %d is not a JSON object: %q: %v", i, line, err)
+		}
+		if (record["ts"] != before && record["ts"] != after) || record["level"] != "error" {
+			t.Errorf("line %d: ts = %v, level = %v, want renamed ts with format 2006-01-02", i, record["ts"], record["level"])
+		}
+		if _, ok := record["timestamp"]; ok {
+			t.Errorf("line %d still has timestamp: %s", i, line)
+		}
+		if _, ok := record["message"]; ok {
+			t.Errorf("line %d still has message: %s", i, line)
+		}
+	}
+	if !strings.Contains(lines[0], `"msg":"Internal server error\nsecond line"`) {
+		t.Errorf("first line = %s, want msg with escaped newline", lines[0])
+	}
+
+	// Console dùng cùng FieldMap (compact JSON vì bật ConsoleCompact)
+	consoleLines := strings.Split(strings.TrimRight(console.String(), "\n"), "\n")
+	if len(consoleLines) != 2 || !strings.Contains(consoleLines[1], `"msg":"Upstream failed"`) {
+		t.Errorf("console output = %q, want compact records with msg", console.String())
+	}
+}
+
+func TestTextConsoleTimestampFormat(t *testing.T) {
+	logger, console := newTestLogrusLogger(t, LoggerOptions{
+		ConsoleOutput:   true,
+		LogLevel:        "error",
+		TimestampFormat: "2006/01/02",
+	})
+	logger.Error("Internal server error", nil)
+
+	if want := time.Now().Format("2006/01/02"); !strings.Contains(console.String(), want) {
+		t.Errorf("console = %q, want timestamp %s", console.String(), want)
+	}
+}
+
+func TestFieldMapInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		fieldMap map[string]string
+		want     string
+	}{
+		{"unknown field", map[string]string{"time": "ts"}, `unknown field "time"`},
+		{"empty name", map[string]string{"message": ""}, `empty name for field "message"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, err := newLogrusLogger(LoggerOptions{ConsoleOutput: true, JSONFormat: true, FieldMap: tt.fieldMap})
+			if logger != nil {
+				defer logger.Close()
+			}
+			if err == nil || !strings.Contains(err.Error(), "FieldMap") || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want FieldMap error containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package goerrorkit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrorMapper convert một error thường sang AppError
+// Trả về (appErr, true) nếu mapper xử lý error này, (nil, false) để nhường cho mapper tiếp theo
+type ErrorMapper func(err error) (*AppError, bool)
+
+var (
+	errorMappersMu sync.RWMutex
+	errorMappers   []ErrorMapper // Mapper do user đăng ký, theo thứ tự đăng ký
+	builtinMappers = []ErrorMapper{
+		mapSentinel(context.DeadlineExceeded, func(err error) *AppError {
+			return (&AppError{Type: ExternalError, Code: 504, Message: "Request timed out"}).Level("warn")
+		}),
+		mapSentinel(sql.ErrNoRows, func(err error) *AppError {
+			return &AppError{Type: BusinessError, Code: 404, Message: "Resource not found"}
+		}),
+	}
+)
+
+// RegisterErrorMapping đăng ký convert cho error match target theo errors.Is (hỗ trợ error bị wrap)
+//
+// Thứ tự ưu tiên trong ConvertToAppError:
+//  1. Error đã là *AppError (hoặc wrap *AppError) → giữ nguyên
+//  2. Mapper do user đăng ký (RegisterErrorMapping/RegisterErrorMapper), theo THỨ TỰ ĐĂNG KÝ -
+//     mapper đầu tiên match được dùng, nên đăng ký mapping cụ thể trước mapping tổng quát
+//  3. Mapping built-in: context.DeadlineExceeded → 504 ExternalError (warn), sql.ErrNoRows → 404 BusinessError
+//     (client hủy request → 499 chỉ được map khi biết trạng thái request, xem ConvertToAppErrorCtx)
+//  4. Fallback: 500 SystemError
+//
+// Vì mapper của user chạy trước built-in, có thể override mapping built-in bằng cách đăng ký cùng target.
+//
+// Example:
+//
+//	goerrorkit.RegisterErrorMapping(os.ErrNotExist, func(err error) *goerrorkit.AppError {
+//	    return goerrorkit.NewBusinessError(404, "File not found")
+//	})
+func RegisterErrorMapping(target error, convert func(err error) *AppError) {
+	RegisterErrorMapper(mapSentinel(target, convert))
+}
+
+// RegisterErrorMapper đăng ký mapper tùy ý (ví dụ dựa trên errors.As với kiểu error của driver)
+// Xem RegisterErrorMapping về thứ tự ưu tiên
+//
+// Example:
+//
+//	goerrorkit.RegisterErrorMapper(func(err error) (*goerrorkit.AppError, bool) {
+//	    var pgErr *pgconn.PgError
+//	    if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+//	        return goerrorkit.NewBusinessError(409, "Already exists"), true
+//	    }
+//	    return nil, false
+//	})
+func RegisterErrorMapper(mapper ErrorMapper) {
+	if mapper == nil {
+		return
+	}
+	errorMappersMu.Lock()
+	errorMappers = append(errorMappers, mapper)
+	errorMappersMu.Unlock()
+}
+
+// ResetErrorMappings xóa toàn bộ mapper do user đăng ký (mapping built-in vẫn giữ)
+func ResetErrorMappings() {
+	errorMappersMu.Lock()
+	errorMappers = nil
+	errorMappersMu.Unlock()
+}
+
+// mapSentinel tạo ErrorMapper match target theo errors.Is
+func mapSentinel(target error, convert func(err error) *AppError) ErrorMapper {
+	return func(err error) (*AppError, bool) {
+		if !errors.Is(err, target) {
+			return nil, false
+		}
+		appErr := convert(err)
+		return appErr, appErr != nil
+	}
+}
+
+// mapRegisteredError chạy mapper của user rồi đến built-in, trả về AppError đầu tiên match
+// Cause, RequestID và CreatedAt được điền nếu mapper không set
+func mapRegisteredError(err error, requestID string) (*AppError, bool) {
+	errorMappersMu.RLock()
+	mappers := errorMappers
+	errorMappersMu.RUnlock()
+
+	for _, list := range [][]ErrorMapper{mappers, builtinMappers} {
+		for _, mapper := range list {
+			appErr, ok := mapper(err)
+			if !ok || appErr == nil {
+				continue
+			}
+			if appErr.Cause == nil {
+				appErr.Cause = err
+			}
+			if appErr.CreatedAt.IsZero() {
+				appErr.CreatedAt = time.Now()
+			}
+			appErr.RequestID = requestID
+			return appErr, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,96 @@
+package goerrorkit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"testing"
+)
+
+func TestConvertToAppErrorBuiltinMappings(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		errType ErrorType
+		code    int
+		level   string
+	}{
+		{"DeadlineExceeded", fmt.Errorf("call inventory: %w", context.DeadlineExceeded), ExternalError, 504, "warn"},
+		{"ErrNoRows", fmt.Errorf("load order 42: %w", fmt.Errorf("scan: %w", sql.ErrNoRows)), BusinessError, 404, "error"},
+		{"unmapped", fmt.Errorf("open: %w", os.ErrNotExist), SystemError, 500, "error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appErr := ConvertToAppError(tt.err, "req-1")
+			if appErr.Type != tt.errType || appErr.Code != tt.code || appErr.GetLogLevel() != tt.level {
+				t.Errorf("got %s %d (%s), want %s %d (%s)", appErr.Type, appErr.Code, appErr.GetLogLevel(), tt.errType, tt.code, tt.level)
+			}
+			if appErr.Cause != tt.err || appErr.RequestID != "req-1" || appErr.CreatedAt.IsZero() {
+				t.Errorf("cause = %v, request_id = %q, created_at = %v", appErr.Cause, appErr.RequestID, appErr.CreatedAt)
+			}
+		})
+	}
+}
+
+func TestRegisterErrorMappingOrder(t *testing.T) {
+	defer ResetErrorMappings()
+
+	RegisterErrorMapping(os.ErrNotExist, func(err error) *AppError {
+		return NewBusinessError(404, "File not found")
+	})
+	// Đăng ký sau cùng target: không bao giờ được dùng (mapper đầu tiên match thắng)
+	RegisterErrorMapping(os.ErrNotExist, func(err error) *AppError {
+		return NewBusinessError(410, "File gone")
+	})
+	// Cùng target với built-in: mapper của user chạy trước nên override được
+	RegisterErrorMapping(sql.ErrNoRows, func(err error) *AppError {
+		return NewBusinessError(204, "No content")
+	})
+
+	wrapped := fmt.Errorf("read config: %w", &fs.PathError{Op: "open", Path: "app.yaml", Err: os.ErrNotExist})
+	if appErr := ConvertToAppError(wrapped, "req-1"); appErr.Code != 404 || appErr.Message != "File not found" {
+		t.Errorf("os.ErrNotExist → %d %q, want first registered mapping", appErr.Code, appErr.Message)
+	}
+	if appErr := ConvertToAppError(fmt.Errorf("q: %w", sql.ErrNoRows), "req-1"); appErr.Code != 204 {
+		t.Errorf("sql.ErrNoRows → %d, want user mapping to override built-in", appErr.Code)
+	}
+
+	ResetErrorMappings()
+	if appErr := ConvertToAppError(wrapped, "req-1"); appErr.Code != 500 {
+		t.Errorf("after reset: os.ErrNotExist → %d, want 500", appErr.Code)
+	}
+	if appErr := ConvertToAppError(sql.ErrNoRows, "req-1"); appErr.Code != 404 {
+		t.Errorf("after reset: sql.ErrNoRows → %d, want built-in 404", appErr.Code)
+	}
+}
+
+type quotaError struct{ limit int }
+
+func (e *quotaError) Error() string { return fmt.Sprintf("quota %d exceeded", e.limit) }
+
+func TestRegisterErrorMapper(t *testing.T) {
+	defer ResetErrorMappings()
+
+	RegisterErrorMapper(nil) // bị bỏ qua
+	// Mapper trả về (nil, true) được coi như không match
+	RegisterErrorMapper(func(err error) (*AppError, bool) { return nil, true })
+	RegisterErrorMapper(func(err error) (*AppError, bool) {
+		var qe *quotaError
+		if errors.As(err, &qe) {
+			return NewBusinessError(429, fmt.Sprintf("Quota of %d reached", qe.limit)), true
+		}
+		return nil, false
+	})
+
+	appErr := ConvertToAppError(fmt.Errorf("upload: %w", &quotaError{limit: 100}), "req-9")
+	if appErr.Code != 429 || appErr.Message != "Quota of 100 reached" || appErr.RequestID != "req-9" {
+		t.Errorf("appErr = %d %q (request %q)", appErr.Code, appErr.Message, appErr.RequestID)
+	}
+	var qe *quotaError
+	if !errors.As(appErr, &qe) {
+		t.Error("mapped error does not unwrap to the original cause")
+	}
+}
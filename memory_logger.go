@@ -0,0 +1,119 @@
+package goerrorkit
+
+import (
+	"strings"
+	"sync"
+)
+
+// LogEntry là một log record được MemoryLogger lưu lại
+type LogEntry struct {
+	Level   string                 // trace, debug, info, warn, error, panic
+	Message string                 // Log message
+	Fields  map[string]interface{} // Log fields (error_type, path, data, ...)
+}
+
+// MemoryLogger implement Logger bằng cách lưu log entries trong memory
+// Dùng cho test để assert những gì đã được log mà không cần file hay stdout. An toàn khi dùng đồng thời
+//
+// Example:
+//
+//	func TestCreateOrder(t *testing.T) {
+//	    logs := goerrorkit.NewMemoryLogger()
+//	    goerrorkit.SetLogger(logs)
+//
+//	    // ... gọi handler ...
+//
+//	    if _, ok := logs.Find("error", "Payment failed"); !ok {
+//	        t.Fatalf("expected payment error to be logged, got %v", logs.Entries())
+//	    }
+//	}
+type MemoryLogger struct {
+	mu      sync.Mutex
+	entries []LogEntry
+}
+
+// NewMemoryLogger tạo MemoryLogger rỗng
+func NewMemoryLogger() *MemoryLogger {
+	return &MemoryLogger{}
+}
+
+// UseMemoryLogger tạo MemoryLogger, set làm logger mặc định và trả về để assert
+//
+// Example:
+//
+//	logs := goerrorkit.UseMemoryLogger()
+func UseMemoryLogger() *MemoryLogger {
+	l := NewMemoryLogger()
+	SetLogger(l)
+	return l
+}
+
+// record lưu một entry (copy fields để caller sửa map sau đó không ảnh hưởng)
+func (l *MemoryLogger) record(level, msg string, fields map[string]interface{}) {
+	copied := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		copied[k] = v
+	}
+
+	l.mu.Lock()
+	l.entries = append(l.entries, LogEntry{Level: level, Message: msg, Fields: copied})
+	l.mu.Unlock()
+}
+
+// Entries trả về bản copy của các entry đã log theo thứ tự
+func (l *MemoryLogger) Entries() []LogEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := make([]LogEntry, len(l.entries))
+	copy(out, l.entries)
+	return out
+}
+
+// Reset xóa toàn bộ entry đã lưu
+func (l *MemoryLogger) Reset() {
+	l.mu.Lock()
+	l.entries = nil
+	l.mu.Unlock()
+}
+
+// Find trả về entry đầu tiên có level (rỗng = mọi level) và message chứa substring
+func (l *MemoryLogger) Find(level, substring string) (LogEntry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, e := range l.entries {
+		if (level == "" || e.Level == level) && strings.Contains(e.Message, substring) {
+			return e, true
+		}
+	}
+	return LogEntry{}, false
+}
+
+// Error implements Logger
+func (l *MemoryLogger) Error(msg string, fields map[string]interface{}) {
+	l.record("error", msg, fields)
+}
+
+// Info implements Logger
+func (l *MemoryLogger) Info(msg string, fields map[string]interface{}) {
+	l.record("info", msg, fields)
+}
+
+// Debug implements Logger
+func (l *MemoryLogger) Debug(msg string, fields map[string]interface{}) {
+	l.record("debug", msg, fields)
+}
+
+// Trace implements Logger
+func (l *MemoryLogger) Trace(msg string, fields map[string]interface{}) {
+	l.record("trace", msg, fields)
+}
+
+// Warn implements Logger
+func (l *MemoryLogger) Warn(msg string, fields map[string]interface{}) {
+	l.record("warn", msg, fields)
+}
+
+// Panic implements Logger
+func (l *MemoryLogger) Panic(msg string, fields map[string]interface{}) {
+	l.record("panic", msg, fields)
+}
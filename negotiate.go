@@ -0,0 +1,57 @@
+package goerrorkit
+
+import (
+	"strconv"
+	"strings"
+)
+
+// acceptQuality trả về q-value của mediaType trong Accept header
+// exact: chỉ tính khi mediaType được liệt kê tường minh; ngược lại tính cả "type/*" và "*/*"
+func acceptQuality(accept, mediaType string, exact bool) float64 {
+	mainType := strings.SplitN(mediaType, "/", 2)[0]
+	best := 0.0
+	bestSpecificity := -1
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		candidate := strings.ToLower(strings.TrimSpace(params[0]))
+
+		specificity := -1
+		switch {
+		case candidate == mediaType:
+			specificity = 2
+		case !exact && candidate == mainType+"/*":
+			specificity = 1
+		case !exact && candidate == "*/*":
+			specificity = 0
+		}
+		if specificity < 0 || specificity < bestSpecificity {
+			continue
+		}
+
+		best, bestSpecificity = parseQuality(params[1:]), specificity
+	}
+	return best
+}
+
+// parseQuality lấy q-value từ các parameter của một phần tử Accept/Accept-Language (mặc định 1)
+func parseQuality(params []string) float64 {
+	for _, p := range params {
+		p = strings.TrimSpace(p)
+		if strings.HasPrefix(p, "q=") {
+			if v, err := strconv.ParseFloat(p[2:], 64); err == nil {
+				return v
+			}
+		}
+	}
+	return 1.0
+}
+
+// prefersMediaType kiểm tra client có liệt kê tường minh mediaType với độ ưu tiên cao hơn JSON không
+// Client không gửi Accept hoặc chỉ gửi "*/*" (curl, API client) luôn nhận JSON
+func prefersMediaType(accept, mediaType string) bool {
+	if accept == "" {
+		return false
+	}
+	q := acceptQuality(accept, mediaType, true)
+	return q > 0 && q > acceptQuality(accept, "application/json", false)
+}
@@ -0,0 +1,48 @@
+package goerrorkit
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// redactedValue là giá trị thay thế cho dữ liệu nhạy cảm đã bị ẩn
+const redactedValue = "[REDACTED]"
+
+// sqlStringLiteral match string literal trong câu SQL (hỗ trợ escape bằng hai dấu nháy đơn)
+var sqlStringLiteral = regexp.MustCompile(`'(?:[^']|'')*'`)
+
+// WithQuery gắn câu SQL (đã redact) vào error để debug database errors
+// Cấu trúc query và placeholders ($1, ?, :name) được giữ nguyên, còn giá trị bind
+// và string literal inline bị ẩn để không lộ PII
+// Query được lưu trong Details["query"], args trong Details["query_args"]
+//
+// Example:
+//
+//	rows, err := db.Query("SELECT * FROM users WHERE email = $1 AND status = $2", email, status)
+//	if err != nil {
+//	    return goerrorkit.WrapWithMessage(err, "Failed to load user").
+//	        WithQuery("SELECT * FROM users WHERE email = $1 AND status = $2", email, status)
+//	}
+//	// Details: {"query": "SELECT * FROM users WHERE email = $1 AND status = $2",
+//	//           "query_args": ["[REDACTED string]", "[REDACTED int]"]}
+func (e *AppError) WithQuery(query string, args ...interface{}) *AppError {
+	if e.Details == nil {
+		e.Details = make(map[string]interface{})
+	}
+
+	e.Details["query"] = sqlStringLiteral.ReplaceAllString(query, "'"+redactedValue+"'")
+
+	if len(args) > 0 {
+		redactedArgs := make([]string, len(args))
+		for i, arg := range args {
+			if arg == nil {
+				redactedArgs[i] = "NULL"
+				continue
+			}
+			redactedArgs[i] = fmt.Sprintf("[REDACTED %T]", arg)
+		}
+		e.Details["query_args"] = redactedArgs
+	}
+
+	return e
+}
@@ -0,0 +1,62 @@
+package goerrorkit
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWithQueryRedactsArgs(t *testing.T) {
+	const query = "SELECT * FROM users WHERE email = $1 AND status = $2 AND deleted_at IS NULL"
+	appErr := NewSystemError(errors.New("timeout")).WithQuery(query, "an@example.com", 3, nil)
+
+	if got := appErr.Details["query"]; got != query {
+		t.Errorf("query = %v, want structure unchanged", got)
+	}
+	want := []string{"[REDACTED string]", "[REDACTED int]", "NULL"}
+	if got := appErr.Details["query_args"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("query_args = %v, want %v", got, want)
+	}
+}
+
+func TestWithQueryRedactsInlineLiterals(t *testing.T) {
+	tests := map[string]string{
+		"SELECT id FROM users WHERE email = 'an@example.com' AND id = ?": "SELECT id FROM users WHERE email = '[REDACTED]' AND id = ?",
+		"UPDATE notes SET body = 'it''s secret' WHERE id = :id":          "UPDATE notes SET body = '[REDACTED]' WHERE id = :id",
+		"INSERT INTO t (a, b) VALUES ('x', 'y')":                         "INSERT INTO t (a, b) VALUES ('[REDACTED]', '[REDACTED]')",
+	}
+	for query, want := range tests {
+		appErr := NewSystemError(nil).WithQuery(query)
+		if got := appErr.Details["query"]; got != want {
+			t.Errorf("WithQuery(%q) = %v, want %s", query, got, want)
+		}
+		if _, ok := appErr.Details["query_args"]; ok {
+			t.Errorf("WithQuery(%q) without args set query_args", query)
+		}
+	}
+}
+
+func TestWithQueryDoesNotLeakArgsToLog(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	appErr := WrapDBError(errors.New("deadlock detected")).
+		WithQuery("UPDATE accounts SET balance = $1 WHERE iban = $2", 1500, "VN12-3456")
+	LogError(appErr, "POST /transfers")
+
+	entries := mem.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("entries = %v, want 1", entries)
+	}
+	logged := fmt.Sprint(entries[0].Fields)
+	if !strings.Contains(logged, "UPDATE accounts SET balance = $1 WHERE iban = $2") {
+		t.Errorf("query missing from log fields: %s", logged)
+	}
+	for _, secret := range []string{"1500", "VN12-3456"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("bound value %q leaked to log: %s", secret, logged)
+		}
+	}
+}
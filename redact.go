@@ -0,0 +1,352 @@
+package goerrorkit
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// defaultRedactKeys là các key mặc định bị che khi log
+// (so khớp không phân biệt hoa thường, theo từng từ của key - xem SetRedactor)
+var defaultRedactKeys = []string{"password", "token", "authorization", "card", "secret"}
+
+// RedactFunc là custom redaction logic
+// Trả về (giá trị thay thế, true) nếu cần che, hoặc (_, false) để giữ nguyên
+type RedactFunc func(key string, value interface{}) (interface{}, bool)
+
+// redactor chứa cấu hình redaction hiện tại
+type redactor struct {
+	keys [][]string // key nhạy cảm đã tách thành các từ (xem keySegments)
+	mask string
+	fn   RedactFunc
+}
+
+var (
+	redactorMu      sync.RWMutex
+	currentRedactor = redactor{
+		keys: segmentKeys(defaultRedactKeys),
+		mask: redactedValue,
+	}
+)
+
+// selfFormattingTypes là các interface mà struct implement thì được giữ nguyên
+// (time.Time, error, ... tự quyết định cách hiển thị khi log)
+var selfFormattingTypes = []reflect.Type{
+	reflect.TypeOf((*json.Marshaler)(nil)).Elem(),
+	reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem(),
+	reflect.TypeOf((*error)(nil)).Elem(),
+	reflect.TypeOf((*fmt.Stringer)(nil)).Elem(),
+}
+
+// SetRedactor thiết lập danh sách key nhạy cảm và chuỗi mask dùng khi log
+// Key so khớp không phân biệt hoa thường theo từng từ (tách bởi _, -, ., khoảng trắng và camelCase):
+// "token" che "access_token", "accessToken", "X-Auth-Token"; "card" che "card_number"
+// nhưng không che "discard" hay "cardinality"
+// keys = nil → dùng mặc định (password, token, authorization, card, secret)
+// Truyền keys rỗng ([]string{}) để tắt redaction theo key
+//
+// Example:
+//
+//	goerrorkit.SetRedactor([]string{"password", "otp", "cvv"}, "***")
+func SetRedactor(keys []string, mask string) {
+	if keys == nil {
+		keys = defaultRedactKeys
+	}
+	if mask == "" {
+		mask = redactedValue
+	}
+
+	redactorMu.Lock()
+	currentRedactor.keys = segmentKeys(keys)
+	currentRedactor.mask = mask
+	redactorMu.Unlock()
+}
+
+// SetRedactFunc thiết lập custom redaction logic, chạy cho mọi key (kể cả trong map lồng nhau)
+// Truyền nil để bỏ
+//
+// Example:
+//
+//	emailRe := regexp.MustCompile(`[^@\s]+@[^@\s]+`)
+//	goerrorkit.SetRedactFunc(func(key string, value interface{}) (interface{}, bool) {
+//	    if s, ok := value.(string); ok && emailRe.MatchString(s) {
+//	        return emailRe.ReplaceAllString(s, "***@***"), true
+//	    }
+//	    return nil, false
+//	})
+func SetRedactFunc(fn RedactFunc) {
+	redactorMu.Lock()
+	currentRedactor.fn = fn
+	redactorMu.Unlock()
+}
+
+// getRedactor trả về snapshot cấu hình redaction hiện tại
+func getRedactor() redactor {
+	redactorMu.RLock()
+	defer redactorMu.RUnlock()
+	return currentRedactor
+}
+
+// redactMap trả về bản copy của m với các giá trị nhạy cảm đã bị che
+// KHÔNG sửa map gốc vì AppError có thể còn được dùng cho debug response
+func (r redactor) redactMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = r.redactValue(k, v)
+	}
+	return out
+}
+
+// redactValue che value nếu key nhạy cảm, đệ quy vào map, slice và struct
+// Struct (kể cả pointer tới struct) được convert thành map theo tên field JSON để che được field lồng nhau;
+// struct implement json.Marshaler, encoding.TextMarshaler, error hoặc fmt.Stringer (time.Time, ...) được giữ nguyên
+func (r redactor) redactValue(key string, value interface{}) interface{} {
+	if r.isSensitiveKey(key) {
+		return r.mask
+	}
+	if r.fn != nil {
+		if replaced, ok := r.fn(key, value); ok {
+			return replaced
+		}
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		return r.redactMap(v)
+	case map[string]string:
+		out := make(map[string]string, len(v))
+		for k, item := range v {
+			if redacted, ok := r.redactValue(k, item).(string); ok {
+				out[k] = redacted
+			} else {
+				out[k] = fmt.Sprint(r.redactValue(k, item))
+			}
+		}
+		return out
+	case []map[string]interface{}:
+		out := make([]map[string]interface{}, len(v))
+		for i, item := range v {
+			out[i] = r.redactMap(item)
+		}
+		return out
+	case []map[string]string:
+		out := make([]map[string]string, len(v))
+		for i, item := range v {
+			out[i], _ = r.redactValue(key, item).(map[string]string)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = r.redactValue(key, item)
+		}
+		return out
+	}
+
+	return r.redactReflect(key, value)
+}
+
+// redactReflect xử lý struct, pointer tới struct và slice của chúng (kiểu không biết trước)
+// Kiểu khác được giữ nguyên
+func (r redactor) redactReflect(key string, value interface{}) interface{} {
+	rv := reflect.ValueOf(value)
+	switch {
+	case isRedactableStruct(rv.Type()):
+		if rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				return value
+			}
+			rv = rv.Elem()
+		}
+		out := make(map[string]interface{}, rv.NumField())
+		r.redactStruct(rv, out)
+		return out
+	case rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array:
+		elem := rv.Type().Elem()
+		if !isRedactableStruct(elem) && elem.Kind() != reflect.Map && elem.Kind() != reflect.Interface {
+			return value
+		}
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return value
+		}
+		out := make([]interface{}, rv.Len())
+		for i := range out {
+			out[i] = r.redactValue(key, rv.Index(i).Interface())
+		}
+		return out
+	case rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String:
+		if rv.IsNil() {
+			return value
+		}
+		out := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			k := iter.Key().String()
+			out[k] = r.redactValue(k, iter.Value().Interface())
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+// redactStruct ghi các field exported của struct vào out theo tên JSON (json tag; "-" và omitempty được tôn trọng)
+// Field embedded không có tag được flatten giống encoding/json
+func (r redactor) redactStruct(rv reflect.Value, out map[string]interface{}) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		fv := rv.Field(i)
+		if field.Anonymous && name == "" {
+			if fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				r.redactStruct(fv, out)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if strings.Contains(","+opts+",", ",omitempty,") && isEmptyValue(fv) {
+			continue
+		}
+		out[name] = r.redactValue(name, fv.Interface())
+	}
+}
+
+// isEmptyValue kiểm tra giá trị rỗng theo quy tắc omitempty của encoding/json
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Struct:
+		return false
+	default:
+		return v.IsZero()
+	}
+}
+
+// isRedactableStruct kiểm tra t là struct (hoặc pointer tới struct) tự serialize theo field
+func isRedactableStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for _, candidate := range []reflect.Type{t, reflect.PtrTo(t)} {
+		for _, iface := range selfFormattingTypes {
+			if candidate.Implements(iface) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isSensitiveKey kiểm tra các từ của key có chứa (liên tiếp) các từ của một key nhạy cảm không
+func (r redactor) isSensitiveKey(key string) bool {
+	if len(r.keys) == 0 {
+		return false
+	}
+	segments := keySegments(key)
+	for _, k := range r.keys {
+		if containsSegments(segments, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// segmentKeys tách từng key nhạy cảm thành các từ (bỏ key rỗng)
+func segmentKeys(keys []string) [][]string {
+	out := make([][]string, 0, len(keys))
+	for _, k := range keys {
+		if segments := keySegments(k); len(segments) > 0 {
+			out = append(out, segments)
+		}
+	}
+	return out
+}
+
+// keySegments tách key thành các từ viết thường: ký tự không phải chữ/số là dấu phân cách,
+// camelCase và acronym được tách ("X-Auth-Token" → x auth token, "APIKey" → api key)
+func keySegments(key string) []string {
+	var segments []string
+	runes := []rune(key)
+	start := -1
+	flush := func(end int) {
+		if start >= 0 {
+			segments = append(segments, strings.ToLower(string(runes[start:end])))
+			start = -1
+		}
+	}
+	for i, c := range runes {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			flush(i)
+			continue
+		}
+		if start >= 0 && unicode.IsUpper(c) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush(i)
+			}
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	flush(len(runes))
+	return segments
+}
+
+// containsSegments kiểm tra needle là dãy con liên tiếp của segments
+func containsSegments(segments, needle []string) bool {
+	for i := 0; i+len(needle) <= len(segments); i++ {
+		match := true
+		for j, n := range needle {
+			if segments[i+j] != n {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactValue che value theo cấu hình hiện tại (SetRedactor, SetRedactFunc) như khi ghi log
+// Dùng cho integration/helper cần đưa dữ liệu user vào error trước khi log hoặc response
+//
+// Example:
+//
+//	meta["value"] = goerrorkit.RedactValue(fieldName, receivedValue)
+func RedactValue(key string, value interface{}) interface{} {
+	return getRedactor().redactValue(key, value)
+}
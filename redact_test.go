@@ -0,0 +1,232 @@
+package goerrorkit
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSensitiveKeyWordBoundaries(t *testing.T) {
+	r := getRedactor()
+
+	cases := map[string]bool{
+		"card":          true,
+		"card_number":   true,
+		"cardNumber":    true,
+		"credit-card":   true,
+		"CreditCardNo":  true,
+		"accessToken":   true,
+		"X-Auth-Token":  true,
+		"Authorization": true,
+		"db.password":   true,
+		"clientSecret":  true,
+		"discard":       false,
+		"cardinality":   false,
+		"discarded_at":  false,
+		"tokenizer":     false,
+		"user_id":       false,
+		"":              false,
+	}
+	for key, want := range cases {
+		if got := r.isSensitiveKey(key); got != want {
+			t.Errorf("isSensitiveKey(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestKeySegments(t *testing.T) {
+	cases := map[string][]string{
+		"card_number":  {"card", "number"},
+		"cardNumber":   {"card", "number"},
+		"APIKey":       {"api", "key"},
+		"X-Auth-Token": {"x", "auth", "token"},
+		"user2FACode":  {"user2", "fa", "code"},
+		"__":           nil,
+	}
+	for key, want := range cases {
+		if got := keySegments(key); !reflect.DeepEqual(got, want) {
+			t.Errorf("keySegments(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestSetRedactorMultiWordKey(t *testing.T) {
+	SetRedactor([]string{"api_key"}, "***")
+	defer SetRedactor(nil, "")
+
+	r := getRedactor()
+	for _, key := range []string{"api_key", "apiKey", "X-Api-Key"} {
+		if !r.isSensitiveKey(key) {
+			t.Errorf("isSensitiveKey(%q) = false, want true", key)
+		}
+	}
+	for _, key := range []string{"api", "key", "key_api"} {
+		if r.isSensitiveKey(key) {
+			t.Errorf("isSensitiveKey(%q) = true, want false", key)
+		}
+	}
+	if got := RedactValue("apiKey", "k-1"); got != "***" {
+		t.Errorf("RedactValue = %v, want ***", got)
+	}
+}
+
+func TestRedactNestedMaps(t *testing.T) {
+	data := map[string]interface{}{
+		"user": map[string]interface{}{
+			"name":     "An",
+			"password": "p@ss",
+			"billing": map[string]interface{}{
+				"card_number": "4111111111111111",
+				"discard":     "keep-me",
+			},
+		},
+	}
+
+	got := getRedactor().redactMap(data)
+
+	user := got["user"].(map[string]interface{})
+	billing := user["billing"].(map[string]interface{})
+	if user["name"] != "An" || user["password"] != redactedValue {
+		t.Errorf("user = %v", user)
+	}
+	if billing["card_number"] != redactedValue || billing["discard"] != "keep-me" {
+		t.Errorf("billing = %v", billing)
+	}
+
+	// Map của caller không bị sửa
+	original := data["user"].(map[string]interface{})
+	if original["password"] != "p@ss" || original["billing"].(map[string]interface{})["card_number"] != "4111111111111111" {
+		t.Errorf("caller map mutated: %v", data)
+	}
+}
+
+func TestRedactSlicesOfMaps(t *testing.T) {
+	data := map[string]interface{}{
+		"items": []map[string]interface{}{
+			{"sku": "A1", "secret": "s1"},
+			{"sku": "B2", "secret": "s2"},
+		},
+		"mixed": []interface{}{
+			map[string]interface{}{"token": "t1"},
+			"plain",
+		},
+		"headers": []map[string]string{
+			{"Authorization": "Bearer x", "Accept": "application/json"},
+		},
+	}
+
+	got := getRedactor().redactMap(data)
+
+	items := got["items"].([]map[string]interface{})
+	for i, item := range items {
+		if item["secret"] != redactedValue || item["sku"] == redactedValue {
+			t.Errorf("items[%d] = %v", i, item)
+		}
+	}
+	mixed := got["mixed"].([]interface{})
+	if mixed[0].(map[string]interface{})["token"] != redactedValue || mixed[1] != "plain" {
+		t.Errorf("mixed = %v", mixed)
+	}
+	headers := got["headers"].([]map[string]string)[0]
+	if headers["Authorization"] != redactedValue || headers["Accept"] != "application/json" {
+		t.Errorf("headers = %v", headers)
+	}
+	if data["items"].([]map[string]interface{})[0]["secret"] != "s1" {
+		t.Error("caller slice mutated")
+	}
+}
+
+func TestRedactStringMap(t *testing.T) {
+	headers := map[string]string{"Authorization": "Bearer x", "X-Request-Id": "req-1"}
+
+	got, ok := RedactValue("headers", headers).(map[string]string)
+	if !ok {
+		t.Fatalf("RedactValue returned %T, want map[string]string", RedactValue("headers", headers))
+	}
+	if got["Authorization"] != redactedValue || got["X-Request-Id"] != "req-1" {
+		t.Errorf("headers = %v", got)
+	}
+	if headers["Authorization"] != "Bearer x" {
+		t.Error("caller map mutated")
+	}
+}
+
+type redactTestCard struct {
+	Holder string `json:"holder"`
+	Number string `json:"card_number"`
+}
+
+type redactTestPayment struct {
+	redactTestAudit
+	Amount   int              `json:"amount"`
+	Card     redactTestCard   `json:"payment_method"`
+	Cards    []redactTestCard `json:"saved"`
+	Password string
+	Note     string    `json:"note,omitempty"`
+	Internal string    `json:"-"`
+	PaidAt   time.Time `json:"paid_at"`
+	secret   string
+}
+
+type redactTestAudit struct {
+	ClientSecret string `json:"client_secret"`
+}
+
+func TestRedactStructs(t *testing.T) {
+	paidAt := time.Date(2025, 11, 28, 10, 0, 0, 0, time.UTC)
+	payment := &redactTestPayment{
+		redactTestAudit: redactTestAudit{ClientSecret: "cs"},
+		Amount:          100,
+		Card:            redactTestCard{Holder: "AN", Number: "4111"},
+		Cards:           []redactTestCard{{Holder: "BINH", Number: "5500"}},
+		Password:        "p@ss",
+		Internal:        "hidden",
+		PaidAt:          paidAt,
+		secret:          "unexported",
+	}
+
+	got, ok := RedactValue("payment", payment).(map[string]interface{})
+	if !ok {
+		t.Fatalf("RedactValue returned %T, want map", RedactValue("payment", payment))
+	}
+
+	want := map[string]interface{}{
+		"client_secret":  redactedValue,
+		"amount":         100,
+		"payment_method": map[string]interface{}{"holder": "AN", "card_number": redactedValue},
+		"saved":          []interface{}{map[string]interface{}{"holder": "BINH", "card_number": redactedValue}},
+		"Password":       redactedValue,
+		"paid_at":        paidAt,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("redacted struct =\n%#v\nwant\n%#v", got, want)
+	}
+	if payment.Card.Number != "4111" || payment.Password != "p@ss" {
+		t.Error("caller struct mutated")
+	}
+}
+
+func TestRedactKeepsSelfFormattingValues(t *testing.T) {
+	cause := errors.New("db down")
+	at := time.Date(2025, 11, 28, 10, 0, 0, 0, time.UTC)
+	chain := []string{"main.a (a.go:1)"}
+
+	r := getRedactor()
+	if got := r.redactValue("cause", cause); got != cause {
+		t.Errorf("error value = %#v, want unchanged", got)
+	}
+	if got := r.redactValue("at", at); got != at {
+		t.Errorf("time value = %#v, want unchanged", got)
+	}
+	if got, ok := r.redactValue("call_chain", chain).([]string); !ok || len(got) != 1 {
+		t.Errorf("call_chain = %#v, want []string unchanged", got)
+	}
+	var nilCard *redactTestCard
+	if got := r.redactValue("card_info", nilCard); got != redactedValue {
+		t.Errorf("sensitive nil pointer = %#v, want masked", got)
+	}
+	if got := r.redactValue("info", nilCard); got != nilCard {
+		t.Errorf("nil pointer = %#v, want unchanged", got)
+	}
+}
@@ -0,0 +1,34 @@
+package goerrorkit
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+)
+
+// refPrefix là prefix của reference code trả về client
+const refPrefix = "ERR-"
+
+// Ref trả về reference code ngắn, dễ đọc cho user (ví dụ "ERR-7F3A9C")
+// Code được derive từ RequestID kèm type, code, message và thời điểm tạo error (không random,
+// không cache) nên giá trị "ref" trong response và trong log luôn trùng nhau, an toàn khi gọi
+// đồng thời, và sentinel dùng chung được ConvertToAppError gắn RequestID riêng cho từng request
+//
+// Example:
+//
+//	// Response: {"error": "Internal server error", "type": "SYSTEM", "ref": "ERR-7F3A9C"}
+//	// Log:      ... ref=ERR-7F3A9C
+//	// User báo "ERR-7F3A9C" → support grep log theo ref
+func (e *AppError) Ref() string {
+	h := fnv.New32a()
+	h.Write([]byte(e.RequestID))
+	h.Write([]byte{0})
+	h.Write([]byte(e.Type))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.Itoa(e.Code)))
+	h.Write([]byte{0})
+	h.Write([]byte(e.Message))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.FormatInt(e.CreatedAt.UnixNano(), 10)))
+	return fmt.Sprintf("%s%06X", refPrefix, h.Sum32()&0xFFFFFF)
+}
@@ -0,0 +1,63 @@
+package goerrorkit
+
+import (
+	"regexp"
+	"sync"
+	"testing"
+)
+
+var refPattern = regexp.MustCompile(`^ERR-[0-9A-F]{6}$`)
+
+func TestRefInResponseAndLog(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	ctx := newTestContext("GET", "/orders/1")
+	appErr := ConvertToAppError(NewBusinessError(404, "Order not found"), "req-1")
+	LogAndRespond(ctx, appErr, "GET /orders/1")
+
+	respRef, _ := ctx.response()["ref"].(string)
+	if !refPattern.MatchString(respRef) {
+		t.Fatalf("response ref = %q", respRef)
+	}
+	entries := mem.Entries()
+	if len(entries) != 1 || entries[0].Fields["ref"] != respRef {
+		t.Errorf("log ref = %v, want %s", entries, respRef)
+	}
+}
+
+func TestRefDeterministic(t *testing.T) {
+	appErr := NewSystemError(nil).WithRequestID("req-1")
+	if appErr.Ref() != appErr.Ref() {
+		t.Error("Ref changes between calls")
+	}
+
+	var wg sync.WaitGroup
+	refs := make([]string, 20)
+	for i := range refs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			refs[i] = appErr.Ref()
+		}(i)
+	}
+	wg.Wait()
+	for _, ref := range refs {
+		if ref != refs[0] {
+			t.Fatalf("concurrent Ref calls disagree: %v", refs)
+		}
+	}
+}
+
+func TestRefPerRequestForSharedSentinel(t *testing.T) {
+	sentinel := NewBusinessError(404, "Product not found")
+
+	first := ConvertToAppError(sentinel, "req-1").Ref()
+	second := ConvertToAppError(sentinel, "req-2").Ref()
+	if first == second {
+		t.Errorf("two requests share ref %s", first)
+	}
+	if again := ConvertToAppError(sentinel, "req-1").Ref(); again != first {
+		t.Errorf("ref for req-1 = %s then %s", first, again)
+	}
+}
@@ -0,0 +1,69 @@
+package goerrorkit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rePanicFlushTimeout là thời gian tối đa chờ flush log trước khi re-panic
+const rePanicFlushTimeout = 5 * time.Second
+
+var (
+	rePanicMu     sync.RWMutex
+	rePanicPolicy func(recovered interface{}) bool
+)
+
+// SetRePanic thiết lập policy re-panic mặc định cho các điểm recover của goerrorkit
+// (adapters/fiber khi Config.RePanic == nil, Go/GoCtx/Recover)
+// Khi policy trả về true: panic được log như bình thường (HandlePanic + LogError), log được flush,
+// rồi panic lại với giá trị gốc để process crash và orchestrator restart
+// Mặc định (nil): không bao giờ re-panic
+//
+// Example:
+//
+//	// Trạng thái global hỏng → crash để Kubernetes restart pod
+//	goerrorkit.SetRePanic(func(recovered interface{}) bool {
+//	    err, ok := recovered.(error)
+//	    return ok && errors.Is(err, ErrCorruptedState)
+//	})
+func SetRePanic(policy func(recovered interface{}) bool) {
+	rePanicMu.Lock()
+	rePanicPolicy = policy
+	rePanicMu.Unlock()
+}
+
+// getRePanic trả về policy re-panic toàn cục
+func getRePanic() func(recovered interface{}) bool {
+	rePanicMu.RLock()
+	defer rePanicMu.RUnlock()
+	return rePanicPolicy
+}
+
+// RePanicIfFatal flush log rồi panic lại với r nếu policy trả về true
+// policy == nil → dùng policy của SetRePanic. Dành cho adapter: chỉ gọi SAU khi panic đã được log
+//
+// Example:
+//
+//	if r := recover(); r != nil {
+//	    goerrorkit.LogError(goerrorkit.HandlePanic(r, requestID), requestPath)
+//	    goerrorkit.RePanicIfFatal(nil, r)
+//	}
+func RePanicIfFatal(policy func(recovered interface{}) bool, r interface{}) {
+	if policy == nil {
+		policy = getRePanic()
+	}
+	rePanicIfFatal(policy, r)
+}
+
+// rePanicIfFatal flush log rồi panic lại với r nếu policy trả về true
+// Chỉ gọi SAU khi panic đã được log
+func rePanicIfFatal(policy func(recovered interface{}) bool, r interface{}) {
+	if policy == nil || !policy(r) {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), rePanicFlushTimeout)
+	_ = FlushLogs(ctx)
+	cancel()
+	panic(r)
+}
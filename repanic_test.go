@@ -0,0 +1,103 @@
+package goerrorkit
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// flushRecordingLogger là MemoryLogger ghi lại số entry đã log tại thời điểm Flush được gọi
+type flushRecordingLogger struct {
+	*MemoryLogger
+	flushedEntries int // -1: chưa flush
+}
+
+func useFlushRecordingLogger(t *testing.T) *flushRecordingLogger {
+	t.Helper()
+	l := &flushRecordingLogger{MemoryLogger: &MemoryLogger{}, flushedEntries: -1}
+	SetLogger(l)
+	t.Cleanup(func() { SetLogger(nil) })
+	return l
+}
+
+func (l *flushRecordingLogger) Flush(ctx context.Context) error {
+	l.flushedEntries = len(l.Entries())
+	return nil
+}
+
+// recoverValue chạy fn và trả về giá trị panic thoát ra khỏi fn (nil nếu không panic)
+func recoverValue(fn func()) (r interface{}) {
+	defer func() { r = recover() }()
+	fn()
+	return nil
+}
+
+var errCorruptedState = errors.New("corrupted global state")
+
+func TestRePanicIfFatalPolicy(t *testing.T) {
+	fatal := func(r interface{}) bool {
+		err, ok := r.(error)
+		return ok && errors.Is(err, errCorruptedState)
+	}
+
+	tests := []struct {
+		name   string
+		policy func(interface{}) bool
+		value  interface{}
+		panics bool
+	}{
+		{"nil policy", nil, errCorruptedState, false},
+		{"policy false", fatal, "boom", false},
+		{"policy true", fatal, errCorruptedState, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := useFlushRecordingLogger(t)
+
+			got := recoverValue(func() { rePanicIfFatal(tt.policy, tt.value) })
+			if tt.panics && got != tt.value {
+				t.Errorf("re-panicked with %v, want original value %v", got, tt.value)
+			}
+			if !tt.panics && got != nil {
+				t.Errorf("re-panicked with %v, want no panic", got)
+			}
+			if flushed := logger.flushedEntries >= 0; flushed != tt.panics {
+				t.Errorf("flushed = %v, want %v", flushed, tt.panics)
+			}
+		})
+	}
+}
+
+func TestRePanicIfFatalGlobalPolicy(t *testing.T) {
+	useFlushRecordingLogger(t)
+	SetRePanic(func(r interface{}) bool { return r == "oom" })
+	defer SetRePanic(nil)
+
+	if got := recoverValue(func() { RePanicIfFatal(nil, "oom") }); got != "oom" {
+		t.Errorf("RePanicIfFatal(nil) = %v, want global policy to re-panic", got)
+	}
+	// Policy truyền vào thay thế policy toàn cục
+	never := func(interface{}) bool { return false }
+	if got := recoverValue(func() { RePanicIfFatal(never, "oom") }); got != nil {
+		t.Errorf("RePanicIfFatal(never) = %v, want no panic", got)
+	}
+}
+
+func TestGoroutinePanicRePanicsAfterLogging(t *testing.T) {
+	logger := useFlushRecordingLogger(t)
+	panics := capturePanicHook(t)
+	SetRePanic(func(r interface{}) bool { return r == errCorruptedState })
+	defer SetRePanic(nil)
+
+	got := recoverValue(func() { handleGoroutinePanic(errCorruptedState, "job-1") })
+
+	if got != errCorruptedState {
+		t.Fatalf("re-panicked with %v, want original value", got)
+	}
+	if logger.flushedEntries != 1 {
+		t.Errorf("flushed with %d entries, want the PanicError logged before flush", logger.flushedEntries)
+	}
+	if appErr := waitPanic(t, panics); appErr.Type != PanicError {
+		t.Errorf("hook got %s, want PanicError", appErr.Type)
+	}
+}
@@ -0,0 +1,93 @@
+package goerrorkit
+
+import "sync"
+
+var (
+	requestFieldsMu      sync.RWMutex
+	loggedRequestHeaders []string // Header được log (set bởi SetLoggedRequestHeaders)
+	loggedQueryParams    []string // Query parameter được log (set bởi SetLoggedQueryParams)
+)
+
+// SetLoggedRequestHeaders cấu hình allowlist request header được thêm vào field "headers"
+// trong log của LogAndRespond (và các adapter). Mặc định không log header nào.
+// Giá trị đi qua SetRedactor; gọi không tham số để tắt.
+// Cần HTTPContext implement HeaderGetter (adapters/fiber.FiberContext, adapters/chi.ChiContext có sẵn)
+//
+// Example:
+//
+//	goerrorkit.SetLoggedRequestHeaders("X-Tenant-Id", "X-Client-Version", "Referer")
+//	// log: {"headers": {"X-Tenant-Id": "acme", "Referer": "https://..."}, ...}
+func SetLoggedRequestHeaders(names ...string) {
+	copied := append([]string{}, names...)
+	requestFieldsMu.Lock()
+	loggedRequestHeaders = copied
+	requestFieldsMu.Unlock()
+}
+
+// SetLoggedQueryParams cấu hình allowlist query parameter được thêm vào field "query"
+// trong log của LogAndRespond (và các adapter). Mặc định không log query nào.
+// Giá trị đi qua SetRedactor; gọi không tham số để tắt.
+// Cần HTTPContext implement QueryGetter
+//
+// Example:
+//
+//	goerrorkit.SetLoggedQueryParams("page", "sort")
+//	// log: {"query": {"page": "2", "sort": "price"}, ...}
+func SetLoggedQueryParams(names ...string) {
+	copied := append([]string{}, names...)
+	requestFieldsMu.Lock()
+	loggedQueryParams = copied
+	requestFieldsMu.Unlock()
+}
+
+// requestFields thêm "headers" và "query" theo allowlist vào extra (giá trị rỗng bị bỏ qua)
+// Header đã có trong extra["headers"] (ví dụ do adapter ghi nhận) được giữ nguyên. Không sửa map extra gốc
+func requestFields(ctx HTTPContext, extra map[string]interface{}) map[string]interface{} {
+	requestFieldsMu.RLock()
+	headerNames, queryNames := loggedRequestHeaders, loggedQueryParams
+	requestFieldsMu.RUnlock()
+
+	var headers, query map[string]interface{}
+	if hg, ok := ctx.(HeaderGetter); ok && len(headerNames) > 0 {
+		headers = allowlistValues(headerNames, hg.GetHeader)
+	}
+	if qg, ok := ctx.(QueryGetter); ok && len(queryNames) > 0 {
+		query = allowlistValues(queryNames, qg.Query)
+	}
+	if headers == nil && query == nil {
+		return extra
+	}
+
+	fields := make(map[string]interface{}, len(extra)+2)
+	for k, v := range extra {
+		fields[k] = v
+	}
+	if headers != nil {
+		if existing, ok := fields["headers"].(map[string]interface{}); ok {
+			for k, v := range existing {
+				headers[k] = v
+			}
+		}
+		fields["headers"] = headers
+	}
+	if query != nil {
+		fields["query"] = query
+	}
+	return fields
+}
+
+// allowlistValues đọc các giá trị theo tên (qua get), redact và bỏ qua giá trị rỗng; nil nếu không có
+func allowlistValues(names []string, get func(name string) string) map[string]interface{} {
+	var values map[string]interface{}
+	for _, name := range names {
+		v := get(name)
+		if v == "" {
+			continue
+		}
+		if values == nil {
+			values = make(map[string]interface{}, len(names))
+		}
+		values[name] = RedactValue(name, v)
+	}
+	return values
+}
@@ -0,0 +1,91 @@
+package goerrorkit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func withLoggedRequestFields(t *testing.T, headers, query []string) {
+	t.Helper()
+	SetLoggedRequestHeaders(headers...)
+	SetLoggedQueryParams(query...)
+	t.Cleanup(func() {
+		SetLoggedRequestHeaders()
+		SetLoggedQueryParams()
+	})
+}
+
+func TestLogAndRespondRequestFields(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+	withLoggedRequestFields(t, []string{"X-Tenant-Id", "X-Missing"}, []string{"page", "sort"})
+
+	ctx := newClientContext()
+	ctx.headers["X-Tenant-Id"] = "acme"
+	ctx.headers["Referer"] = "https://shop.example/cart"
+	LogAndRespond(ctx, NewBusinessError(404, "Order not found"), "GET /orders")
+
+	entry, ok := mem.Find("", "Order not found")
+	if !ok {
+		t.Fatalf("error not logged: %+v", mem.Entries())
+	}
+	// Chỉ header/query trong allowlist có giá trị mới được log
+	if got, want := entry.Fields["headers"], map[string]interface{}{"X-Tenant-Id": "acme"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("headers = %v, want %v", got, want)
+	}
+	if got, want := entry.Fields["query"], map[string]interface{}{"page": "2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("query = %v, want %v", got, want)
+	}
+}
+
+func TestRequestFieldsOffByDefault(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	ctx := newClientContext()
+	ctx.headers["X-Tenant-Id"] = "acme"
+	LogAndRespond(ctx, NewBusinessError(404, "Order not found"), "GET /orders")
+
+	entry, _ := mem.Find("", "Order not found")
+	if _, ok := entry.Fields["headers"]; ok {
+		t.Errorf("headers logged without allowlist: %v", entry.Fields)
+	}
+	if _, ok := entry.Fields["query"]; ok {
+		t.Errorf("query logged without allowlist: %v", entry.Fields)
+	}
+}
+
+func TestRequestFieldsRedacted(t *testing.T) {
+	withLoggedRequestFields(t, []string{"Authorization"}, []string{"access_token"})
+
+	ctx := newClientContext()
+	ctx.headers["Authorization"] = "Bearer secret-value"
+	ctx.query["access_token"] = "secret-value"
+	fields := requestFields(ctx, nil)
+
+	headers, _ := fields["headers"].(map[string]interface{})
+	query, _ := fields["query"].(map[string]interface{})
+	if headers["Authorization"] == nil || headers["Authorization"] == "Bearer secret-value" {
+		t.Errorf("headers = %v, want Authorization redacted", headers)
+	}
+	if query["access_token"] == nil || query["access_token"] == "secret-value" {
+		t.Errorf("query = %v, want access_token redacted", query)
+	}
+}
+
+func TestRequestFieldsMergesAdapterHeaders(t *testing.T) {
+	withLoggedRequestFields(t, []string{"X-Tenant-Id"}, nil)
+
+	ctx := newClientContext()
+	ctx.headers["X-Tenant-Id"] = "acme"
+	extra := map[string]interface{}{"headers": map[string]interface{}{"X-Request-Source": "mobile"}}
+	fields := requestFields(ctx, extra)
+
+	want := map[string]interface{}{"X-Tenant-Id": "acme", "X-Request-Source": "mobile"}
+	if !reflect.DeepEqual(fields["headers"], want) {
+		t.Errorf("headers = %v, want %v", fields["headers"], want)
+	}
+	if len(extra["headers"].(map[string]interface{})) != 1 {
+		t.Errorf("extra mutated: %v", extra)
+	}
+}
@@ -0,0 +1,47 @@
+package goerrorkit
+
+import (
+	"context"
+	"errors"
+)
+
+// requestIDKey là key private để lưu request ID trong context.Context
+type requestIDKey struct{}
+
+// ContextWithRequestID gắn request ID (hoặc job ID) vào context.Context
+// Các ctx-aware functions (WrapCtx, WrapWithMessageCtx, NewBusinessErrorCtx) sẽ tự động lấy ID này
+// (và SLA tier nếu có, xem ContextWithTier)
+// Fiber middleware tự gắn request ID vào c.UserContext() nên handler chỉ cần truyền c.UserContext()
+//
+// Example:
+//
+//	// Background job: dùng job ID làm request ID
+//	ctx := goerrorkit.ContextWithRequestID(context.Background(), job.ID)
+//	if err := processJob(ctx, job); err != nil {
+//	    goerrorkit.LogError(goerrorkit.WrapCtx(ctx, err), "job:"+job.Name)
+//	}
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext lấy request ID từ context.Context
+// Trả về chuỗi rỗng nếu context không có request ID
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if rid, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return rid
+	}
+	return ""
+}
+
+// inheritRequestID lấy request ID từ AppError nằm trong chain của err (nếu có)
+// Giúp request ID không bị mất khi wrap nhiều lớp
+func inheritRequestID(err error) string {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr.RequestID
+	}
+	return ""
+}
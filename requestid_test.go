@@ -0,0 +1,214 @@
+package goerrorkit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestRequestIDInResponseAndLog(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	ctx := newTestContext("GET", "/orders/42")
+	appErr := NewBusinessError(404, "Order not found")
+	appErr.RequestID = "req-42"
+	LogAndRespond(ctx, appErr, "GET /orders/42")
+
+	if got := ctx.response()["request_id"]; got != "req-42" {
+		t.Errorf("response request_id = %v, want req-42", got)
+	}
+	entry, ok := mem.Find("", "Order not found")
+	if !ok || entry.Fields["request_id"] != "req-42" {
+		t.Errorf("log entries = %v, want request_id req-42", mem.Entries())
+	}
+
+	// Không có request ID: không thêm field rỗng
+	mem.Reset()
+	if _, ok := FormatErrorResponse(NewBusinessError(404, "Order not found"))["request_id"]; ok {
+		t.Error("response has request_id for error without one")
+	}
+	LogError(NewBusinessError(404, "Order not found"), "GET /orders/42")
+	if entry, _ := mem.Find("", "Order not found"); entry.Fields["request_id"] != nil {
+		t.Errorf("log request_id = %v, want none", entry.Fields["request_id"])
+	}
+}
+
+func TestConvertToAppErrorKeepsExistingRequestID(t *testing.T) {
+	appErr := NewBusinessError(404, "Job input not found").WithRequestID("job-42")
+
+	converted := ConvertToAppError(fmt.Errorf("run job: %w", appErr), "unknown")
+	if converted.RequestID != "job-42" {
+		t.Errorf("RequestID = %q, want job-42", converted.RequestID)
+	}
+
+	filled := ConvertToAppError(NewBusinessError(404, "Not found"), "req-1")
+	if filled.RequestID != "req-1" {
+		t.Errorf("RequestID = %q, want req-1 for AppError without ID", filled.RequestID)
+	}
+}
+
+func TestHandlePanicKeepsExistingRequestID(t *testing.T) {
+	sentinel := NewBusinessError(409, "Conflict")
+	withID := NewBusinessError(409, "Conflict").WithRequestID("job-42")
+
+	if got := HandlePanic(withID, "req-1").RequestID; got != "job-42" {
+		t.Errorf("RequestID = %q, want job-42", got)
+	}
+
+	panicErr := HandlePanic(sentinel, "req-1")
+	if panicErr.RequestID != "req-1" || panicErr.Details["panic"] != true {
+		t.Errorf("got RequestID %q panic=%v", panicErr.RequestID, panicErr.Details["panic"])
+	}
+	if sentinel.RequestID != "" || sentinel.Details["panic"] != nil {
+		t.Errorf("panic(sentinel) mutated sentinel: %q %v", sentinel.RequestID, sentinel.Details)
+	}
+}
+
+func TestWriteErrorRequestID(t *testing.T) {
+	UseMemoryLogger()
+	defer SetLogger(nil)
+
+	tests := []struct {
+		name  string
+		local interface{}
+		err   error
+		want  string
+	}{
+		{"local", "req-1", NewBusinessError(404, "Not found"), "req-1"},
+		{"error wins over local", "req-1", NewBusinessError(404, "Not found").WithRequestID("job-42"), "job-42"},
+		{"missing falls back to unknown", nil, NewBusinessError(404, "Not found"), "unknown"},
+	}
+	for _, tt := range tests {
+		ctx := newTestContext("GET", "/orders")
+		if tt.local != nil {
+			ctx.locals["requestid"] = tt.local
+		}
+		WriteError(ctx, tt.err, "GET /orders")
+		if got := ctx.response()["request_id"]; got != tt.want {
+			t.Errorf("%s: request_id = %v, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCtxVariantsRequestID(t *testing.T) {
+	ctx := ContextWithRequestID(context.Background(), "job-42")
+
+	if got := NewBusinessErrorCtx(ctx, 404, "Not found").RequestID; got != "job-42" {
+		t.Errorf("NewBusinessErrorCtx RequestID = %q", got)
+	}
+	if got := WrapCtx(ctx, fmt.Errorf("boom")).RequestID; got != "job-42" {
+		t.Errorf("WrapCtx RequestID = %q", got)
+	}
+	if got := RequestIDFromContext(context.Background()); got != "" {
+		t.Errorf("RequestIDFromContext(empty) = %q", got)
+	}
+
+	// Context không có ID: middleware điền "unknown" khi convert
+	appErr := WrapCtx(context.Background(), fmt.Errorf("boom"))
+	if got := ConvertToAppError(appErr, "unknown").RequestID; got != "unknown" {
+		t.Errorf("RequestID = %q, want unknown", got)
+	}
+}
+
+func TestJobIDSurvivesWrapping(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	ctx := ContextWithRequestID(context.Background(), "job-42")
+
+	// Layer dưới: lỗi thường; layer giữa thêm context; layer trên wrap thêm một lớp không có ctx
+	repoErr := WrapWithMessageCtx(ctx, fmt.Errorf("connection reset"), "load invoice")
+	serviceErr := WrapCtx(ctx, fmt.Errorf("render pdf: %w", repoErr))
+	jobErr := WrapWithMessage(serviceErr, "send invoice email")
+
+	for name, appErr := range map[string]*AppError{
+		"WrapWithMessageCtx": repoErr,
+		"WrapCtx":            serviceErr,
+		"WrapWithMessage":    jobErr,
+	} {
+		if appErr.RequestID != "job-42" {
+			t.Errorf("%s RequestID = %q, want job-42", name, appErr.RequestID)
+		}
+	}
+
+	// Middleware/LogError không ghi đè job ID
+	LogError(ConvertToAppError(jobErr, "unknown"), "job:send-invoice")
+	entry, ok := mem.Find("", "send invoice email")
+	if !ok {
+		t.Fatalf("error not logged: %v", mem.Entries())
+	}
+	if entry.Fields["request_id"] != "job-42" {
+		t.Errorf("logged request_id = %v, want job-42", entry.Fields["request_id"])
+	}
+}
+
+func TestWrapWithMessageCtxPreservesTypeAndJobID(t *testing.T) {
+	ctx := ContextWithRequestID(context.Background(), "job-7")
+
+	notFound := NewBusinessError(404, "Customer not found")
+	wrapped := WrapWithMessageCtx(ctx, notFound, "nightly sync")
+
+	if wrapped.RequestID != "job-7" || wrapped.Type != BusinessError || wrapped.Code != 404 {
+		t.Errorf("got %s %d RequestID=%q, want BUSINESS 404 job-7", wrapped.Type, wrapped.Code, wrapped.RequestID)
+	}
+	if notFound.RequestID != "" {
+		t.Errorf("inner AppError mutated: RequestID = %q", notFound.RequestID)
+	}
+	if WrapCtx(ctx, nil) != nil || WrapWithMessageCtx(ctx, nil, "x") != nil {
+		t.Error("wrapping nil error must return nil")
+	}
+}
+
+func TestWrapCtxWithoutIDKeepsInnerID(t *testing.T) {
+	inner := NewBusinessError(404, "Not found").WithRequestID("job-9")
+
+	if got := WrapCtx(context.Background(), inner).RequestID; got != "job-9" {
+		t.Errorf("WrapCtx RequestID = %q, want inner job-9", got)
+	}
+	if got := WrapWithMessageCtx(context.Background(), inner, "ctx").RequestID; got != "job-9" {
+		t.Errorf("WrapWithMessageCtx RequestID = %q, want inner job-9", got)
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	tests := []struct {
+		name      string
+		err       error
+		status    int
+		requestID string
+	}{
+		{"plain error", fmt.Errorf("db down"), 500, "req-local"},
+		{"wrapped AppError", fmt.Errorf("load: %w", NewBusinessError(404, "Order not found")), 404, "req-local"},
+		{"AppError with request ID", WrapCtx(ContextWithRequestID(context.Background(), "job-7"), fmt.Errorf("timeout")), 500, "job-7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mem.Reset()
+			ctx := newTestContext("GET", "/orders")
+			ctx.locals["requestid"] = "req-local"
+			WriteError(ctx, tt.err, "GET /orders")
+
+			if ctx.status != tt.status {
+				t.Errorf("status = %d, want %d", ctx.status, tt.status)
+			}
+			if got := ctx.response()["request_id"]; got != tt.requestID {
+				t.Errorf("request_id = %v, want %s", got, tt.requestID)
+			}
+			if len(mem.Entries()) != 1 {
+				t.Errorf("entries = %v, want one log entry", mem.Entries())
+			}
+		})
+	}
+
+	// nil error: không log, không response
+	mem.Reset()
+	ctx := newTestContext("GET", "/orders")
+	WriteError(ctx, nil, "GET /orders")
+	if ctx.jsonCalls != 0 || len(mem.Entries()) != 0 {
+		t.Errorf("WriteError(nil) responded %d times, logged %v", ctx.jsonCalls, mem.Entries())
+	}
+}
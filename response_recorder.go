@@ -0,0 +1,108 @@
+package goerrorkit
+
+import "encoding/json"
+
+// responseRecorder wrap HTTPContext để ghi nhận Content-Type và kích thước body của error response
+// Status trả về chính recorder để ctx.Status(code).JSON(...) vẫn đi qua recorder
+type responseRecorder struct {
+	HTTPContext
+	contentType string
+	size        int
+	written     bool
+}
+
+// bodyResponseRecorder là responseRecorder cho HTTPContext có implement BodySender
+// (tách riêng để type assertion BodySender của ResponseWriter vẫn đúng với context gốc)
+type bodyResponseRecorder struct {
+	*responseRecorder
+}
+
+// newResponseRecorder tạo recorder giữ nguyên khả năng BodySender của ctx
+func newResponseRecorder(ctx HTTPContext) recordingContext {
+	rec := &responseRecorder{HTTPContext: ctx}
+	if _, ok := ctx.(BodySender); ok {
+		return &bodyResponseRecorder{responseRecorder: rec}
+	}
+	return rec
+}
+
+// recordingContext là HTTPContext có thể trả về thông tin response đã ghi nhận
+type recordingContext interface {
+	HTTPContext
+	fields() map[string]interface{}
+}
+
+// Status implements HTTPContext
+func (r *responseRecorder) Status(code int) HTTPContext {
+	r.HTTPContext.Status(code)
+	return r
+}
+
+// JSON implements HTTPContext
+func (r *responseRecorder) JSON(data interface{}) error {
+	if body, err := json.Marshal(data); err == nil {
+		r.record("application/json", len(body))
+	}
+	return r.HTTPContext.JSON(data)
+}
+
+// SetHeader implements HeaderSetter (no-op nếu context gốc không hỗ trợ)
+func (r *responseRecorder) SetHeader(key, value string) {
+	if hs, ok := r.HTTPContext.(HeaderSetter); ok {
+		hs.SetHeader(key, value)
+	}
+}
+
+// GetHeader implements HeaderGetter (rỗng nếu context gốc không hỗ trợ)
+func (r *responseRecorder) GetHeader(key string) string {
+	if hg, ok := r.HTTPContext.(HeaderGetter); ok {
+		return hg.GetHeader(key)
+	}
+	return ""
+}
+
+// Query implements QueryGetter (rỗng nếu context gốc không hỗ trợ)
+func (r *responseRecorder) Query(name string) string {
+	if qg, ok := r.HTTPContext.(QueryGetter); ok {
+		return qg.Query(name)
+	}
+	return ""
+}
+
+// ClientIP implements ClientIPGetter (rỗng nếu context gốc không hỗ trợ)
+func (r *responseRecorder) ClientIP() string {
+	if ig, ok := r.HTTPContext.(ClientIPGetter); ok {
+		return ig.ClientIP()
+	}
+	return ""
+}
+
+// record lưu Content-Type và kích thước body
+func (r *responseRecorder) record(contentType string, size int) {
+	r.contentType = contentType
+	r.size = size
+	r.written = true
+}
+
+// fields trả về log fields response_content_type và response_size (nil nếu chưa ghi response)
+func (r *responseRecorder) fields() map[string]interface{} {
+	if !r.written {
+		return nil
+	}
+	return map[string]interface{}{
+		"response_content_type": r.contentType,
+		"response_size":         r.size,
+	}
+}
+
+// Status implements HTTPContext
+func (r *bodyResponseRecorder) Status(code int) HTTPContext {
+	r.HTTPContext.Status(code)
+	return r
+}
+
+// SendBody implements BodySender
+func (r *bodyResponseRecorder) SendBody(contentType string, body []byte) error {
+	r.record(contentType, len(body))
+	return r.HTTPContext.(BodySender).SendBody(contentType, body)
+}
@@ -0,0 +1,84 @@
+package goerrorkit
+
+import (
+	"errors"
+	"testing"
+)
+
+// bodyTestContext là testContext có implement BodySender
+type bodyTestContext struct {
+	*testContext
+	contentType string
+}
+
+func (c *bodyTestContext) SendBody(contentType string, body []byte) error {
+	c.contentType = contentType
+	c.body = body
+	return nil
+}
+
+func TestLogAndRespondRecordsResponseFields(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	ctx := newTestContext("GET", "/orders/42")
+	LogAndRespond(ctx, NewBusinessError(404, "Order not found"), "GET /orders/42")
+
+	entry, ok := mem.Find("", "Order not found")
+	if !ok {
+		t.Fatalf("error not logged: %v", mem.Entries())
+	}
+	if entry.Fields["response_content_type"] != "application/json" {
+		t.Errorf("response_content_type = %v", entry.Fields["response_content_type"])
+	}
+	if entry.Fields["response_size"] != len(ctx.body) || len(ctx.body) == 0 {
+		t.Errorf("response_size = %v, want %d bytes sent", entry.Fields["response_size"], len(ctx.body))
+	}
+}
+
+func TestLogAndRespondRecordsSendBody(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+	if err := SetResponseWriter(ResponseWriterProblem); err != nil {
+		t.Fatal(err)
+	}
+	defer SetResponseWriter(ResponseWriterJSON)
+
+	ctx := &bodyTestContext{testContext: newTestContext("GET", "/orders/42")}
+	LogAndRespond(ctx, NewBusinessError(404, "Order not found"), "GET /orders/42")
+
+	entry, _ := mem.Find("", "Order not found")
+	if ctx.contentType != "application/problem+json" || entry.Fields["response_content_type"] != ctx.contentType {
+		t.Errorf("response_content_type = %v, sent %q", entry.Fields["response_content_type"], ctx.contentType)
+	}
+	if entry.Fields["response_size"] != len(ctx.body) {
+		t.Errorf("response_size = %v, want %d", entry.Fields["response_size"], len(ctx.body))
+	}
+}
+
+func TestLogAndRespondWithRecordsResponseFields(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	ctx := newTestContext("GET", "/orders/42")
+	LogAndRespondWith(ctx, NewSystemError(errors.New("db down")), "GET /orders/42", func(appErr *AppError) interface{} {
+		return map[string]interface{}{"code": appErr.Code}
+	})
+
+	entry, _ := mem.Find("", "")
+	if entry.Fields["response_content_type"] != "application/json" || entry.Fields["response_size"] != len(`{"code":500}`) {
+		t.Errorf("response fields = %v, %v", entry.Fields["response_content_type"], entry.Fields["response_size"])
+	}
+}
+
+func TestLogRequestErrorHasNoResponseFields(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	LogRequestError(newTestContext("GET", "/orders/42"), NewSystemError(errors.New("db down")), "GET /orders/42")
+
+	entry, _ := mem.Find("", "")
+	if _, ok := entry.Fields["response_size"]; ok {
+		t.Errorf("response_size logged without a response: %v", entry.Fields)
+	}
+}
@@ -0,0 +1,169 @@
+package goerrorkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// ResponseWriter ghi error response ra HTTPContext, độc lập với framework
+// Cho phép dùng chung một format response (problem details, envelope, XML, ...) cho mọi adapter
+type ResponseWriter interface {
+	WriteError(ctx HTTPContext, appErr *AppError) error
+}
+
+// ResponseWriterFunc cho phép dùng function làm ResponseWriter
+type ResponseWriterFunc func(ctx HTTPContext, appErr *AppError) error
+
+// WriteError implements ResponseWriter
+func (f ResponseWriterFunc) WriteError(ctx HTTPContext, appErr *AppError) error {
+	return f(ctx, appErr)
+}
+
+// Tên các ResponseWriter built-in
+const (
+	ResponseWriterJSON    = "json"    // JSON từ ResponseEncoder (mặc định FormatErrorResponse)
+	ResponseWriterProblem = "problem" // RFC 7807 application/problem+json
+)
+
+var (
+	responseWritersMu sync.RWMutex
+	responseWriters   = map[string]ResponseWriter{
+		ResponseWriterJSON:    ResponseWriterFunc(writeJSONResponse),
+		ResponseWriterProblem: ResponseWriterFunc(writeProblemResponse),
+	}
+	activeResponseWriter ResponseWriter = ResponseWriterFunc(writeJSONResponse)
+)
+
+// RegisterResponseWriter đăng ký ResponseWriter theo tên (ghi đè nếu tên đã tồn tại)
+// Dùng SetResponseWriter để chọn writer được LogAndRespond sử dụng
+//
+// Example:
+//
+//	goerrorkit.RegisterResponseWriter("envelope", goerrorkit.ResponseWriterFunc(
+//	    func(ctx goerrorkit.HTTPContext, appErr *goerrorkit.AppError) error {
+//	        return ctx.Status(appErr.Code).JSON(map[string]interface{}{
+//	            "success": false,
+//	            "error":   goerrorkit.FormatErrorResponse(appErr),
+//	        })
+//	    }))
+//	goerrorkit.SetResponseWriter("envelope")
+func RegisterResponseWriter(name string, w ResponseWriter) {
+	if w == nil {
+		return
+	}
+	responseWritersMu.Lock()
+	responseWriters[name] = w
+	responseWritersMu.Unlock()
+}
+
+// SetResponseWriter chọn ResponseWriter đã đăng ký làm writer mặc định cho mọi adapter
+// Built-in: "json" (mặc định), "problem" (RFC 7807)
+func SetResponseWriter(name string) error {
+	responseWritersMu.Lock()
+	defer responseWritersMu.Unlock()
+	w, ok := responseWriters[name]
+	if !ok {
+		return fmt.Errorf("goerrorkit: response writer %q is not registered", name)
+	}
+	activeResponseWriter = w
+	return nil
+}
+
+// getResponseWriter trả về ResponseWriter đang được chọn
+func getResponseWriter() ResponseWriter {
+	responseWritersMu.RLock()
+	defer responseWritersMu.RUnlock()
+	return activeResponseWriter
+}
+
+// ResponseEncoder quyết định status code và body (JSON) của error response
+type ResponseEncoder func(appErr *AppError, ctx HTTPContext) (status int, body interface{})
+
+// DefaultResponseEncoder trả về appErr.Code và FormatErrorResponse(appErr)
+// với message được dịch theo Accept-Language của request (xem SetTranslator)
+func DefaultResponseEncoder(appErr *AppError, ctx HTTPContext) (int, interface{}) {
+	return appErr.Code, FormatLocalizedErrorResponse(appErr, requestLanguage(ctx))
+}
+
+var (
+	responseEncoderMu sync.RWMutex
+	responseEncoder   ResponseEncoder = DefaultResponseEncoder
+)
+
+// SetResponseEncoder thay đổi status/body của response do writer "json" (mặc định) gửi
+// Dùng khi chỉ cần đổi payload mà vẫn gửi JSON; truyền nil để dùng lại DefaultResponseEncoder
+//
+// Example:
+//
+//	// Envelope cho API v2, giữ nguyên format cũ cho các path khác
+//	goerrorkit.SetResponseEncoder(func(appErr *goerrorkit.AppError, ctx goerrorkit.HTTPContext) (int, interface{}) {
+//	    status, body := goerrorkit.DefaultResponseEncoder(appErr, ctx)
+//	    if strings.HasPrefix(ctx.Path(), "/api/v2/") {
+//	        return status, map[string]interface{}{
+//	            "success": false,
+//	            "error":   body,
+//	            "meta":    map[string]interface{}{"request_id": appErr.RequestID},
+//	        }
+//	    }
+//	    return status, body
+//	})
+func SetResponseEncoder(encoder ResponseEncoder) {
+	if encoder == nil {
+		encoder = DefaultResponseEncoder
+	}
+	responseEncoderMu.Lock()
+	responseEncoder = encoder
+	responseEncoderMu.Unlock()
+}
+
+// getResponseEncoder trả về ResponseEncoder hiện tại
+func getResponseEncoder() ResponseEncoder {
+	responseEncoderMu.RLock()
+	defer responseEncoderMu.RUnlock()
+	return responseEncoder
+}
+
+// writeJSONResponse là writer mặc định: headers + body từ ResponseEncoder dạng JSON
+func writeJSONResponse(ctx HTTPContext, appErr *AppError) error {
+	writeHeaders(ctx, appErr)
+	status, body := getResponseEncoder()(appErr, ctx)
+	return ctx.Status(status).JSON(body)
+}
+
+// writeProblemResponse ghi RFC 7807 problem details
+// Content-Type application/problem+json chỉ được set khi HTTPContext implement BodySender,
+// ngược lại fallback về ctx.JSON (application/json)
+func writeProblemResponse(ctx HTTPContext, appErr *AppError) error {
+	writeHeaders(ctx, appErr)
+
+	lang := requestLanguage(ctx)
+	problem := map[string]interface{}{
+		"type":   "about:blank",
+		"title":  http.StatusText(appErr.Code),
+		"status": appErr.Code,
+		"detail": appErr.LocalizedMessage(lang),
+	}
+	// Giữ các field extension (error_type, request_id, ref, ...) từ FormatErrorResponse
+	for k, v := range FormatLocalizedErrorResponse(appErr, lang) {
+		switch k {
+		case "error":
+			// đã có trong "detail"
+		case "type":
+			problem["error_type"] = v
+		default:
+			problem[k] = v
+		}
+	}
+
+	if bs, ok := ctx.(BodySender); ok {
+		body, err := json.Marshal(problem)
+		if err != nil {
+			return err
+		}
+		ctx.Status(appErr.Code)
+		return bs.SendBody("application/problem+json", body)
+	}
+	return ctx.Status(appErr.Code).JSON(problem)
+}
@@ -0,0 +1,56 @@
+package goerrorkit
+
+import (
+	"strings"
+	"testing"
+)
+
+// envelopeEncoder bọc response mặc định trong {"success": false, "error": ..., "meta": ...} cho /api/v2
+func envelopeEncoder(appErr *AppError, ctx HTTPContext) (int, interface{}) {
+	status, body := DefaultResponseEncoder(appErr, ctx)
+	if !strings.HasPrefix(ctx.Path(), "/api/v2/") {
+		return status, body
+	}
+	return 200, map[string]interface{}{
+		"success": false,
+		"error":   body,
+		"meta":    map[string]interface{}{"path": ctx.Path()},
+	}
+}
+
+func TestSetResponseEncoder(t *testing.T) {
+	SetResponseEncoder(envelopeEncoder)
+	defer SetResponseEncoder(nil)
+
+	ctx := newTestContext("GET", "/api/v2/orders/42")
+	LogAndRespond(ctx, NewBusinessError(404, "Order not found"), "GET /api/v2/orders/42")
+
+	if ctx.status != 200 || ctx.jsonCalls != 1 {
+		t.Errorf("status = %d, jsonCalls = %d, want encoder status sent as JSON", ctx.status, ctx.jsonCalls)
+	}
+	resp := ctx.response()
+	inner, _ := resp["error"].(map[string]interface{})
+	if resp["success"] != false || inner["error"] != "Order not found" || inner["type"] != "BUSINESS" {
+		t.Errorf("body = %s, want envelope around default response", ctx.body)
+	}
+
+	// Encoder giữ format cũ cho path khác
+	ctx = newTestContext("GET", "/api/v1/orders/42")
+	LogAndRespond(ctx, NewBusinessError(404, "Order not found"), "GET /api/v1/orders/42")
+	if ctx.status != 404 || ctx.response()["error"] != "Order not found" {
+		t.Errorf("v1 response = %d %s, want default", ctx.status, ctx.body)
+	}
+}
+
+func TestSetResponseEncoderNilRestoresDefault(t *testing.T) {
+	SetResponseEncoder(func(appErr *AppError, ctx HTTPContext) (int, interface{}) {
+		return 418, "teapot"
+	})
+	SetResponseEncoder(nil)
+
+	ctx := newTestContext("GET", "/orders/42")
+	LogAndRespond(ctx, NewBusinessError(404, "Order not found"), "GET /orders/42")
+	if ctx.status != 404 || ctx.response()["error"] != "Order not found" {
+		t.Errorf("response = %d %s, want DefaultResponseEncoder", ctx.status, ctx.body)
+	}
+}
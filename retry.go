@@ -0,0 +1,74 @@
+package goerrorkit
+
+import (
+	"sync"
+	"time"
+)
+
+// RetryAttempt là thông tin một lần thử thất bại
+type RetryAttempt struct {
+	Attempt int       `json:"attempt"` // Số thứ tự lần thử (bắt đầu từ 1)
+	At      time.Time `json:"at"`      // Thời điểm thất bại
+	Error   string    `json:"error"`   // Message của error
+}
+
+// RetryRecorder ghi lại lịch sử các lần retry thất bại
+// An toàn khi dùng đồng thời
+//
+// Example:
+//
+//	recorder := goerrorkit.NewRetryRecorder()
+//	for i := 0; i < 3; i++ {
+//	    if err = callPaymentGateway(); err == nil {
+//	        return nil
+//	    }
+//	    recorder.Record(err)
+//	    time.Sleep(backoff(i))
+//	}
+//	return goerrorkit.NewExternalError(502, "Payment gateway unavailable", err).
+//	    WithRetryHistory(recorder)
+type RetryRecorder struct {
+	mu       sync.Mutex
+	attempts []RetryAttempt
+}
+
+// NewRetryRecorder tạo RetryRecorder mới
+func NewRetryRecorder() *RetryRecorder {
+	return &RetryRecorder{}
+}
+
+// Record ghi lại một lần thử thất bại
+func (r *RetryRecorder) Record(err error) {
+	msg := ""
+	if err != nil {
+		msg = err.Error()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.attempts = append(r.attempts, RetryAttempt{
+		Attempt: len(r.attempts) + 1,
+		At:      time.Now(),
+		Error:   msg,
+	})
+}
+
+// Attempts trả về bản copy của lịch sử các lần thử
+func (r *RetryRecorder) Attempts() []RetryAttempt {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]RetryAttempt{}, r.attempts...)
+}
+
+// WithRetryHistory gắn lịch sử retry vào Details["retry_history"]
+// Log sẽ có field "retry_history" dạng array (attempt, at, error)
+func (e *AppError) WithRetryHistory(recorder *RetryRecorder) *AppError {
+	if recorder == nil {
+		return e
+	}
+	if e.Details == nil {
+		e.Details = make(map[string]interface{})
+	}
+	e.Details["retry_history"] = recorder.Attempts()
+	return e
+}
@@ -0,0 +1,85 @@
+package goerrorkit
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithRetryHistoryLogged(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	before := time.Now()
+	recorder := NewRetryRecorder()
+	var err error
+	for i := 1; i <= 3; i++ {
+		err = fmt.Errorf("gateway timeout (attempt %d)", i)
+		recorder.Record(err)
+	}
+	LogError(NewExternalError(502, "Payment gateway unavailable", err).WithRetryHistory(recorder), "POST /payments")
+
+	entry, ok := mem.Find("", "Payment gateway unavailable")
+	if !ok {
+		t.Fatalf("error not logged: %v", mem.Entries())
+	}
+	history, ok := entry.Fields["retry_history"].([]interface{})
+	if !ok || len(history) != 3 {
+		t.Fatalf("retry_history = %#v, want 3 attempts", entry.Fields["retry_history"])
+	}
+	var prev time.Time
+	for i, raw := range history {
+		attempt, ok := raw.(map[string]interface{})
+		if !ok {
+			t.Fatalf("retry_history[%d] = %#v, want object", i, raw)
+		}
+		if attempt["attempt"] != i+1 || attempt["error"] != fmt.Sprintf("gateway timeout (attempt %d)", i+1) {
+			t.Errorf("retry_history[%d] = %v", i, attempt)
+		}
+		at, _ := attempt["at"].(time.Time)
+		if at.Before(before) || at.Before(prev) {
+			t.Errorf("retry_history[%d].at = %v, want increasing timestamps after %v", i, at, before)
+		}
+		prev = at
+	}
+}
+
+func TestRetryRecorderAttemptsIsCopy(t *testing.T) {
+	recorder := NewRetryRecorder()
+	recorder.Record(errors.New("first"))
+	recorder.Record(nil)
+
+	attempts := recorder.Attempts()
+	attempts[0].Error = "changed"
+	if got := recorder.Attempts(); got[0].Error != "first" || got[1].Error != "" || got[1].Attempt != 2 {
+		t.Errorf("Attempts() = %+v", got)
+	}
+
+	appErr := NewSystemError(nil).WithRetryHistory(nil)
+	if _, ok := appErr.Details["retry_history"]; ok {
+		t.Error("WithRetryHistory(nil) set retry_history")
+	}
+}
+
+func TestRetryRecorderConcurrent(t *testing.T) {
+	recorder := NewRetryRecorder()
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			recorder.Record(errors.New("timeout"))
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[int]bool)
+	for _, a := range recorder.Attempts() {
+		seen[a.Attempt] = true
+	}
+	if len(seen) != 20 {
+		t.Errorf("attempt numbers = %v, want 1..20 without duplicates", seen)
+	}
+}
@@ -0,0 +1,195 @@
+package goerrorkit
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// timeRotatingWriter rotate file log theo thời gian (LoggerOptions.RotateDaily / RotateInterval)
+// Mỗi khoảng thời gian ghi vào một file riêng: errors.log → errors-2025-11-28.log
+// Trong cùng khoảng, lumberjack vẫn rotate theo MaxFileSize như bình thường
+//
+// Rotation xảy ra trong Write dưới mutex: record kích hoạt rotation được ghi vào file MỚI,
+// không record nào bị mất hay ghi lẫn vào file cũ khi nhiều goroutine ghi đồng thời
+type timeRotatingWriter struct {
+	mu sync.Mutex
+
+	basePath   string        // opts.FilePath, dùng để sinh tên file theo thời gian
+	daily      bool          // rotate lúc 0h theo giờ local
+	interval   time.Duration // rotate theo khoảng cố định (khi daily = false)
+	maxSize    int
+	maxBackups int
+	maxAge     int
+	now        func() time.Time // inject clock (mặc định time.Now)
+
+	current *lumberjack.Logger
+	period  time.Time // thời điểm bắt đầu khoảng hiện tại
+}
+
+// newTimeRotatingWriter tạo writer rotate theo thời gian dựa trên LoggerOptions
+// RotateDaily được ưu tiên hơn RotateInterval
+func newTimeRotatingWriter(opts LoggerOptions) *timeRotatingWriter {
+	return &timeRotatingWriter{
+		basePath:   opts.FilePath,
+		daily:      opts.RotateDaily,
+		interval:   opts.RotateInterval,
+		maxSize:    opts.MaxFileSize,
+		maxBackups: opts.MaxBackups,
+		maxAge:     opts.MaxAge,
+		now:        time.Now,
+	}
+}
+
+// Write implements io.Writer
+func (w *timeRotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	start := w.periodStart(w.now())
+	opened := false
+	if w.current == nil || !start.Equal(w.period) {
+		if w.current != nil {
+			_ = w.current.Close()
+		}
+		w.period = start
+		w.current = &lumberjack.Logger{
+			Filename:   w.filename(start),
+			MaxSize:    w.maxSize,
+			MaxBackups: w.maxBackups,
+			MaxAge:     w.maxAge,
+			Compress:   true,
+			LocalTime:  true,
+		}
+		opened = true
+	}
+	n, err := w.current.Write(p)
+	w.mu.Unlock()
+
+	// Dọn file của các khoảng cũ theo MaxAge/MaxBackups (ngoài lock để không chặn Write khác)
+	if opened {
+		w.cleanup(start)
+	}
+	return n, err
+}
+
+// Close đóng file hiện tại
+func (w *timeRotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.current == nil {
+		return nil
+	}
+	err := w.current.Close()
+	w.current = nil
+	return err
+}
+
+// currentFilename trả về file sẽ được ghi nếu Write được gọi ngay bây giờ
+func (w *timeRotatingWriter) currentFilename() string {
+	return w.filename(w.periodStart(w.now()))
+}
+
+// periodStart trả về thời điểm bắt đầu khoảng rotate chứa t
+func (w *timeRotatingWriter) periodStart(t time.Time) time.Time {
+	if w.daily {
+		y, m, d := t.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	}
+	return t.Truncate(w.interval)
+}
+
+// layout trả về time layout trong tên file, đủ chi tiết để mỗi khoảng có tên riêng
+func (w *timeRotatingWriter) layout() string {
+	switch {
+	case w.daily || w.interval%(24*time.Hour) == 0:
+		return "2006-01-02"
+	case w.interval%time.Minute == 0:
+		return "2006-01-02T15-04"
+	default:
+		return "2006-01-02T15-04-05"
+	}
+}
+
+// filename sinh tên file cho khoảng bắt đầu tại start: logs/errors.log → logs/errors-2025-11-28.log
+func (w *timeRotatingWriter) filename(start time.Time) string {
+	ext := filepath.Ext(w.basePath)
+	return strings.TrimSuffix(w.basePath, ext) + "-" + start.Format(w.layout()) + ext
+}
+
+// cleanup dọn file của các khoảng cũ, giống ngữ nghĩa của lumberjack. Lỗi được bỏ qua
+//   - Mọi file của khoảng active (file chính và backup lumberjack errors-<date>-<timestamp>.log.gz) được giữ nguyên
+//   - MaxBackups áp dụng cho từng khoảng: mỗi khoảng cũ giữ file chính và MaxBackups backup mới nhất
+//     (lumberjack làm việc này khi khoảng còn active; cleanup bảo đảm cả khi process dừng giữa chừng)
+//   - MaxAge (ngày) áp dụng cho mọi file của các khoảng cũ theo thời gian sửa đổi
+func (w *timeRotatingWriter) cleanup(active time.Time) {
+	if w.maxAge <= 0 && w.maxBackups <= 0 {
+		return
+	}
+
+	dir := filepath.Dir(w.basePath)
+	ext := filepath.Ext(w.basePath)
+	prefix := strings.TrimSuffix(filepath.Base(w.basePath), ext) + "-"
+	layout := w.layout()
+	activeStamp := active.Format(layout)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	type backupFile struct {
+		path    string
+		modTime time.Time
+	}
+	var expired []string
+	backups := make(map[string][]backupFile) // stamp của khoảng → backup lumberjack
+	cutoff := w.now().Add(-time.Duration(w.maxAge) * 24 * time.Hour)
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if !strings.HasSuffix(name, ext) && !strings.HasSuffix(name, ext+".gz") {
+			continue
+		}
+		// Chỉ xoá file do writer này sinh ra (phần sau prefix bắt đầu bằng timestamp đúng layout)
+		rest := strings.TrimPrefix(name, prefix)
+		if len(rest) < len(layout) {
+			continue
+		}
+		stamp := rest[:len(layout)]
+		if _, err := time.Parse(layout, stamp); err != nil || stamp == activeStamp {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		if w.maxAge > 0 && info.ModTime().Before(cutoff) {
+			expired = append(expired, path)
+			continue
+		}
+		if rest != stamp+ext {
+			backups[stamp] = append(backups[stamp], backupFile{path: path, modTime: info.ModTime()})
+		}
+	}
+
+	for _, path := range expired {
+		_ = os.Remove(path)
+	}
+	if w.maxBackups <= 0 {
+		return
+	}
+	for _, files := range backups {
+		sort.Slice(files, func(i, j int) bool { return files[i].modTime.After(files[j].modTime) })
+		for _, f := range files[min(w.maxBackups, len(files)):] {
+			_ = os.Remove(f.path)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package goerrorkit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestRotatingWriter tạo timeRotatingWriter ghi vào thư mục tạm với clock giả
+func newTestRotatingWriter(t *testing.T, opts LoggerOptions) (*timeRotatingWriter, *fakeClock, string) {
+	t.Helper()
+	dir := t.TempDir()
+	opts.FilePath = filepath.Join(dir, "errors.log")
+	clock := newFakeClock()
+	w := newTimeRotatingWriter(opts)
+	w.now = clock.Now
+	t.Cleanup(func() { _ = w.Close() })
+	return w, clock, dir
+}
+
+func readLogFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatalf("read %s: %v", name, err)
+	}
+	return string(data)
+}
+
+func listLogFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestTimeRotatingWriterDaily(t *testing.T) {
+	w, clock, dir := newTestRotatingWriter(t, LoggerOptions{RotateDaily: true})
+
+	if got := filepath.Base(w.currentFilename()); got != "errors-2025-11-28.log" {
+		t.Errorf("currentFilename = %s", got)
+	}
+	_, _ = w.Write([]byte("first\n"))
+	clock.Advance(13*time.Hour + 59*time.Minute) // 23:59 cùng ngày
+	_, _ = w.Write([]byte("second\n"))
+	clock.Advance(time.Minute) // 0h ngày mới: record này phải vào file mới
+	_, _ = w.Write([]byte("third\n"))
+
+	if got := readLogFile(t, dir, "errors-2025-11-28.log"); got != "first\nsecond\n" {
+		t.Errorf("errors-2025-11-28.log = %q", got)
+	}
+	if got := readLogFile(t, dir, "errors-2025-11-29.log"); got != "third\n" {
+		t.Errorf("errors-2025-11-29.log = %q", got)
+	}
+}
+
+func TestTimeRotatingWriterInterval(t *testing.T) {
+	w, clock, dir := newTestRotatingWriter(t, LoggerOptions{RotateInterval: time.Hour})
+
+	_, _ = w.Write([]byte("a\n"))
+	clock.Advance(30 * time.Minute)
+	_, _ = w.Write([]byte("b\n"))
+	clock.Advance(30 * time.Minute)
+	_, _ = w.Write([]byte("c\n"))
+
+	want := []string{"errors-2025-11-28T10-00.log", "errors-2025-11-28T11-00.log"}
+	if got := listLogFiles(t, dir); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("files = %v, want %v", got, want)
+	}
+	if got := readLogFile(t, dir, want[0]); got != "a\nb\n" {
+		t.Errorf("%s = %q", want[0], got)
+	}
+	if got := readLogFile(t, dir, want[1]); got != "c\n" {
+		t.Errorf("%s = %q", want[1], got)
+	}
+}
+
+func TestTimeRotatingWriterConcurrentRotation(t *testing.T) {
+	w, clock, dir := newTestRotatingWriter(t, LoggerOptions{RotateInterval: time.Minute})
+
+	const writers, perWriter = 8, 50
+	var wg sync.WaitGroup
+	for g := 0; g < writers; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				_, _ = fmt.Fprintf(w, "writer-%d-%d\n", g, i)
+				if g == 0 && i%10 == 0 {
+					clock.Advance(time.Minute)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	_ = w.Close()
+
+	seen := make(map[string]int)
+	files := listLogFiles(t, dir)
+	if len(files) < 2 {
+		t.Fatalf("files = %v, want several periods", files)
+	}
+	for _, name := range files {
+		for _, line := range strings.Split(strings.TrimSuffix(readLogFile(t, dir, name), "\n"), "\n") {
+			seen[line]++
+		}
+	}
+	for g := 0; g < writers; g++ {
+		for i := 0; i < perWriter; i++ {
+			if line := fmt.Sprintf("writer-%d-%d", g, i); seen[line] != 1 {
+				t.Errorf("%s written %d times", line, seen[line])
+			}
+		}
+	}
+}
+
+func TestTimeRotatingWriterCleanup(t *testing.T) {
+	w, clock, dir := newTestRotatingWriter(t, LoggerOptions{RotateDaily: true, MaxBackups: 1, MaxAge: 90})
+	now := clock.Now()
+
+	create := func(name string, age time.Duration) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("x\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		mod := now.Add(-age)
+		if err := os.Chtimes(path, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Khoảng active: backup lumberjack luôn được giữ, kể cả khi cũ hơn MaxAge
+	create("errors-2025-11-28-2025-11-28T09-00-00.000.log.gz", 200*24*time.Hour)
+	create("errors-2025-11-28-2025-11-28T09-30-00.000.log.gz", time.Hour)
+	// Khoảng cũ trong MaxAge: giữ file chính và MaxBackups backup mới nhất
+	create("errors-2025-11-27.log", 10*time.Hour)
+	create("errors-2025-11-27-2025-11-27T20-00-00.000.log.gz", 14*time.Hour)
+	create("errors-2025-11-27-2025-11-27T18-00-00.000.log.gz", 16*time.Hour)
+	create("errors-2025-11-27-2025-11-27T16-00-00.000.log.gz", 18*time.Hour)
+	// Các khoảng cũ khác: MaxBackups không đếm gộp giữa các khoảng
+	create("errors-2025-11-20.log", 8*24*time.Hour)
+	create("errors-2025-11-19.log", 9*24*time.Hour)
+	// Quá MaxAge: bị xoá (cả file chính và backup)
+	create("errors-2025-08-01.log", 119*24*time.Hour)
+	create("errors-2025-08-01-2025-08-01T12-00-00.000.log.gz", 119*24*time.Hour)
+	// Không phải file do writer sinh ra
+	create("errors-notes.log", 300*24*time.Hour)
+	create("other.log", 300*24*time.Hour)
+
+	_, _ = w.Write([]byte("now\n"))
+
+	want := []string{
+		"errors-2025-11-19.log",
+		"errors-2025-11-20.log",
+		"errors-2025-11-27-2025-11-27T20-00-00.000.log.gz",
+		"errors-2025-11-27.log",
+		"errors-2025-11-28-2025-11-28T09-00-00.000.log.gz",
+		"errors-2025-11-28-2025-11-28T09-30-00.000.log.gz",
+		"errors-2025-11-28.log",
+		"errors-notes.log",
+		"other.log",
+	}
+	if got := listLogFiles(t, dir); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("files after cleanup:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestTimeRotatingWriterCleanupOnRotation(t *testing.T) {
+	w, clock, dir := newTestRotatingWriter(t, LoggerOptions{RotateDaily: true, MaxAge: 1})
+
+	_, _ = w.Write([]byte("day1\n"))
+	_, _ = w.Write([]byte("day1 again\n"))
+	old := clock.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, "errors-2025-11-28.log"), old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	// Sang ngày mới: file của ngày cũ quá MaxAge bị xoá
+	clock.Advance(24 * time.Hour)
+	_, _ = w.Write([]byte("day2\n"))
+	if got := listLogFiles(t, dir); strings.Join(got, ",") != "errors-2025-11-29.log" {
+		t.Errorf("files = %v, want only errors-2025-11-29.log", got)
+	}
+}
+
+func TestLoggerOptionsRotateDaily(t *testing.T) {
+	dir := t.TempDir()
+	before := time.Now().Format("2006-01-02")
+	logger, _ := newTestLogrusLogger(t, LoggerOptions{
+		FileOutput:   true,
+		FilePath:     filepath.Join(dir, "errors.log"),
+		JSONFormat:   true,
+		FileLogLevel: "error",
+		RotateDaily:  true,
+	})
+	logger.Error("Internal server error", nil)
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+	after := time.Now().Format("2006-01-02")
+
+	// File mang tên theo ngày; không có errors.log không ngày tháng
+	files := listLogFiles(t, dir)
+	if len(files) != 1 || (files[0] != "errors-"+before+".log" && files[0] != "errors-"+after+".log") {
+		t.Fatalf("files = %v, want errors-%s.log", files, after)
+	}
+	if got := readLogFile(t, dir, files[0]); !strings.Contains(got, "Internal server error") {
+		t.Errorf("%s = %q", files[0], got)
+	}
+}
+
+func TestLoggerOptionsRotateIntervalNegative(t *testing.T) {
+	_, err := newLogrusLogger(LoggerOptions{ConsoleOutput: true, RotateInterval: -time.Hour})
+	if err == nil || !strings.Contains(err.Error(), "RotateInterval") {
+		t.Errorf("err = %v, want RotateInterval error", err)
+	}
+}
@@ -0,0 +1,322 @@
+package goerrorkit
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultSamplingWindow là khoảng thời gian mặc định của một sampling window
+const defaultSamplingWindow = time.Minute
+
+// defaultSamplingMaxKeys là số sample key tối đa được theo dõi cùng lúc (giới hạn memory)
+const defaultSamplingMaxKeys = 10000
+
+// defaultSummaryFlushInterval là chu kỳ mặc định kiểm tra window đã kết thúc để emit summary
+// (dùng chung cho sampling và dedup)
+const defaultSummaryFlushInterval = time.Second
+
+// SamplingRule cấu hình sampling cho một log level
+type SamplingRule struct {
+	// First - Số entry đầu tiên mỗi key được log trong mỗi Window
+	First int
+
+	// Thereafter - Sau First entries, chỉ log 1 trên Thereafter entries (0: bỏ hết)
+	Thereafter int
+
+	// Window - Độ dài mỗi sampling window (mặc định 1 phút)
+	Window time.Duration
+}
+
+// SamplingOptions cấu hình sampling/rate limiting cho LogError
+// Level không có rule trong Rules sẽ luôn được log
+//
+// Example:
+//
+//	goerrorkit.InitLogger(goerrorkit.LoggerOptions{
+//	    // ...
+//	    Sampling: &goerrorkit.SamplingOptions{
+//	        Rules: map[string]goerrorkit.SamplingRule{
+//	            "warn": {First: 10, Thereafter: 100, Window: time.Minute},
+//	            // "error" không có rule → luôn log
+//	        },
+//	    },
+//	})
+type SamplingOptions struct {
+	// Rules - Sampling rule theo log level (trace, debug, info, warn, error, panic)
+	Rules map[string]SamplingRule
+
+	// KeyFunc - Tính sample key cho AppError (mặc định: ErrorType + Code + file:line)
+	KeyFunc func(appErr *AppError) string
+
+	// MaxKeys - Số sample key tối đa được theo dõi (mặc định 10000). Khi đầy, key mới
+	// dùng chung một bucket "overflow" theo level (vẫn bị rate limit)
+	MaxKeys int
+
+	// FlushInterval - Chu kỳ background flush emit summary của window đã kết thúc
+	// (mặc định 1 giây), không cần chờ tới lần LogError kế tiếp
+	FlushInterval time.Duration
+
+	// Clock - Nguồn thời gian, inject được cho test (mặc định time.Now)
+	Clock func() time.Time
+}
+
+// sampleBucket đếm số entry của một key trong window hiện tại
+type sampleBucket struct {
+	level       string
+	windowStart time.Time
+	window      time.Duration
+	count       int
+	suppressed  int
+}
+
+// sampler áp dụng SamplingOptions, an toàn khi dùng đồng thời
+type sampler struct {
+	opts SamplingOptions
+
+	mu        sync.Mutex
+	buckets   map[string]*sampleBucket
+	lastSweep time.Time
+
+	stop chan struct{} // đóng khi sampler bị thay thế (dừng background flush)
+}
+
+// samplingSummary là thông tin summary của một key đã hết window
+type samplingSummary struct {
+	key        string
+	level      string
+	suppressed int
+	window     time.Duration
+}
+
+// defaultSampler là sampler hiện tại (nil: không sampling)
+var (
+	samplerMu      sync.RWMutex
+	defaultSampler *sampler
+)
+
+// SetSampling bật sampling cho LogError (truyền nil để tắt)
+// InitLogger gọi SetSampling(opts.Sampling) khi LoggerOptions.Sampling khác nil
+// Summary đang chờ của sampler cũ được emit ngay khi bị thay thế
+func SetSampling(opts *SamplingOptions) {
+	var s *sampler
+	if opts != nil {
+		s = &sampler{
+			opts:    *opts,
+			buckets: make(map[string]*sampleBucket),
+			stop:    make(chan struct{}),
+		}
+		if s.opts.KeyFunc == nil {
+			s.opts.KeyFunc = defaultSampleKey
+		}
+		if s.opts.MaxKeys <= 0 {
+			s.opts.MaxKeys = defaultSamplingMaxKeys
+		}
+		if s.opts.FlushInterval <= 0 {
+			s.opts.FlushInterval = defaultSummaryFlushInterval
+		}
+		if s.opts.Clock == nil {
+			s.opts.Clock = time.Now
+		}
+		go runSummaryFlusher(s.opts.FlushInterval, s.stop, s.flushExpired)
+	}
+
+	samplerMu.Lock()
+	prev := defaultSampler
+	defaultSampler = s
+	samplerMu.Unlock()
+
+	if prev != nil {
+		close(prev.stop)
+		prev.flushPending()
+	}
+}
+
+// getSampler trả về sampler hiện tại (nil nếu không bật)
+func getSampler() *sampler {
+	samplerMu.RLock()
+	defer samplerMu.RUnlock()
+	return defaultSampler
+}
+
+// defaultSampleKey là sample key mặc định: ErrorType + Code + file:line
+func defaultSampleKey(appErr *AppError) string {
+	return fmt.Sprintf("%s|%d|%v", appErr.Type, appErr.Code, appErr.Details["file"])
+}
+
+// allow quyết định có log AppError ở level này không
+// Trả về thêm các summary của những key đã hết window (cần được emit bởi caller)
+func (s *sampler) allow(appErr *AppError, level string) (bool, []samplingSummary) {
+	rule, ok := s.opts.Rules[level]
+	now := s.opts.Clock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	summaries := s.sweep(now, false)
+	if !ok {
+		return true, summaries
+	}
+
+	window := rule.Window
+	if window <= 0 {
+		window = defaultSamplingWindow
+	}
+
+	key := s.opts.KeyFunc(appErr)
+	bucket, exists := s.buckets[key]
+	if !exists && len(s.buckets) >= s.opts.MaxKeys {
+		// Giới hạn memory: sweep ngay khi đầy, nếu vẫn đầy thì dùng chung bucket overflow của level
+		summaries = append(summaries, s.sweep(now, true)...)
+		if len(s.buckets) >= s.opts.MaxKeys {
+			key = "overflow|" + level
+			bucket, exists = s.buckets[key]
+		}
+	}
+	if !exists {
+		bucket = &sampleBucket{level: level, windowStart: now, window: window}
+		s.buckets[key] = bucket
+	}
+
+	bucket.count++
+	if bucket.count <= rule.First {
+		return true, summaries
+	}
+	if rule.Thereafter > 0 && (bucket.count-rule.First)%rule.Thereafter == 0 {
+		return true, summaries
+	}
+
+	bucket.suppressed++
+	return false, summaries
+}
+
+// sweep xóa các bucket đã hết window và trả về summary cho các bucket có entry bị suppress
+// Chạy tối đa một lần mỗi giây để giữ chi phí thấp (trừ khi force)
+func (s *sampler) sweep(now time.Time, force bool) []samplingSummary {
+	if !force && now.Sub(s.lastSweep) < time.Second {
+		return nil
+	}
+	s.lastSweep = now
+
+	var summaries []samplingSummary
+	for key, bucket := range s.buckets {
+		if now.Sub(bucket.windowStart) < bucket.window {
+			continue
+		}
+		if bucket.suppressed > 0 {
+			summaries = append(summaries, samplingSummary{
+				key:        key,
+				level:      bucket.level,
+				suppressed: bucket.suppressed,
+				window:     bucket.window,
+			})
+		}
+		delete(s.buckets, key)
+	}
+	return summaries
+}
+
+// flushExpired emit summary của các bucket đã hết window (gọi bởi background flush)
+func (s *sampler) flushExpired() {
+	now := s.opts.Clock()
+	s.mu.Lock()
+	summaries := s.sweep(now, true)
+	s.mu.Unlock()
+	emitSamplingSummaries(summaries)
+}
+
+// flushPending emit summary cho mọi bucket đang có entry bị suppress, kể cả khi window chưa kết thúc
+// (FlushLogs, CloseLogger). Bucket được giữ lại nên rate limit của window hiện tại vẫn áp dụng
+func (s *sampler) flushPending() {
+	s.mu.Lock()
+	var summaries []samplingSummary
+	for key, bucket := range s.buckets {
+		if bucket.suppressed == 0 {
+			continue
+		}
+		summaries = append(summaries, samplingSummary{
+			key:        key,
+			level:      bucket.level,
+			suppressed: bucket.suppressed,
+			window:     bucket.window,
+		})
+		bucket.suppressed = 0
+	}
+	s.mu.Unlock()
+	emitSamplingSummaries(summaries)
+}
+
+// runSummaryFlusher gọi flush mỗi interval cho tới khi stop bị đóng
+// Logger panic trong lúc emit summary không được làm crash process
+func runSummaryFlusher(interval time.Duration, stop <-chan struct{}, flush func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						fmt.Fprintf(os.Stderr, "goerrorkit: logger panicked while emitting summary: %v\n", r)
+					}
+				}()
+				flush()
+			}()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// flushSummaries emit ngay summary đang chờ của sampling và dedup (FlushLogs, CloseLogger)
+func flushSummaries() {
+	if s := getSampler(); s != nil {
+		s.flushPending()
+	}
+	if d := getDeduper(); d != nil {
+		d.flushPending()
+	}
+}
+
+// emitSamplingSummaries log summary line cho các key bị suppress
+// Ví dụ: "suppressed 4,832 similar warnings"
+func emitSamplingSummaries(summaries []samplingSummary) {
+	if len(summaries) == 0 || defaultLogger == nil {
+		return
+	}
+	for _, summary := range summaries {
+		logAtLevel(summary.level, fmt.Sprintf("suppressed %s similar %s",
+			formatThousands(summary.suppressed), levelNoun(summary.level)),
+			map[string]interface{}{
+				"sample_key": summary.key,
+				"suppressed": summary.suppressed,
+				"window":     summary.window.String(),
+			})
+	}
+}
+
+// levelNoun trả về danh từ số nhiều cho log level ("warn" → "warnings")
+func levelNoun(level string) string {
+	switch level {
+	case "warn":
+		return "warnings"
+	case "error", "panic":
+		return "errors"
+	default:
+		return level + " entries"
+	}
+}
+
+// formatThousands format số với dấu phẩy ngăn cách hàng nghìn: 4832 → "4,832"
+func formatThousands(n int) string {
+	if n < 0 {
+		return "-" + formatThousands(-n)
+	}
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
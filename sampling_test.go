@@ -0,0 +1,271 @@
+package goerrorkit
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock là nguồn thời gian điều khiển được cho test sampling/dedup
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, 11, 28, 10, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+// findEntries trả về các entry có message chứa substr
+func findEntries(mem *MemoryLogger, substr string) []LogEntry {
+	var found []LogEntry
+	for _, e := range mem.Entries() {
+		if strings.Contains(e.Message, substr) {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+// waitForEntry chờ tới khi có entry chứa substr (background flush chạy trên goroutine khác)
+func waitForEntry(t *testing.T, mem *MemoryLogger, substr string) LogEntry {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if found := findEntries(mem, substr); len(found) > 0 {
+			return found[0]
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("no entry containing %q, entries: %+v", substr, mem.Entries())
+	return LogEntry{}
+}
+
+func logWarnings(n int) {
+	for i := 0; i < n; i++ {
+		LogError(NewValidationError("Invalid email", nil).Level("warn"), "POST /signup")
+	}
+}
+
+func TestSamplingCountsAndSummary(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+	clock := newFakeClock()
+	SetSampling(&SamplingOptions{
+		Rules:         map[string]SamplingRule{"warn": {First: 2, Thereafter: 5, Window: time.Minute}},
+		Clock:         clock.Now,
+		FlushInterval: time.Hour, // flush bằng tay trong test
+	})
+	defer SetSampling(nil)
+
+	logWarnings(20)
+	// 2 entry đầu + mỗi entry thứ 5 sau đó (7, 12, 17) được log
+	if got := len(findEntries(mem, "Invalid email")); got != 5 {
+		t.Fatalf("logged %d entries, want 5", got)
+	}
+
+	clock.Advance(time.Minute)
+	getSampler().flushExpired()
+
+	summary := findEntries(mem, "suppressed")
+	if len(summary) != 1 || summary[0].Message != "suppressed 15 similar warnings" || summary[0].Level != "warn" {
+		t.Fatalf("summary = %+v", summary)
+	}
+	if summary[0].Fields["suppressed"] != 15 {
+		t.Errorf("suppressed field = %v", summary[0].Fields["suppressed"])
+	}
+
+	// Window mới: đếm lại từ đầu
+	logWarnings(1)
+	if got := len(findEntries(mem, "Invalid email")); got != 6 {
+		t.Errorf("logged %d entries after window reset, want 6", got)
+	}
+}
+
+func TestSamplingBackgroundFlush(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+	clock := newFakeClock()
+	SetSampling(&SamplingOptions{
+		Rules:         map[string]SamplingRule{"warn": {First: 1, Window: time.Minute}},
+		Clock:         clock.Now,
+		FlushInterval: 5 * time.Millisecond,
+	})
+	defer SetSampling(nil)
+
+	logWarnings(4)
+	clock.Advance(time.Minute)
+
+	// Không có LogError nào sau khi window kết thúc: summary vẫn được emit bởi background flush
+	entry := waitForEntry(t, mem, "suppressed")
+	if entry.Message != "suppressed 3 similar warnings" {
+		t.Errorf("summary = %q", entry.Message)
+	}
+}
+
+func TestSamplingFlushLogsEmitsPendingSummary(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+	SetSampling(&SamplingOptions{
+		Rules:         map[string]SamplingRule{"warn": {First: 1, Window: time.Hour}},
+		Clock:         newFakeClock().Now,
+		FlushInterval: time.Hour,
+	})
+	defer SetSampling(nil)
+
+	logWarnings(3)
+	if err := FlushLogs(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if summary := findEntries(mem, "suppressed"); len(summary) != 1 || summary[0].Message != "suppressed 2 similar warnings" {
+		t.Fatalf("summary after FlushLogs = %+v", summary)
+	}
+
+	// Window vẫn còn: entry tiếp theo vẫn bị suppress, không có summary trùng
+	logWarnings(1)
+	FlushLogs(context.Background())
+	if summary := findEntries(mem, "suppressed"); len(summary) != 2 || summary[1].Message != "suppressed 1 similar warnings" {
+		t.Errorf("summaries = %+v", summary)
+	}
+}
+
+func TestSamplingMaxKeys(t *testing.T) {
+	UseMemoryLogger()
+	defer SetLogger(nil)
+	SetSampling(&SamplingOptions{
+		Rules:         map[string]SamplingRule{"warn": {First: 1, Window: time.Minute}},
+		KeyFunc:       func(appErr *AppError) string { return appErr.Message },
+		MaxKeys:       3,
+		Clock:         newFakeClock().Now,
+		FlushInterval: time.Hour,
+	})
+	defer SetSampling(nil)
+
+	for i := 0; i < 100; i++ {
+		LogError(NewValidationError(strings.Repeat("x", i+1), nil).Level("warn"), "POST /signup")
+	}
+
+	s := getSampler()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.buckets) > 4 {
+		t.Errorf("tracked %d buckets, want at most MaxKeys + overflow", len(s.buckets))
+	}
+	if overflow := s.buckets["overflow|warn"]; overflow == nil || overflow.suppressed != 96 {
+		t.Errorf("overflow bucket = %+v, want 96 suppressed", overflow)
+	}
+}
+
+func TestInstallLoggerKeepsExplicitSettings(t *testing.T) {
+	defer SetLogger(nil)
+	SetSampling(&SamplingOptions{Rules: map[string]SamplingRule{"warn": {First: 1}}})
+	defer SetSampling(nil)
+	SetDedup(&DedupOptions{Window: time.Minute})
+	defer SetDedup(nil)
+	SetIncludeClientInfo(true)
+	defer SetIncludeClientInfo(false)
+	sampler, deduper := getSampler(), getDeduper()
+
+	logger, err := newLogrusLogger(LoggerOptions{ConsoleOutput: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.consoleLogger.SetOutput(&strings.Builder{})
+	installLogger(logger, LoggerOptions{ConsoleOutput: true})
+
+	if getSampler() != sampler {
+		t.Error("InitLogger without Sampling replaced SetSampling configuration")
+	}
+	if getDeduper() != deduper {
+		t.Error("InitLogger without DedupWindow replaced SetDedup configuration")
+	}
+	if !includeClientInfo {
+		t.Error("InitLogger without IncludeClientInfo disabled SetIncludeClientInfo(true)")
+	}
+
+	installLogger(logger, LoggerOptions{ConsoleOutput: true, DedupWindow: time.Second})
+	if d := getDeduper(); d == deduper || d.opts.Window != time.Second {
+		t.Error("explicit DedupWindow not applied")
+	}
+}
+
+func TestSamplingPerLevelRules(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+	SetSampling(&SamplingOptions{
+		Rules:         map[string]SamplingRule{"warn": {First: 1, Window: time.Minute}},
+		Clock:         newFakeClock().Now,
+		FlushInterval: time.Hour,
+	})
+	defer SetSampling(nil)
+
+	logWarnings(10)
+	for i := 0; i < 10; i++ {
+		LogError(NewSystemError(errors.New("db down")), "GET /orders")
+	}
+
+	if got := len(findEntries(mem, "Invalid email")); got != 1 {
+		t.Errorf("warn: logged %d entries, want 1", got)
+	}
+	// Không có rule cho level error: luôn được log
+	errorsLogged := 0
+	for _, e := range mem.Entries() {
+		if e.Level == "error" {
+			errorsLogged++
+		}
+	}
+	if errorsLogged != 10 {
+		t.Errorf("error: logged %d entries, want 10", errorsLogged)
+	}
+}
+
+func TestSamplingKeyFunc(t *testing.T) {
+	logFromTwoSites := func() {
+		for i := 0; i < 5; i++ {
+			LogError(NewValidationError("Invalid email", nil).Level("warn"), "POST /signup")
+			LogError(NewValidationError("Invalid phone", nil).Level("warn"), "POST /signup")
+		}
+	}
+	tests := []struct {
+		name    string
+		keyFunc func(*AppError) string
+		want    int
+	}{
+		// Key mặc định gồm file:line: mỗi call site được sample riêng
+		{"default key", nil, 2},
+		{"custom key", func(appErr *AppError) string { return string(appErr.Type) }, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mem := UseMemoryLogger()
+			defer SetLogger(nil)
+			SetSampling(&SamplingOptions{
+				Rules:         map[string]SamplingRule{"warn": {First: 1, Window: time.Minute}},
+				KeyFunc:       tt.keyFunc,
+				Clock:         newFakeClock().Now,
+				FlushInterval: time.Hour,
+			})
+			defer SetSampling(nil)
+
+			logFromTwoSites()
+			if got := len(findEntries(mem, "Invalid")); got != tt.want {
+				t.Errorf("logged %d entries, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package goerrorkit
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SelfTest tạo một error mẫu cho mỗi ErrorType và trả về báo cáo dễ đọc về cấu hình hiện tại:
+// build mode, logger, log level của từng loại error, error đó có được ghi ra console/file không,
+// HTTP status và các field trong response. Không ghi log thật và không thay đổi cấu hình.
+//
+// Example:
+//
+//	goerrorkit.InitLogger(opts)
+//	goerrorkit.ConfigureForApplication("github.com/yourname/app")
+//	for _, line := range goerrorkit.SelfTest() {
+//	    fmt.Println(line)
+//	}
+func SelfTest() []string {
+	var report []string
+
+	if debugBuild {
+		report = append(report, "build mode: debug (trace/debug logs enabled)")
+	} else {
+		report = append(report, "build mode: production (trace/debug logs fall back to warn; build with -tags=debug to enable)")
+	}
+	report = append(report, "environment: "+GetEnvironment())
+
+	console, file := GetLogLevels()
+	switch {
+	case defaultLogger == nil:
+		report = append(report, "logger: not configured (call InitLogger or SetLogger) - errors will NOT be logged")
+	case console == "" && file == "":
+		report = append(report, fmt.Sprintf("logger: %T (custom logger, routing unknown)", defaultLogger))
+	default:
+		report = append(report, fmt.Sprintf("logger: %T console=%s file=%s",
+			defaultLogger, levelOrOff(console), levelOrOff(file)))
+	}
+
+	cause := errors.New("selftest cause")
+	samples := []*AppError{
+		NewValidationError("selftest validation", nil),
+		NewAuthError(401, "selftest auth"),
+		NewBusinessError(409, "selftest business"),
+		NewSystemError(cause),
+		NewExternalError(502, "selftest external", cause),
+		{Type: PanicError, Code: PanicError.DefaultHTTPStatus(), Message: "selftest panic"},
+	}
+
+	for _, appErr := range samples {
+		level := appErr.GetLogLevel()
+		response := FormatErrorResponse(appErr)
+		keys := make([]string, 0, len(response))
+		for k := range response {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		report = append(report, fmt.Sprintf("%s: level=%s status=%d console=%s file=%s response=[%s]",
+			appErr.Type, level, appErr.Code,
+			routeDescription(level, console), routeDescription(level, file),
+			strings.Join(keys, ",")))
+	}
+
+	return report
+}
+
+// levelOrOff trả về "off" cho output không bật
+func levelOrOff(level string) string {
+	if level == "" {
+		return "off"
+	}
+	return level
+}
+
+// levelRank là thứ tự của log level (càng lớn càng nghiêm trọng)
+var levelRank = map[string]int{"trace": 0, "debug": 1, "info": 2, "warn": 3, "error": 4, "panic": 5}
+
+// routeDescription mô tả entry ở level có được ghi ra output có ngưỡng threshold không
+func routeDescription(level, threshold string) string {
+	if threshold == "" {
+		return "off"
+	}
+	effective := level
+	if !debugBuild && (level == "debug" || level == "trace") {
+		effective = "warn"
+	}
+	if levelRank[effective] >= levelRank[threshold] {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,116 @@
+package goerrorkit
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// selfTestLine trả về dòng đầu tiên của report bắt đầu bằng prefix
+func selfTestLine(t *testing.T, report []string, prefix string) string {
+	t.Helper()
+	for _, line := range report {
+		if strings.HasPrefix(line, prefix) {
+			return line
+		}
+	}
+	t.Fatalf("report has no line starting with %q:\n%s", prefix, strings.Join(report, "\n"))
+	return ""
+}
+
+func TestSelfTestReportsEveryErrorType(t *testing.T) {
+	logger, _ := newTestLogrusLogger(t, LoggerOptions{
+		ConsoleOutput: true,
+		FileOutput:    true,
+		FilePath:      filepath.Join(t.TempDir(), "errors.log"),
+		LogLevel:      "warn",
+		FileLogLevel:  "error",
+	})
+	SetLogger(logger)
+	defer SetLogger(nil)
+
+	report := SelfTest()
+
+	wantMode := "build mode: production"
+	if IsDebugBuild() {
+		wantMode = "build mode: debug"
+	}
+	selfTestLine(t, report, wantMode)
+	selfTestLine(t, report, "environment: ")
+	if line := selfTestLine(t, report, "logger: "); !strings.Contains(line, "console=warn file=error") {
+		t.Errorf("logger line = %q, want console/file levels", line)
+	}
+
+	// warn: console có, file không; error: cả hai
+	tests := []struct {
+		errType ErrorType
+		want    string
+	}{
+		{ValidationError, "level=warn status=400 console=yes file=no"},
+		{AuthError, "level=warn status=401 console=yes file=no"},
+		{BusinessError, "level=error status=409 console=yes file=yes"},
+		{SystemError, "level=error status=500 console=yes file=yes"},
+		{ExternalError, "level=error status=502 console=yes file=yes"},
+		{PanicError, "level=error status=500 console=yes file=yes"},
+	}
+	for _, tt := range tests {
+		line := selfTestLine(t, report, string(tt.errType)+": ")
+		if !strings.Contains(line, tt.want) {
+			t.Errorf("%s line = %q, want %q", tt.errType, line, tt.want)
+		}
+		if !strings.Contains(line, "response=[") || !strings.Contains(line, "error") {
+			t.Errorf("%s line = %q, want response fields", tt.errType, line)
+		}
+	}
+}
+
+func TestSelfTestDoesNotLog(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	report := SelfTest()
+
+	if entries := mem.Entries(); len(entries) != 0 {
+		t.Errorf("SelfTest logged %d entries: %+v", len(entries), entries)
+	}
+	if line := selfTestLine(t, report, "logger: "); !strings.Contains(line, "routing unknown") {
+		t.Errorf("logger line = %q, want routing unknown for custom logger", line)
+	}
+}
+
+func TestSelfTestWithoutLogger(t *testing.T) {
+	SetLogger(nil)
+
+	report := SelfTest()
+
+	if line := selfTestLine(t, report, "logger: "); !strings.Contains(line, "not configured") {
+		t.Errorf("logger line = %q, want not configured warning", line)
+	}
+	selfTestLine(t, report, string(SystemError)+": ")
+}
+
+func TestRouteDescription(t *testing.T) {
+	tests := []struct {
+		level, threshold string
+		want             string
+	}{
+		{"error", "", "off"},
+		{"error", "warn", "yes"},
+		{"warn", "error", "no"},
+		{"info", "info", "yes"},
+	}
+	for _, tt := range tests {
+		if got := routeDescription(tt.level, tt.threshold); got != tt.want {
+			t.Errorf("routeDescription(%q, %q) = %q, want %q", tt.level, tt.threshold, got, tt.want)
+		}
+	}
+
+	// Production build: debug/trace được ghi ở warn
+	want := "yes"
+	if IsDebugBuild() {
+		want = "no"
+	}
+	if got := routeDescription("debug", "warn"); got != want {
+		t.Errorf("routeDescription(debug, warn) = %q, want %q", got, want)
+	}
+}
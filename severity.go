@@ -0,0 +1,49 @@
+package goerrorkit
+
+// Severity thể hiện mức độ ảnh hưởng nghiệp vụ của lỗi, dùng để route alert cho on-call
+// Độc lập với log level: log level quyết định log đi đâu ("ồn" thế nào),
+// severity quyết định cần xử lý gấp thế nào
+type Severity string
+
+const (
+	SevCritical Severity = "SEV1" // Sự cố nghiêm trọng, ảnh hưởng diện rộng - page ngay
+	SevHigh     Severity = "SEV2" // Chức năng chính bị lỗi - xử lý trong giờ
+	SevMedium   Severity = "SEV3" // Ảnh hưởng một phần, có workaround
+	SevLow      Severity = "SEV4" // Ảnh hưởng nhỏ/lỗi phía client - chỉ theo dõi
+)
+
+// Severity thiết lập severity cho error (override severity mặc định theo ErrorType)
+// Được log trong field "severity"
+//
+// Example:
+//
+//	// Thanh toán lỗi: vẫn log ở warn nhưng cần page ngay
+//	return goerrorkit.NewExternalError(502, "Payment gateway unavailable", err).
+//	    Level("warn").
+//	    Severity(goerrorkit.SevCritical)
+func (e *AppError) Severity(sev Severity) *AppError {
+	e.severity = sev
+	return e
+}
+
+// GetSeverity trả về severity của error
+// Thứ tự ưu tiên: .Severity() → mặc định theo ErrorType
+// (Panic: SEV1, System/External: SEV2, Business: SEV3, Validation/Auth: SEV4)
+func (e *AppError) GetSeverity() Severity {
+	if e.severity != "" {
+		return e.severity
+	}
+
+	switch e.Type {
+	case PanicError:
+		return SevCritical
+	case SystemError, ExternalError:
+		return SevHigh
+	case BusinessError:
+		return SevMedium
+	case ValidationError, AuthError:
+		return SevLow
+	default:
+		return SevHigh
+	}
+}
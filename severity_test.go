@@ -0,0 +1,66 @@
+package goerrorkit
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDefaultSeverityAndLogLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		appErr   *AppError
+		severity Severity
+		logLevel string
+	}{
+		{"panic", &AppError{Type: PanicError, Code: 500, Message: "nil map"}, SevCritical, "error"},
+		{"system", NewSystemError(errors.New("db down")), SevHigh, "error"},
+		{"external", NewExternalError(502, "Payment gateway unavailable", nil), SevHigh, "error"},
+		{"business", NewBusinessError(409, "Order already paid"), SevMedium, "error"},
+		{"validation", NewValidationError("Email is required", nil), SevLow, "warn"},
+		{"auth", NewAuthError(401, "Token expired"), SevLow, "warn"},
+		{"unknown type", &AppError{Type: "CUSTOM", Code: 500, Message: "custom"}, SevHigh, "error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.appErr.GetSeverity(); got != tt.severity {
+				t.Errorf("GetSeverity() = %s, want %s", got, tt.severity)
+			}
+			if got := tt.appErr.GetLogLevel(); got != tt.logLevel {
+				t.Errorf("GetLogLevel() = %s, want %s", got, tt.logLevel)
+			}
+
+			mem := UseMemoryLogger()
+			defer SetLogger(nil)
+			LogError(tt.appErr, "GET /orders")
+			entries := mem.Entries()
+			if len(entries) != 1 {
+				t.Fatalf("entries = %v, want 1", entries)
+			}
+			if entries[0].Level != tt.logLevel || entries[0].Fields["severity"] != string(tt.severity) {
+				t.Errorf("logged level=%s severity=%v, want %s/%s",
+					entries[0].Level, entries[0].Fields["severity"], tt.logLevel, tt.severity)
+			}
+		})
+	}
+}
+
+func TestSeverityIndependentOfLogLevel(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	// Thanh toán lỗi: log ở warn nhưng cần page ngay
+	LogError(NewExternalError(502, "Payment gateway unavailable", nil).Level("warn").Severity(SevCritical), "POST /checkout")
+	// Lỗi nhỏ được log ở error nhưng chỉ cần theo dõi
+	LogError(NewSystemError(errors.New("thumbnail cache miss")).Severity(SevLow), "GET /products")
+
+	entries := mem.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("entries = %v, want 2", entries)
+	}
+	if entries[0].Level != "warn" || entries[0].Fields["severity"] != "SEV1" {
+		t.Errorf("entry 0: level=%s severity=%v, want warn/SEV1", entries[0].Level, entries[0].Fields["severity"])
+	}
+	if entries[1].Level != "error" || entries[1].Fields["severity"] != "SEV4" {
+		t.Errorf("entry 1: level=%s severity=%v, want error/SEV4", entries[1].Level, entries[1].Fields["severity"])
+	}
+}
@@ -0,0 +1,88 @@
+package goerrorkit
+
+import (
+	"strings"
+	"sync"
+)
+
+// UpgradedLocal là key trong context locals để handler đánh dấu connection đã được upgrade/hijack
+// (dùng khi adapter không tự nhận biết được, xem IsUpgradedConnection)
+//
+// Example:
+//
+//	app.Get("/ws", func(c *fiber.Ctx) error {
+//	    c.Locals(goerrorkit.UpgradedLocal, true)
+//	    return websocketHandler(c)
+//	})
+const UpgradedLocal = "goerrorkit_upgraded"
+
+var (
+	skipResponseMu   sync.RWMutex
+	skipResponseWhen = IsUpgradedConnection
+)
+
+// SetSkipResponseWhen thiết lập điều kiện chỉ log error mà KHÔNG ghi response body
+// (LogAndRespond và Fiber middleware). Mặc định: IsUpgradedConnection - ghi JSON vào
+// connection đã upgrade (WebSocket) sẽ làm hỏng protocol
+// Khi connection chưa thực sự upgrade, status code của error vẫn được set (chỉ bỏ body)
+// Truyền nil để dùng lại mặc định
+//
+// Mặc định KHÔNG dựa vào header "Connection: Upgrade": header chỉ cho biết client xin upgrade,
+// còn error xảy ra trước khi upgrade (ví dụ auth middleware trả 401 cho /ws) vẫn đang ở HTTP
+// và client cần nhận response bình thường. Chỉ khi đã 101/hijack thì ghi body mới làm hỏng protocol.
+// Muốn bỏ body cho mọi request xin upgrade thì truyền IsUpgradeRequest.
+//
+// Example:
+//
+//	goerrorkit.SetSkipResponseWhen(func(ctx goerrorkit.HTTPContext) bool {
+//	    // SSE stream đã gửi header, không thể ghi error response
+//	    return goerrorkit.IsUpgradedConnection(ctx) || strings.HasPrefix(ctx.Path(), "/events")
+//	})
+//
+//	// Dựa vào header "Connection: Upgrade" thay vì trạng thái connection
+//	goerrorkit.SetSkipResponseWhen(goerrorkit.IsUpgradeRequest)
+func SetSkipResponseWhen(fn func(ctx HTTPContext) bool) {
+	if fn == nil {
+		fn = IsUpgradedConnection
+	}
+	skipResponseMu.Lock()
+	skipResponseWhen = fn
+	skipResponseMu.Unlock()
+}
+
+// shouldSkipResponse kiểm tra request có nên bỏ qua bước ghi response không
+func shouldSkipResponse(ctx HTTPContext) bool {
+	skipResponseMu.RLock()
+	fn := skipResponseWhen
+	skipResponseMu.RUnlock()
+	return fn(ctx)
+}
+
+// IsUpgradedConnection kiểm tra connection đã thực sự được upgrade (status 101) hoặc hijack
+// Dựa vào UpgradeStateGetter (adapters/fiber.FiberContext có sẵn) hoặc local UpgradedLocal == true
+// Request chỉ có header "Connection: Upgrade" nhưng bị lỗi trước khi upgrade vẫn nhận response bình thường
+func IsUpgradedConnection(ctx HTTPContext) bool {
+	if upgraded, ok := ctx.GetLocal(UpgradedLocal).(bool); ok && upgraded {
+		return true
+	}
+	if getter, ok := ctx.(UpgradeStateGetter); ok {
+		return getter.Upgraded()
+	}
+	return false
+}
+
+// IsUpgradeRequest kiểm tra request có header "Connection: Upgrade" (WebSocket, h2c, ...)
+// Chỉ cho biết client XIN upgrade - dùng IsUpgradedConnection để biết connection đã upgrade chưa
+// Cần HTTPContext implement HeaderGetter (adapters/fiber.FiberContext có sẵn), ngược lại luôn trả về false
+func IsUpgradeRequest(ctx HTTPContext) bool {
+	hg, ok := ctx.(HeaderGetter)
+	if !ok {
+		return false
+	}
+	for _, token := range strings.Split(hg.GetHeader("Connection"), ",") {
+		if strings.EqualFold(strings.TrimSpace(token), "upgrade") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package goerrorkit
+
+import (
+	"strings"
+	"testing"
+)
+
+// upgradedContext là testContext implement UpgradeStateGetter
+type upgradedContext struct {
+	*testContext
+	upgraded bool
+}
+
+func (c *upgradedContext) Upgraded() bool { return c.upgraded }
+
+func TestLogAndRespondUpgradeRequestNotUpgraded(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	// Client xin upgrade nhưng handler lỗi trước khi upgrade: vẫn nhận response bình thường
+	ctx := newTestContext("GET", "/ws")
+	ctx.headers["Connection"] = "keep-alive, Upgrade"
+	ctx.headers["Upgrade"] = "websocket"
+	LogAndRespond(ctx, NewAuthError(401, "Unauthorized"), "GET /ws")
+
+	if ctx.status != 401 || ctx.jsonCalls != 1 {
+		t.Errorf("status = %d, jsonCalls = %d, want 401 with body", ctx.status, ctx.jsonCalls)
+	}
+	if len(mem.Entries()) != 1 {
+		t.Errorf("entries = %+v, want 1", mem.Entries())
+	}
+}
+
+func TestLogAndRespondUpgradedConnection(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	viaLocal := newTestContext("GET", "/ws")
+	viaLocal.locals[UpgradedLocal] = true
+	LogAndRespond(viaLocal, NewSystemError(nil), "GET /ws")
+	if viaLocal.status != 0 || viaLocal.jsonCalls != 0 {
+		t.Errorf("UpgradedLocal: status = %d, jsonCalls = %d, want nothing written", viaLocal.status, viaLocal.jsonCalls)
+	}
+
+	viaState := &upgradedContext{testContext: newTestContext("GET", "/ws"), upgraded: true}
+	LogAndRespond(viaState, NewSystemError(nil), "GET /ws")
+	if viaState.status != 0 || viaState.jsonCalls != 0 {
+		t.Errorf("Upgraded(): status = %d, jsonCalls = %d, want nothing written", viaState.status, viaState.jsonCalls)
+	}
+
+	if len(mem.Entries()) != 2 {
+		t.Errorf("entries = %+v, want 2 (logging still happens)", mem.Entries())
+	}
+}
+
+func TestSetSkipResponseWhenSetsStatusWithoutBody(t *testing.T) {
+	UseMemoryLogger()
+	defer SetLogger(nil)
+	SetSkipResponseWhen(func(ctx HTTPContext) bool {
+		return IsUpgradedConnection(ctx) || strings.HasPrefix(ctx.Path(), "/events")
+	})
+	defer SetSkipResponseWhen(nil)
+
+	ctx := newTestContext("GET", "/events/orders")
+	LogAndRespondWith(ctx, NewBusinessError(404, "Stream not found"), "GET /events/orders",
+		func(appErr *AppError) interface{} { return appErr.Message })
+
+	if ctx.status != 404 {
+		t.Errorf("status = %d, want 404", ctx.status)
+	}
+	if ctx.jsonCalls != 0 {
+		t.Errorf("body written %d times, want none", ctx.jsonCalls)
+	}
+}
+
+func TestIsUpgradeRequest(t *testing.T) {
+	ctx := newTestContext("GET", "/ws")
+	if IsUpgradeRequest(ctx) {
+		t.Error("plain request reported as upgrade request")
+	}
+	ctx.headers["Connection"] = "Upgrade"
+	if !IsUpgradeRequest(ctx) {
+		t.Error("Connection: Upgrade not detected")
+	}
+	if IsUpgradedConnection(ctx) {
+		t.Error("upgrade request reported as upgraded connection")
+	}
+}
+
+func TestSetSkipResponseWhenUpgradeRequestHeader(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+	SetSkipResponseWhen(IsUpgradeRequest)
+	defer SetSkipResponseWhen(nil)
+
+	// Chọn dựa vào header: request xin upgrade chỉ nhận status, không có body
+	ctx := newTestContext("GET", "/ws")
+	ctx.headers["Connection"] = "Upgrade"
+	LogAndRespond(ctx, NewAuthError(401, "Unauthorized"), "GET /ws")
+	if ctx.status != 401 || ctx.jsonCalls != 0 {
+		t.Errorf("status = %d, jsonCalls = %d, want 401 without body", ctx.status, ctx.jsonCalls)
+	}
+
+	plain := newTestContext("GET", "/orders")
+	LogAndRespond(plain, NewAuthError(401, "Unauthorized"), "GET /orders")
+	if plain.jsonCalls != 1 {
+		t.Errorf("plain request: jsonCalls = %d, want body", plain.jsonCalls)
+	}
+	if len(mem.Entries()) != 2 {
+		t.Errorf("entries = %+v, want both logged", mem.Entries())
+	}
+}
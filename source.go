@@ -0,0 +1,53 @@
+package goerrorkit
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+)
+
+const (
+	// defaultSourceContextLines là số dòng trước/sau mặc định của source snippet
+	defaultSourceContextLines = 2
+
+	// maxSourceContextLines giới hạn kích thước snippet để tránh log bloat
+	maxSourceContextLines = 10
+)
+
+// readSourceSnippet đọc các dòng source quanh dòng line của file path
+// Dòng gây lỗi được đánh dấu bằng ">": ["  40 | x := items[i]", "> 41 | y := m[k]", ...]
+// Trả về nil nếu không đọc được file (fail silently)
+func readSourceSnippet(path string, line, contextLines int) []string {
+	if path == "" || line <= 0 {
+		return nil
+	}
+	if contextLines <= 0 {
+		contextLines = defaultSourceContextLines
+	}
+	if contextLines > maxSourceContextLines {
+		contextLines = maxSourceContextLines
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	start, end := line-contextLines, line+contextLines
+	var snippet []string
+
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan() && n <= end; n++ {
+		if n < start {
+			continue
+		}
+		marker := " "
+		if n == line {
+			marker = ">"
+		}
+		snippet = append(snippet, fmt.Sprintf("%s %4d | %s", marker, n, scanner.Text()))
+	}
+
+	return snippet
+}
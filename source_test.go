@@ -0,0 +1,90 @@
+package goerrorkit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeSourceFixture tạo file n dòng "line 1" ... "line n" trong thư mục tạm
+func writeSourceFixture(t *testing.T, n int) string {
+	t.Helper()
+	var b strings.Builder
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&b, "line %d\n", i)
+	}
+	path := filepath.Join(t.TempDir(), "fixture.go")
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadSourceSnippet(t *testing.T) {
+	path := writeSourceFixture(t, 50)
+
+	want := []string{
+		"     8 | line 8",
+		"     9 | line 9",
+		">   10 | line 10",
+		"    11 | line 11",
+		"    12 | line 12",
+	}
+	if got := readSourceSnippet(path, 10, 0); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("default context:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// Đầu/cuối file: snippet bị cắt theo số dòng thực tế
+	if got := readSourceSnippet(path, 1, 2); len(got) != 3 || !strings.HasPrefix(got[0], ">") {
+		t.Errorf("first line snippet = %q", got)
+	}
+	if got := readSourceSnippet(path, 50, 2); len(got) != 3 || !strings.HasPrefix(got[2], ">") {
+		t.Errorf("last line snippet = %q", got)
+	}
+
+	// Context bị giới hạn bởi maxSourceContextLines
+	if got := readSourceSnippet(path, 25, 100); len(got) != 2*maxSourceContextLines+1 {
+		t.Errorf("len = %d, want capped at %d", len(got), 2*maxSourceContextLines+1)
+	}
+}
+
+func TestReadSourceSnippetMissingFile(t *testing.T) {
+	for _, tt := range []struct {
+		path string
+		line int
+	}{
+		{filepath.Join(t.TempDir(), "stripped.go"), 10},
+		{"", 10},
+		{writeSourceFixture(t, 5), 0},
+	} {
+		if got := readSourceSnippet(tt.path, tt.line, 2); got != nil {
+			t.Errorf("readSourceSnippet(%q, %d) = %q, want nil", tt.path, tt.line, got)
+		}
+	}
+}
+
+func TestHandlePanicIncludeSource(t *testing.T) {
+	withStackTraceConfig(t)
+
+	appErr, _ := panicHelper()
+	if _, ok := appErr.Details["source"]; ok {
+		t.Errorf("source attached without IncludeSource: %v", appErr.Details["source"])
+	}
+
+	cfg := getStackTraceConfig()
+	cfg.IncludeSource = true
+	cfg.SourceContextLines = 1
+	SetStackTraceConfig(cfg)
+
+	appErr, caller := panicHelper()
+	source, ok := appErr.Details["source"].([]string)
+	if !ok || len(source) != 3 {
+		t.Fatalf("source = %#v, want 3 lines", appErr.Details["source"])
+	}
+	want := fmt.Sprintf("> %4d | \tpanic(\"boom\")", caller.Line)
+	if source[1] != want {
+		t.Errorf("panic line = %q, want %q", source[1], want)
+	}
+}
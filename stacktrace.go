@@ -3,9 +3,11 @@ package goerrorkit
 import (
 	"fmt"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"runtime/debug"
 	"strings"
+	"sync"
 )
 
 // StackTraceConfig cấu hình cho stack trace filtering
@@ -20,12 +22,40 @@ type StackTraceConfig struct {
 	// Nếu empty, sẽ include tất cả trừ SkipPackages
 	IncludePackages []string
 
+	// SkipRegexes - Regex patterns cần bỏ qua, match với tên function của frame
+	// (không gồm arguments), ví dụ `\.func\d+$` hoặc `^github\.com/acme/gen/`
+	SkipRegexes []*regexp.Regexp
+
+	// IncludeRegexes - Regex patterns cần include (bổ sung cho IncludePackages)
+	// Frame được include nếu match IncludePackages HOẶC IncludeRegexes
+	IncludeRegexes []*regexp.Regexp
+
 	// ShowFullPath - Hiển thị full package path hay chỉ tên cuối
 	// true: github.com/user/myapp.Handler
 	// false: myapp.Handler
 	ShowFullPath bool
+
+	// MaxFrames - Số frame tối đa trong call chain (0: mặc định 32, < 0: không giới hạn)
+	// Khi vượt quá, giữ N-2 frame đầu và 2 frame cuối với marker "... X frames elided ..."
+	// (N < 3: giữ N frame đầu và thêm marker "... (X more frames)" ở cuối)
+	// Quy ước 0 = mặc định, < 0 = không giới hạn dùng chung cho SetMaxCallChainDepth và WithCallChainDepth
+	MaxFrames int
+
+	// IncludeSource - Đính kèm source code quanh dòng gây panic vào Details["source"]
+	// Bỏ qua im lặng nếu file source không đọc được (ví dụ container đã strip source)
+	IncludeSource bool
+
+	// SourceContextLines - Số dòng trước/sau dòng gây panic (mặc định 2, tối đa 10)
+	SourceContextLines int
 }
 
+// defaultMaxFrames là số frame tối đa mặc định khi MaxFrames = 0
+const defaultMaxFrames = 32
+
+// configMu bảo vệ defaultConfig: ghi lúc startup, đọc đồng thời khi capture stack trên nhiều goroutine
+// Quy ước copy-on-write: writer luôn tạo slice mới nên snapshot của reader không bao giờ bị sửa
+var configMu sync.RWMutex
+
 // defaultConfig là cấu hình mặc định cho stack trace
 var defaultConfig = StackTraceConfig{
 	SkipPackages: []string{
@@ -34,13 +64,19 @@ var defaultConfig = StackTraceConfig{
 	},
 	SkipFunctions: []string{
 		"formatStackTraceArray",
+		"captureStackFrames",
+		"goerrorkit.FilteredCallChain",
 		"getActualPanicLocation",
 		"HandlePanic",
 		"ErrorHandler",
 		"middleware",
+		"handleGoroutinePanic",
+		"goerrorkit.Recover",
+		"goerrorkit.Go",
 	},
 	IncludePackages: []string{},
 	ShowFullPath:    false,
+	MaxFrames:       defaultMaxFrames,
 }
 
 // SetStackTraceConfig cho phép user customize stack trace behavior
@@ -52,20 +88,67 @@ var defaultConfig = StackTraceConfig{
 //	    ShowFullPath: false,
 //	})
 func SetStackTraceConfig(config StackTraceConfig) {
-	defaultConfig = config
+	configMu.Lock()
+	defaultConfig = config.clone()
+	configMu.Unlock()
+}
+
+// getStackTraceConfig trả về snapshot của config hiện tại (an toàn khi đọc đồng thời)
+func getStackTraceConfig() StackTraceConfig {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return defaultConfig
+}
+
+// clone tạo bản copy với slices riêng biệt
+func (cfg StackTraceConfig) clone() StackTraceConfig {
+	cfg.SkipPackages = append([]string{}, cfg.SkipPackages...)
+	cfg.SkipFunctions = append([]string{}, cfg.SkipFunctions...)
+	cfg.IncludePackages = append([]string{}, cfg.IncludePackages...)
+	cfg.SkipRegexes = append([]*regexp.Regexp{}, cfg.SkipRegexes...)
+	cfg.IncludeRegexes = append([]*regexp.Regexp{}, cfg.IncludeRegexes...)
+	return cfg
 }
 
 // ConfigureForApplication là helper function để config nhanh cho application
+// Nhận một hoặc nhiều package (monorepo nhiều service/module), tất cả được set vào IncludePackages
 //
 // Example:
 //
 //	goerrorkit.ConfigureForApplication("github.com/yourname/myapp")
-func ConfigureForApplication(appPackage string) {
-	defaultConfig.IncludePackages = []string{appPackage}
-	// Auto-skip thư viện goerrorkit
-	defaultConfig.SkipPackages = append(defaultConfig.SkipPackages,
-		"github.com/techmaster-vietnam/goerrorkit",
-	)
+//
+//	// Monorepo
+//	goerrorkit.ConfigureForApplication(
+//	    "github.com/yourname/mono/orders",
+//	    "github.com/yourname/mono/billing",
+//	    "github.com/yourname/mono/shared",
+//	)
+func ConfigureForApplication(appPackages ...string) {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	defaultConfig = withApplicationPackages(defaultConfig.clone(), appPackages)
+}
+
+// withApplicationPackages set IncludePackages và auto-skip thư viện goerrorkit (cfg phải là bản clone)
+func withApplicationPackages(cfg StackTraceConfig, appPackages []string) StackTraceConfig {
+	cfg.IncludePackages = append([]string{}, appPackages...)
+	// Auto-skip thư viện goerrorkit (không thêm trùng khi gọi nhiều lần)
+	const kitPackage = "github.com/techmaster-vietnam/goerrorkit"
+	if !containsString(cfg.SkipPackages, kitPackage) {
+		cfg.SkipPackages = append(cfg.SkipPackages, kitPackage)
+	}
+	return cfg
+}
+
+// containsString kiểm tra slice có chứa s không
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
 }
 
 // StackTraceConfigurator cung cấp fluent API để configure stack trace
@@ -85,12 +168,7 @@ type StackTraceConfigurator struct {
 func Configure() *StackTraceConfigurator {
 	// Copy config hiện tại để tránh modify trực tiếp
 	return &StackTraceConfigurator{
-		config: StackTraceConfig{
-			SkipPackages:    append([]string{}, defaultConfig.SkipPackages...),
-			SkipFunctions:   append([]string{}, defaultConfig.SkipFunctions...),
-			IncludePackages: append([]string{}, defaultConfig.IncludePackages...),
-			ShowFullPath:    defaultConfig.ShowFullPath,
-		},
+		config: getStackTraceConfig().clone(),
 	}
 }
 
@@ -128,6 +206,42 @@ func (c *StackTraceConfigurator) SkipPatterns(patterns ...string) *StackTraceCon
 	return c.SkipFunctions(patterns...)
 }
 
+// SkipRegex thêm regex pattern cần bỏ qua (compile một lần khi configure)
+// Panic nếu pattern không hợp lệ (giống regexp.MustCompile) - nên gọi lúc startup
+//
+// Example:
+//
+//	goerrorkit.Configure().
+//	    SkipRegex(`\.func\d+$`).             // Mọi closure đánh số
+//	    SkipRegex(`^github\.com/acme/gen/`). // Generated code
+//	    Apply()
+func (c *StackTraceConfigurator) SkipRegex(pattern string) *StackTraceConfigurator {
+	c.config.SkipRegexes = append(c.config.SkipRegexes, regexp.MustCompile(pattern))
+	return c
+}
+
+// SkipRegexes thêm nhiều regex patterns cần bỏ qua
+func (c *StackTraceConfigurator) SkipRegexes(patterns ...string) *StackTraceConfigurator {
+	for _, pattern := range patterns {
+		c.SkipRegex(pattern)
+	}
+	return c
+}
+
+// IncludeRegex thêm regex pattern cần include (compile một lần khi configure)
+func (c *StackTraceConfigurator) IncludeRegex(pattern string) *StackTraceConfigurator {
+	c.config.IncludeRegexes = append(c.config.IncludeRegexes, regexp.MustCompile(pattern))
+	return c
+}
+
+// IncludeRegexes thêm nhiều regex patterns cần include
+func (c *StackTraceConfigurator) IncludeRegexes(patterns ...string) *StackTraceConfigurator {
+	for _, pattern := range patterns {
+		c.IncludeRegex(pattern)
+	}
+	return c
+}
+
 // IncludePackage set package cần include (application code)
 func (c *StackTraceConfigurator) IncludePackage(pkg string) *StackTraceConfigurator {
 	c.config.IncludePackages = append(c.config.IncludePackages, pkg)
@@ -146,9 +260,23 @@ func (c *StackTraceConfigurator) ShowFullPath(show bool) *StackTraceConfigurator
 	return c
 }
 
+// MaxFrames set số frame tối đa trong call chain (0: mặc định 32, < 0: không giới hạn)
+func (c *StackTraceConfigurator) MaxFrames(n int) *StackTraceConfigurator {
+	c.config.MaxFrames = n
+	return c
+}
+
+// IncludeSource bật/tắt đính kèm source snippet quanh dòng gây panic
+// contextLines là số dòng trước/sau (0 → mặc định 2)
+func (c *StackTraceConfigurator) IncludeSource(include bool, contextLines int) *StackTraceConfigurator {
+	c.config.IncludeSource = include
+	c.config.SourceContextLines = contextLines
+	return c
+}
+
 // Apply áp dụng configuration
 func (c *StackTraceConfigurator) Apply() {
-	defaultConfig = c.config
+	SetStackTraceConfig(c.config)
 }
 
 // AddSkipPatterns là shorthand function để nhanh chóng thêm skip patterns
@@ -158,7 +286,30 @@ func (c *StackTraceConfigurator) Apply() {
 //
 //	goerrorkit.AddSkipPatterns(".RequestID.func", ".Logger.func", "telemetry")
 func AddSkipPatterns(patterns ...string) {
-	defaultConfig.SkipFunctions = append(defaultConfig.SkipFunctions, patterns...)
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	cfg := defaultConfig.clone()
+	cfg.SkipFunctions = append(cfg.SkipFunctions, patterns...)
+	defaultConfig = cfg
+}
+
+// SetMaxCallChainDepth là shorthand để giới hạn số frame trong call_chain
+// (WithCallChain và panic) mà không cần tạo configurator
+// n > 0: giữ n frame, phần bị cắt được thay bằng marker "... X frames elided ..."
+// n = 0: về mặc định 32 frame; n < 0: không giới hạn (giống StackTraceConfig.MaxFrames)
+//
+// Example:
+//
+//	goerrorkit.SetMaxCallChainDepth(16) // log gọn hơn cho service có đệ quy sâu
+//	goerrorkit.SetMaxCallChainDepth(-1) // giữ toàn bộ call chain
+func SetMaxCallChainDepth(n int) {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	cfg := defaultConfig.clone()
+	cfg.MaxFrames = n
+	defaultConfig = cfg
 }
 
 // AddSkipPackages là shorthand function để nhanh chóng thêm skip packages
@@ -167,12 +318,19 @@ func AddSkipPatterns(patterns ...string) {
 //
 //	goerrorkit.AddSkipPackages("internal/telemetry", "vendor/monitoring")
 func AddSkipPackages(packages ...string) {
-	defaultConfig.SkipPackages = append(defaultConfig.SkipPackages, packages...)
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	cfg := defaultConfig.clone()
+	cfg.SkipPackages = append(cfg.SkipPackages, packages...)
+	defaultConfig = cfg
 }
 
 // getActualPanicLocation lấy thông tin về dòng THỰC SỰ gây panic
 // Đây là nơi thực sự phát sinh lỗi, không phải nơi gọi hàm
-func getActualPanicLocation() (file string, line int, function string) {
+// path là đường dẫn đầy đủ của file (dùng để đọc source snippet)
+func getActualPanicLocation() (file string, line int, function string, path string) {
+	cfg := getStackTraceConfig()
 	stack := string(debug.Stack())
 	lines := strings.Split(stack, "\n")
 
@@ -195,15 +353,12 @@ func getActualPanicLocation() (file string, line int, function string) {
 		}
 
 		// Tìm function đầu tiên của user code (không phải runtime/debug và không phải goerrorkit)
-		if isUserFunction(l) && !shouldSkipFunction(l) {
-			function = l
-			// Lấy tên function (bỏ phần parameter)
-			if idx := strings.Index(function, "("); idx > 0 {
-				function = function[:idx]
-			}
+		if cfg.isUserFunction(l) && !cfg.shouldSkipFunction(l) {
+			// Lấy tên function (bỏ phần parameter, giữ receiver như "(*Svc)")
+			function = frameFunctionName(l)
 
 			// Format function name
-			function = formatFunctionName(function)
+			function = cfg.formatFunctionName(function)
 
 			// Dòng tiếp theo chứa file:line
 			if i+1 < len(lines) {
@@ -212,10 +367,10 @@ func getActualPanicLocation() (file string, line int, function string) {
 				if len(parts) > 0 {
 					fileAndLine := parts[0]
 					if idx := strings.LastIndex(fileAndLine, ":"); idx > 0 {
-						file = fileAndLine[:idx]
+						path = fileAndLine[:idx]
 						fmt.Sscanf(fileAndLine[idx+1:], "%d", &line)
 						// Chỉ lấy tên file, bỏ đường dẫn
-						file = filepath.Base(file)
+						file = filepath.Base(path)
 					}
 				}
 			}
@@ -224,19 +379,120 @@ func getActualPanicLocation() (file string, line int, function string) {
 	}
 
 	if file == "" {
-		return "unknown", 0, "unknown"
+		return "unknown", 0, "unknown", ""
 	}
 
-	return file, line, function
+	return file, line, function, path
+}
+
+// StackFrame là một frame trong call chain ở dạng structured
+// Giúp tooling bên ngoài (Sentry, log pipeline) không phải parse lại chuỗi call_chain
+type StackFrame struct {
+	Function string `json:"function"` // Tên function (đã format theo ShowFullPath)
+	File     string `json:"file"`     // Tên file (không có đường dẫn)
+	Line     int    `json:"line"`     // Số dòng
 }
 
-// formatStackTraceArray format stack trace thành array dễ đọc
+// String format frame theo dạng call_chain: "main.checkInventory (main.go:312)"
+func (f StackFrame) String() string {
+	// Marker frame (ví dụ "... 57 frames elided ...") không có file
+	if f.File == "" {
+		return f.Function
+	}
+	return fmt.Sprintf("%s (%s:%d)", f.Function, f.File, f.Line)
+}
+
+// FilterStack áp dụng stack trace config hiện tại (SkipPackages, SkipFunctions, IncludePackages,
+// ShowFullPath) lên output của debug.Stack() bất kỳ và trả về call chain đã lọc
+// Hữu ích để gắn trace sạch vào log entry không phải AppError
+//
+// Example:
+//
+//	goerrorkit.Warn("Slow request", map[string]interface{}{
+//	    "call_chain": goerrorkit.FilterStack(debug.Stack()),
+//	})
+func FilterStack(raw []byte) []string {
+	return formatCallChain(FilterStackFrames(raw))
+}
+
+// FilterStackFrames giống FilterStack nhưng trả về dạng structured StackFrame
+func FilterStackFrames(raw []byte) []StackFrame {
+	cfg := getStackTraceConfig()
+	return limitFrames(cfg.parseStackFrames(raw), cfg.maxFrames())
+}
+
+// FilteredCallChain trả về call chain đã lọc của vị trí gọi hiện tại
+//
+// Example:
+//
+//	goerrorkit.Info("Cache rebuilt", map[string]interface{}{
+//	    "call_chain": goerrorkit.FilteredCallChain(),
+//	})
+func FilteredCallChain() []string {
+	return formatCallChain(captureStackFrames())
+}
+
+// captureStackFrames capture stack hiện tại thành danh sách StackFrame đã được lọc
+func captureStackFrames() []StackFrame {
+	return captureStackFramesLimit(getStackTraceConfig().maxFrames())
+}
+
+// captureStackFramesLimit capture stack hiện tại với giới hạn số frame
+// (0: MaxFrames của config, < 0: không giới hạn)
+func captureStackFramesLimit(maxFrames int) []StackFrame {
+	cfg := getStackTraceConfig()
+	if maxFrames == 0 {
+		maxFrames = cfg.maxFrames()
+	}
+	return limitFrames(cfg.parseStackFrames(debug.Stack()), maxFrames)
+}
+
+// maxFrames trả về MaxFrames từ config (0 → mặc định 32)
+func (cfg StackTraceConfig) maxFrames() int {
+	if cfg.MaxFrames == 0 {
+		return defaultMaxFrames
+	}
+	return cfg.MaxFrames
+}
+
+// limitFrames giới hạn số frame: giữ maxFrames-2 frame đầu, 2 frame cuối
+// và chèn marker "... X frames elided ..." ở giữa (maxFrames < 0: không giới hạn)
+func limitFrames(frames []StackFrame, maxFrames int) []StackFrame {
+	if maxFrames < 0 || len(frames) <= maxFrames {
+		return frames
+	}
+	if maxFrames < 3 {
+		// Không đủ chỗ cho head + marker + tail: giữ frame đầu, marker ở cuối
+		limited := append(make([]StackFrame, 0, maxFrames+1), frames[:maxFrames]...)
+		return append(limited, StackFrame{Function: fmt.Sprintf("... (%d more frames)", len(frames)-maxFrames)})
+	}
+
+	const tail = 2
+	head := maxFrames - tail
+	elided := len(frames) - head - tail
+
+	limited := make([]StackFrame, 0, maxFrames+1)
+	limited = append(limited, frames[:head]...)
+	limited = append(limited, StackFrame{Function: fmt.Sprintf("... %d frames elided ...", elided)})
+	limited = append(limited, frames[len(frames)-tail:]...)
+	return limited
+}
+
+// formatCallChain convert danh sách StackFrame sang call_chain dạng string
+func formatCallChain(frames []StackFrame) []string {
+	callChain := make([]string, 0, len(frames))
+	for _, frame := range frames {
+		callChain = append(callChain, frame.String())
+	}
+	return callChain
+}
+
+// parseStackFrames parse output của debug.Stack() thành StackFrame
 // Tự động lọc các hàm utility và chỉ lấy application code
-func formatStackTraceArray() []string {
-	stack := string(debug.Stack())
-	lines := strings.Split(stack, "\n")
+func (cfg StackTraceConfig) parseStackFrames(stack []byte) []StackFrame {
+	lines := strings.Split(string(stack), "\n")
 
-	var callChain []string
+	var frames []StackFrame
 	skipNext := false
 
 	for i := 0; i < len(lines); i++ {
@@ -248,15 +504,12 @@ func formatStackTraceArray() []string {
 		}
 
 		// Chỉ lấy user functions, bỏ qua utility và runtime
-		if isUserFunction(l) && !shouldSkipFunction(l) {
-			funcName := l
-			// Lấy tên function (bỏ phần parameter)
-			if idx := strings.Index(funcName, "("); idx > 0 {
-				funcName = funcName[:idx]
-			}
+		if cfg.isUserFunction(l) && !cfg.shouldSkipFunction(l) {
+			// Lấy tên function (bỏ phần parameter, giữ receiver như "(*Svc)")
+			funcName := frameFunctionName(l)
 
 			// Format function name
-			funcName = formatFunctionName(funcName)
+			funcName = cfg.formatFunctionName(funcName)
 
 			// Dòng tiếp theo chứa file:line
 			if i+1 < len(lines) {
@@ -264,20 +517,24 @@ func formatStackTraceArray() []string {
 				parts := strings.Fields(locationLine)
 				if len(parts) > 0 {
 					fileAndLine := parts[0]
+					frame := StackFrame{Function: funcName, File: fileAndLine}
 
-					// Chỉ lấy tên file, bỏ đường dẫn đầy đủ
-					if idx := strings.LastIndex(fileAndLine, "/"); idx >= 0 {
-						fileAndLine = fileAndLine[idx+1:]
+					if idx := strings.LastIndex(fileAndLine, ":"); idx > 0 {
+						frame.File = fileAndLine[:idx]
+						fmt.Sscanf(fileAndLine[idx+1:], "%d", &frame.Line)
 					}
 
-					callChain = append(callChain, fmt.Sprintf("%s (%s)", funcName, fileAndLine))
+					// Chỉ lấy tên file, bỏ đường dẫn đầy đủ
+					frame.File = filepath.Base(frame.File)
+
+					frames = append(frames, frame)
 				}
 			}
 			skipNext = true
 		}
 	}
 
-	return callChain
+	return frames
 }
 
 // getCallerInfo lấy thông tin về nơi gọi factory function
@@ -299,7 +556,7 @@ func getCallerInfo(skip int) (file string, line int, function string) {
 	}
 
 	// Format function name
-	function = formatFunctionName(function)
+	function = getStackTraceConfig().formatFunctionName(function)
 
 	// Chỉ lấy tên file, bỏ đường dẫn đầy đủ
 	file = filepath.Base(file)
@@ -308,12 +565,17 @@ func getCallerInfo(skip int) (file string, line int, function string) {
 }
 
 // isUserFunction kiểm tra xem có phải user code không
-func isUserFunction(line string) bool {
+func (cfg StackTraceConfig) isUserFunction(line string) bool {
 	// Bỏ qua dòng trống và các dòng không phải function
 	if line == "" || !strings.Contains(line, ".") {
 		return false
 	}
 
+	// Bỏ qua dòng location ("/path/to/file.go:42 +0x1f") - tên function không bao giờ chứa ".go:"
+	if strings.Contains(line, ".go:") {
+		return false
+	}
+
 	// Bỏ qua runtime internal
 	if strings.HasPrefix(line, "runtime.") ||
 		strings.HasPrefix(line, "runtime/debug.") ||
@@ -323,15 +585,15 @@ func isUserFunction(line string) bool {
 	}
 
 	// Bỏ qua các packages trong SkipPackages
-	for _, pkg := range defaultConfig.SkipPackages {
+	for _, pkg := range cfg.SkipPackages {
 		if strings.HasPrefix(line, pkg+".") || strings.Contains(line, pkg+".") || strings.Contains(line, "/"+pkg+".") {
 			return false
 		}
 	}
 
-	// Nếu có config IncludePackages, chỉ lấy những packages đó
-	if len(defaultConfig.IncludePackages) > 0 {
-		for _, pkg := range defaultConfig.IncludePackages {
+	// Nếu có config IncludePackages/IncludeRegexes, chỉ lấy những packages đó
+	if len(cfg.IncludePackages) > 0 || len(cfg.IncludeRegexes) > 0 {
+		for _, pkg := range cfg.IncludePackages {
 			// Hỗ trợ cả package name và full path
 			if strings.HasPrefix(line, pkg+".") ||
 				strings.Contains(line, pkg+".") ||
@@ -339,20 +601,48 @@ func isUserFunction(line string) bool {
 				return true
 			}
 		}
+		if len(cfg.IncludeRegexes) > 0 {
+			name := frameFunctionName(line)
+			for _, re := range cfg.IncludeRegexes {
+				if re.MatchString(name) {
+					return true
+				}
+			}
+		}
 		return false
 	}
 
 	return true
 }
 
+// frameFunctionName bỏ phần arguments ở cuối dòng function của debug.Stack()
+// "main.(*Svc).Do(0xc000010000)" → "main.(*Svc).Do"
+func frameFunctionName(line string) string {
+	if strings.HasSuffix(line, ")") {
+		if idx := strings.LastIndex(line, "("); idx > 0 {
+			return line[:idx]
+		}
+	}
+	return line
+}
+
 // shouldSkipFunction kiểm tra xem có cần skip function này không
-func shouldSkipFunction(line string) bool {
-	for _, skipFunc := range defaultConfig.SkipFunctions {
+func (cfg StackTraceConfig) shouldSkipFunction(line string) bool {
+	for _, skipFunc := range cfg.SkipFunctions {
 		if strings.Contains(line, skipFunc) {
 			return true
 		}
 	}
 
+	if len(cfg.SkipRegexes) > 0 {
+		name := frameFunctionName(line)
+		for _, re := range cfg.SkipRegexes {
+			if re.MatchString(name) {
+				return true
+			}
+		}
+	}
+
 	// Skip tất cả anonymous functions có pattern như "package.function.Method.func1"
 	// Ví dụ: "main.main.New.func1", "github.com/gofiber/fiber.(*App).Next.func1"
 	// Đây thường là middleware wrappers, không phải business logic
@@ -405,8 +695,8 @@ func isMiddlewareAnonymousFunc(line string) bool {
 }
 
 // formatFunctionName format function name theo config
-func formatFunctionName(fullName string) string {
-	if defaultConfig.ShowFullPath {
+func (cfg StackTraceConfig) formatFunctionName(fullName string) string {
+	if cfg.ShowFullPath {
 		return fullName
 	}
 
@@ -0,0 +1,435 @@
+package goerrorkit
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+// recurse gọi chính nó depth lần rồi mới chạy fn (tạo call chain sâu)
+func recurse(depth int, fn func() *AppError) *AppError {
+	if depth == 0 {
+		return fn()
+	}
+	return recurse(depth-1, fn)
+}
+
+// withStackTraceConfig khôi phục stack trace config khi test kết thúc
+func withStackTraceConfig(t *testing.T) {
+	t.Helper()
+	prev := getStackTraceConfig()
+	t.Cleanup(func() { SetStackTraceConfig(prev) })
+}
+
+func callChainOf(appErr *AppError) []string {
+	chain, _ := appErr.Details["call_chain"].([]string)
+	return chain
+}
+
+func countRecurse(chain []string) int {
+	n := 0
+	for _, frame := range chain {
+		if strings.Contains(frame, "recurse") {
+			n++
+		}
+	}
+	return n
+}
+
+func TestCallChainDefaultLimit(t *testing.T) {
+	withStackTraceConfig(t)
+
+	chain := callChainOf(recurse(60, func() *AppError { return NewSystemError(nil).WithCallChain() }))
+	if len(chain) != defaultMaxFrames+1 {
+		t.Fatalf("len(call_chain) = %d, want %d frames + marker", len(chain), defaultMaxFrames)
+	}
+	if marker := chain[defaultMaxFrames-2]; !strings.Contains(marker, "frames elided") {
+		t.Errorf("marker = %q, want elision marker", marker)
+	}
+}
+
+func TestSetMaxCallChainDepth(t *testing.T) {
+	withStackTraceConfig(t)
+	deep := func() *AppError {
+		return recurse(40, func() *AppError { return NewSystemError(nil).WithCallChain() })
+	}
+
+	SetMaxCallChainDepth(5)
+	chain := callChainOf(deep())
+	if len(chain) != 6 || !strings.Contains(chain[3], "frames elided") {
+		t.Errorf("depth 5: call_chain = %v", chain)
+	}
+
+	SetMaxCallChainDepth(-1)
+	if chain := callChainOf(deep()); countRecurse(chain) < 40 {
+		t.Errorf("unlimited: only %d recurse frames", countRecurse(chain))
+	}
+
+	SetMaxCallChainDepth(0)
+	if chain := callChainOf(deep()); len(chain) != defaultMaxFrames+1 {
+		t.Errorf("0 should restore default: len = %d", len(chain))
+	}
+}
+
+func TestWithCallChainDepth(t *testing.T) {
+	withStackTraceConfig(t)
+	SetMaxCallChainDepth(8)
+
+	withDepth := func(n int) []string {
+		return callChainOf(recurse(20, func() *AppError { return NewSystemError(nil).WithCallChainDepth(n) }))
+	}
+
+	if chain := withDepth(0); len(chain) != 9 {
+		t.Errorf("WithCallChainDepth(0) should use config MaxFrames 8: len = %d", len(chain))
+	}
+	if chain := withDepth(-1); countRecurse(chain) < 20 {
+		t.Errorf("WithCallChainDepth(-1) should be unlimited: %d recurse frames", countRecurse(chain))
+	}
+	chain := withDepth(2)
+	if len(chain) != 3 || !strings.HasPrefix(chain[2], "... (") || !strings.HasSuffix(chain[2], " more frames)") {
+		t.Errorf("WithCallChainDepth(2) = %v, want 2 frames and trailing marker", chain)
+	}
+}
+
+func TestLimitFrames(t *testing.T) {
+	frames := make([]StackFrame, 10)
+	for i := range frames {
+		frames[i] = StackFrame{Function: "f", File: "f.go", Line: i + 1}
+	}
+
+	if got := limitFrames(frames, -1); len(got) != 10 {
+		t.Errorf("unlimited: len = %d", len(got))
+	}
+	if got := limitFrames(frames, 10); len(got) != 10 {
+		t.Errorf("exact: len = %d", len(got))
+	}
+
+	got := limitFrames(frames, 5)
+	if len(got) != 6 || got[3].Function != "... 5 frames elided ..." || got[5].Line != 10 {
+		t.Errorf("limit 5 = %+v", got)
+	}
+
+	got = limitFrames(frames, 1)
+	if len(got) != 2 || got[0].Line != 1 || got[1].Function != "... (9 more frames)" {
+		t.Errorf("limit 1 = %+v", got)
+	}
+}
+
+// framesHelper trả về error có call chain cùng vị trí runtime.Caller của dòng gọi WithCallChain
+func framesHelper() (*AppError, runtime.Frame) {
+	pc, file, line, _ := runtime.Caller(0)
+	appErr := NewSystemError(nil).WithCallChain() // phải nằm ngay dòng sau runtime.Caller
+	return appErr, runtime.Frame{PC: pc, File: file, Line: line + 1}
+}
+
+// panicHelper panic ở dòng ngay sau runtime.Caller và trả về error từ HandlePanic
+func panicHelper() (appErr *AppError, caller runtime.Frame) {
+	defer func() {
+		if r := recover(); r != nil {
+			appErr = HandlePanic(r, "req-1")
+		}
+	}()
+	_, file, line, _ := runtime.Caller(0)
+	caller = runtime.Frame{File: file, Line: line + 2}
+	panic("boom")
+}
+
+func findFrame(frames []StackFrame, function string) (StackFrame, bool) {
+	for _, frame := range frames {
+		if frame.Function == function {
+			return frame, true
+		}
+	}
+	return StackFrame{}, false
+}
+
+func TestWithCallChainFramesMatchRuntimeCaller(t *testing.T) {
+	appErr, caller := framesHelper()
+
+	frame, ok := findFrame(appErr.Frames, "goerrorkit.framesHelper")
+	if !ok {
+		t.Fatalf("framesHelper not in frames: %+v", appErr.Frames)
+	}
+	if frame.File != filepath.Base(caller.File) || frame.Line != caller.Line {
+		t.Errorf("frame = %s:%d, want %s:%d", frame.File, frame.Line, filepath.Base(caller.File), caller.Line)
+	}
+	if !reflect.DeepEqual(appErr.StackFrames(), appErr.Frames) {
+		t.Errorf("StackFrames() = %+v, want Frames", appErr.StackFrames())
+	}
+	if got := callChainOf(appErr); !reflect.DeepEqual(got, formatCallChain(appErr.Frames)) {
+		t.Errorf("call_chain = %q, want formatted Frames", got)
+	}
+	if NewSystemError(nil).StackFrames() != nil {
+		t.Error("StackFrames() without WithCallChain should be nil")
+	}
+}
+
+func TestHandlePanicFramesPointToPanicLine(t *testing.T) {
+	appErr, caller := panicHelper()
+
+	want := fmt.Sprintf("%s:%d", filepath.Base(caller.File), caller.Line)
+	if got := appErr.Details["file"]; got != want {
+		t.Errorf("file = %v, want %s", got, want)
+	}
+	if got := appErr.Details["function"]; got != "goerrorkit.panicHelper" {
+		t.Errorf("function = %v, want goerrorkit.panicHelper", got)
+	}
+	frame, ok := findFrame(appErr.Frames, "goerrorkit.panicHelper")
+	if !ok {
+		t.Fatalf("panicHelper not in frames: %+v", appErr.Frames)
+	}
+	if frame.Line != caller.Line {
+		t.Errorf("frame line = %d, want %d", frame.Line, caller.Line)
+	}
+	for _, frame := range appErr.Frames {
+		if strings.Contains(frame.Function, ".go:") {
+			t.Errorf("location line parsed as function: %+v", frame)
+		}
+	}
+}
+
+func TestDebugResponseFramesAreStructured(t *testing.T) {
+	withDebugResponses(t, true)
+	UseMemoryLogger()
+	defer SetLogger(nil)
+
+	appErr, caller := framesHelper()
+	ctx := newTestContext("GET", "/orders/42")
+	LogAndRespond(ctx, appErr, "GET /orders/42")
+
+	debugInfo, _ := ctx.response()["debug"].(map[string]interface{})
+	frames, ok := debugInfo["frames"].([]interface{})
+	if !ok || len(frames) != len(appErr.Frames) {
+		t.Fatalf("debug.frames = %#v, want %d frames", debugInfo["frames"], len(appErr.Frames))
+	}
+	for _, raw := range frames {
+		frame, ok := raw.(map[string]interface{})
+		if !ok {
+			t.Fatalf("frame = %#v, want JSON object", raw)
+		}
+		if frame["function"] != "goerrorkit.framesHelper" {
+			continue
+		}
+		if frame["file"] != filepath.Base(caller.File) || frame["line"] != float64(caller.Line) {
+			t.Errorf("frame = %v, want %s:%d", frame, filepath.Base(caller.File), caller.Line)
+		}
+		return
+	}
+	t.Errorf("framesHelper not in debug.frames: %v", frames)
+}
+
+func TestMaxFramesLimitsStructuredFrames(t *testing.T) {
+	withStackTraceConfig(t)
+	SetMaxCallChainDepth(6)
+
+	appErr := recurse(30, func() *AppError { return NewSystemError(nil).WithCallChain() })
+	if len(appErr.Frames) != 7 || !strings.Contains(appErr.Frames[4].Function, "frames elided") {
+		t.Errorf("Frames = %+v, want 6 frames + elision marker", appErr.Frames)
+	}
+
+	// Panic trong code đệ quy sâu cũng bị giới hạn
+	var panicErr *AppError
+	func() {
+		defer func() { panicErr = HandlePanic(recover(), "req-1") }()
+		recurse(30, func() *AppError { panic("deep") })
+	}()
+	if len(panicErr.Frames) != 7 || len(callChainOf(panicErr)) != 7 {
+		t.Errorf("panic frames = %d, call_chain = %d, want 7", len(panicErr.Frames), len(callChainOf(panicErr)))
+	}
+}
+
+// frameTestService có method pointer receiver để kiểm tra tên method trong frames
+type frameTestService struct{}
+
+func (s *frameTestService) Charge() *AppError {
+	return NewSystemError(nil).WithCallChain()
+}
+
+func (s *frameTestService) Crash() (appErr *AppError) {
+	defer func() { appErr = HandlePanic(recover(), "req-1") }()
+	panic("charge failed")
+}
+
+func TestFramesKeepMethodReceiver(t *testing.T) {
+	svc := &frameTestService{}
+
+	if _, ok := findFrame(svc.Charge().Frames, "goerrorkit.(*frameTestService).Charge"); !ok {
+		t.Errorf("method frame missing: %v", callChainOf(svc.Charge()))
+	}
+	panicErr := svc.Crash()
+	if got := panicErr.Details["function"]; got != "goerrorkit.(*frameTestService).Crash" {
+		t.Errorf("panic function = %v, want receiver kept", got)
+	}
+}
+
+func TestFilterStack(t *testing.T) {
+	chain := FilterStack(debug.Stack())
+	if len(chain) == 0 || !strings.Contains(chain[0], "goerrorkit.TestFilterStack") {
+		t.Fatalf("FilterStack = %q, want test function first", chain)
+	}
+	for _, frame := range chain {
+		if strings.HasPrefix(frame, "debug.") || strings.HasPrefix(frame, "runtime.") {
+			t.Errorf("frame %q not filtered", frame)
+		}
+	}
+
+	frames := FilterStackFrames(debug.Stack())
+	if len(frames) == 0 || frames[0].Function != "goerrorkit.TestFilterStack" {
+		t.Errorf("FilterStackFrames = %+v, want test function first", frames)
+	}
+}
+
+func TestFilteredCallChain(t *testing.T) {
+	chain := FilteredCallChain()
+	if len(chain) == 0 || !strings.Contains(chain[0], "goerrorkit.TestFilteredCallChain") {
+		t.Fatalf("FilteredCallChain = %q, want caller first", chain)
+	}
+	for _, frame := range chain {
+		if strings.HasPrefix(frame, "goerrorkit.FilteredCallChain ") || strings.Contains(frame, "captureStackFrames") {
+			t.Errorf("frame %q not filtered", frame)
+		}
+	}
+}
+
+// monorepoStack là output debug.Stack() giả lập với frame từ nhiều package của một monorepo
+const monorepoStack = `goroutine 1 [running]:
+runtime/debug.Stack()
+	/usr/local/go/src/runtime/debug/stack.go:26 +0x5e
+github.com/techmaster-vietnam/goerrorkit.(*AppError).WithCallChain(0xc000010000)
+	/go/pkg/mod/github.com/techmaster-vietnam/goerrorkit/error.go:96 +0x18
+github.com/acme/mono/billing.(*Service).Charge(0xc000020000, 0x2a)
+	/src/mono/billing/service.go:42 +0x8d
+github.com/jackc/pgx/v5.(*Conn).Exec(0xc000030000)
+	/go/pkg/mod/github.com/jackc/pgx/v5/conn.go:500 +0x1f
+github.com/acme/mono/orders.(*Handler).Checkout(0xc000040000)
+	/src/mono/orders/handler.go:77 +0x4c
+github.com/acme/mono/shared.Run()
+	/src/mono/shared/run.go:12 +0x25
+main.main()
+	/src/mono/cmd/api/main.go:9 +0x17
+`
+
+func TestConfigureForApplicationMultiplePackages(t *testing.T) {
+	withStackTraceConfig(t)
+
+	ConfigureForApplication("github.com/acme/mono/orders", "github.com/acme/mono/billing")
+	cfg := getStackTraceConfig()
+
+	if got := strings.Join(cfg.IncludePackages, ","); got != "github.com/acme/mono/orders,github.com/acme/mono/billing" {
+		t.Errorf("IncludePackages = %v", cfg.IncludePackages)
+	}
+	var got []string
+	for _, frame := range cfg.parseStackFrames([]byte(monorepoStack)) {
+		got = append(got, frame.String())
+	}
+	want := []string{
+		"billing.(*Service).Charge (service.go:42)",
+		"orders.(*Handler).Checkout (handler.go:77)",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("frames = %q, want %q", got, want)
+	}
+}
+
+func TestConfigureForApplicationSinglePackage(t *testing.T) {
+	withStackTraceConfig(t)
+
+	ConfigureForApplication("github.com/acme/mono/shared")
+	ConfigureForApplication("github.com/acme/mono/shared") // gọi lại không thêm trùng SkipPackages
+	cfg := getStackTraceConfig()
+
+	if len(cfg.IncludePackages) != 1 || cfg.IncludePackages[0] != "github.com/acme/mono/shared" {
+		t.Errorf("IncludePackages = %v", cfg.IncludePackages)
+	}
+	kit := 0
+	for _, pkg := range cfg.SkipPackages {
+		if pkg == "github.com/techmaster-vietnam/goerrorkit" {
+			kit++
+		}
+	}
+	if kit != 1 {
+		t.Errorf("goerrorkit in SkipPackages %d times, want 1", kit)
+	}
+	frames := cfg.parseStackFrames([]byte(monorepoStack))
+	if len(frames) != 1 || frames[0].Function != "shared.Run" || frames[0].Line != 12 {
+		t.Errorf("frames = %+v, want only shared.Run", frames)
+	}
+}
+
+// regexStack là output debug.Stack() mẫu có closure đánh số và code generated
+const regexStack = `goroutine 7 [running]:
+runtime/debug.Stack()
+	/usr/local/go/src/runtime/debug/stack.go:26 +0x5e
+main.work.func1()
+	/src/app/main.go:31 +0x2a
+main.work()
+	/src/app/main.go:35 +0x3b
+github.com/acme/gen/api.Decode(0xc000010000)
+	/src/gen/api/decode.go:88 +0x44
+github.com/acme/app/orders.Place(0x2a)
+	/src/app/orders/place.go:19 +0x71
+main.main()
+	/src/app/main.go:12 +0x17
+`
+
+func frameFunctions(frames []StackFrame) []string {
+	var names []string
+	for _, frame := range frames {
+		names = append(names, frame.Function)
+	}
+	return names
+}
+
+func TestSkipRegexSkipsClosures(t *testing.T) {
+	withStackTraceConfig(t)
+	SetStackTraceConfig(StackTraceConfig{SkipPackages: []string{"runtime"}, MaxFrames: -1})
+
+	if got := frameFunctions(FilterStackFrames([]byte(regexStack))); !containsString(got, "main.work.func1") {
+		t.Fatalf("without SkipRegex frames = %q, want closure kept", got)
+	}
+
+	Configure().SkipRegex(`\.func\d+$`).Apply()
+	got := frameFunctions(FilterStackFrames([]byte(regexStack)))
+	want := []string{"main.work", "api.Decode", "orders.Place", "main.main"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("frames = %q, want %q", got, want)
+	}
+}
+
+func TestIncludeRegexWithIncludePackages(t *testing.T) {
+	withStackTraceConfig(t)
+	SetStackTraceConfig(StackTraceConfig{SkipPackages: []string{"runtime"}, MaxFrames: -1})
+
+	Configure().
+		IncludePackage("github.com/acme/app/orders").
+		IncludeRegex(`^github\.com/acme/gen/`).
+		Apply()
+
+	got := frameFunctions(FilterStackFrames([]byte(regexStack)))
+	want := []string{"api.Decode", "orders.Place"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("frames = %q, want %q (IncludePackages OR IncludeRegexes)", got, want)
+	}
+}
+
+func TestSkipRegexInvalidPatternPanics(t *testing.T) {
+	withStackTraceConfig(t)
+
+	for name, apply := range map[string]func(*StackTraceConfigurator){
+		"SkipRegex":    func(c *StackTraceConfigurator) { c.SkipRegex(`(unclosed`) },
+		"IncludeRegex": func(c *StackTraceConfigurator) { c.IncludeRegex(`[z-a]`) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic for invalid pattern")
+				}
+			}()
+			apply(Configure())
+		})
+	}
+}
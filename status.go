@@ -0,0 +1,51 @@
+package goerrorkit
+
+import (
+	"fmt"
+	"sync"
+)
+
+// mismatchWarned lưu các call site (file:line) đã được cảnh báo để không spam log
+var mismatchWarned sync.Map
+
+// isMismatchedStatus kiểm tra HTTP code có lệch hẳn so với ErrorType không:
+// code ngoài 100-599, code không phải lỗi (< 400), hoặc lỗi phía client (Validation/Auth) với 5xx
+// BusinessError với 5xx vẫn hợp lệ (ví dụ phát hiện dữ liệu hỏng)
+func isMismatchedStatus(t ErrorType, code int) bool {
+	if code < 400 || code > 599 {
+		return true
+	}
+	switch t {
+	case ValidationError, AuthError:
+		return code >= 500
+	default:
+		return false
+	}
+}
+
+// warnMismatchedStatus log cảnh báo (một lần mỗi call site) khi code lệch hẳn so với ErrorType
+// Ví dụ NewAuthError(500, ...) hoặc NewBusinessError(200, ...)
+func warnMismatchedStatus(t ErrorType, code int, file string, line int) {
+	if !isMismatchedStatus(t, code) {
+		return
+	}
+	warnMismatchedStatusAt(t, code, fmt.Sprintf("%s:%d", file, line))
+}
+
+// warnMismatchedStatusAt log cảnh báo mismatch một lần cho mỗi location (file:line)
+// Location rỗng (AppError không có Details["file"]) được gom theo type và code
+func warnMismatchedStatusAt(t ErrorType, code int, location string) {
+	key := location
+	if key == "" {
+		key = fmt.Sprintf("%s/%d", t, code)
+	}
+	if _, warned := mismatchWarned.LoadOrStore(key, true); warned {
+		return
+	}
+	Warn(fmt.Sprintf("goerrorkit: HTTP code %d looks inconsistent with error type %s (default %d)",
+		code, t, t.DefaultHTTPStatus()), map[string]interface{}{
+		"error_type": string(t),
+		"code":       code,
+		"file":       location,
+	})
+}
@@ -0,0 +1,108 @@
+package goerrorkit
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// resetMismatchWarnings xoá các call site đã cảnh báo để test chạy lặp lại được (-count)
+func resetMismatchWarnings(t *testing.T) {
+	t.Helper()
+	reset := func() {
+		mismatchWarned.Range(func(key, _ interface{}) bool {
+			mismatchWarned.Delete(key)
+			return true
+		})
+	}
+	reset()
+	t.Cleanup(reset)
+}
+
+func TestDefaultHTTPStatus(t *testing.T) {
+	cases := map[ErrorType]int{
+		ValidationError:     400,
+		AuthError:           401,
+		BusinessError:       409,
+		SystemError:         500,
+		ExternalError:       502,
+		PanicError:          500,
+		ErrorType("CUSTOM"): 500,
+	}
+	for typ, want := range cases {
+		if got := typ.DefaultHTTPStatus(); got != want {
+			t.Errorf("%s.DefaultHTTPStatus() = %d, want %d", typ, got, want)
+		}
+	}
+}
+
+func TestWithStatusWarnsAtCreationSite(t *testing.T) {
+	resetMismatchWarnings(t)
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	var created []*AppError
+	for i := 0; i < 3; i++ {
+		created = append(created, NewValidationError("Email không hợp lệ", nil).WithStatus(503))
+	}
+
+	warnings := findEntries(mem, "looks inconsistent")
+	if len(warnings) != 1 {
+		t.Fatalf("got %d mismatch warnings, want 1 per creation site: %v", len(warnings), mem.Entries())
+	}
+	if got, want := warnings[0].Fields["file"], created[0].Details["file"]; got != want {
+		t.Errorf("warning file = %v, want creation site %v", got, want)
+	}
+	if !strings.HasPrefix(warnings[0].Fields["file"].(string), "status_test.go:") {
+		t.Errorf("warning file = %v", warnings[0].Fields["file"])
+	}
+	if created[0].Code != 503 {
+		t.Errorf("Code = %d, want 503 (WithStatus still applies)", created[0].Code)
+	}
+}
+
+func TestWithStatusConsistentCodeDoesNotWarn(t *testing.T) {
+	resetMismatchWarnings(t)
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	NewValidationError("Email không hợp lệ", nil).WithStatus(422)
+	NewBusinessError(404, "Order not found").WithStatus(503)
+	Wrap(errors.New("version mismatch")).WithStatus(409)
+
+	if warnings := findEntries(mem, "looks inconsistent"); len(warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+}
+
+func TestWithStatusWithoutDetails(t *testing.T) {
+	resetMismatchWarnings(t)
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	for i := 0; i < 2; i++ {
+		(&AppError{Type: AuthError, Code: 401, Message: "Unauthorized"}).WithStatus(200)
+	}
+
+	warnings := findEntries(mem, "looks inconsistent")
+	if len(warnings) != 1 {
+		t.Fatalf("got %d warnings, want 1: %v", len(warnings), mem.Entries())
+	}
+	if warnings[0].Fields["file"] != "" || warnings[0].Fields["code"] != 200 {
+		t.Errorf("warning fields = %v", warnings[0].Fields)
+	}
+}
+
+func TestFactoryMismatchWarnsOnce(t *testing.T) {
+	resetMismatchWarnings(t)
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	for i := 0; i < 3; i++ {
+		NewAuthError(500, "Token invalid")
+	}
+
+	if warnings := findEntries(mem, "looks inconsistent"); len(warnings) != 1 {
+		t.Errorf("got %d warnings, want 1: %v", len(warnings), mem.Entries())
+	}
+}
@@ -0,0 +1,68 @@
+package goerrorkit
+
+import (
+	"context"
+	"errors"
+)
+
+// tierKey là key private để lưu SLA tier trong context.Context
+type tierKey struct{}
+
+// ContextWithTier gắn SLA tier của khách hàng (gold/silver/bronze) vào context.Context
+// Các ctx-aware functions (WrapCtx, WrapWithMessageCtx, NewBusinessErrorCtx, WithTierCtx) sẽ tự động lấy tier này
+//
+// Example:
+//
+//	// Middleware xác định tier từ tài khoản
+//	app.Use(func(c *fiber.Ctx) error {
+//	    c.SetUserContext(goerrorkit.ContextWithTier(c.UserContext(), account.Tier))
+//	    return c.Next()
+//	})
+func ContextWithTier(ctx context.Context, tier string) context.Context {
+	return context.WithValue(ctx, tierKey{}, tier)
+}
+
+// TierFromContext lấy SLA tier từ context.Context
+// Trả về chuỗi rỗng nếu context không có tier
+func TierFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if tier, ok := ctx.Value(tierKey{}).(string); ok {
+		return tier
+	}
+	return ""
+}
+
+// WithTier gắn SLA tier vào error, được log dưới field "sla_tier"
+// Dùng được làm label cho metrics qua field Tier
+//
+// Example:
+//
+//	return goerrorkit.NewSystemError(err).WithTier("gold")
+//
+//	// Metrics
+//	errorCounter.WithLabelValues(string(appErr.Type), appErr.Tier).Inc()
+func (e *AppError) WithTier(tier string) *AppError {
+	e.Tier = tier
+	return e
+}
+
+// WithTierCtx gắn SLA tier lấy từ context.Context (xem ContextWithTier)
+// Giữ nguyên tier hiện tại nếu context không có tier
+func (e *AppError) WithTierCtx(ctx context.Context) *AppError {
+	if tier := TierFromContext(ctx); tier != "" {
+		e.Tier = tier
+	}
+	return e
+}
+
+// inheritTier lấy SLA tier từ AppError nằm trong chain của err (nếu có)
+// Giúp tier không bị mất khi wrap nhiều lớp
+func inheritTier(err error) string {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr.Tier
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+package goerrorkit
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestWithTierLogged(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	LogError(NewSystemError(errors.New("db down")).WithTier("gold"), "GET /orders")
+	LogError(NewSystemError(errors.New("cache down")), "GET /orders")
+
+	entries := mem.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("entries = %v", entries)
+	}
+	if entries[0].Fields["sla_tier"] != "gold" {
+		t.Errorf("sla_tier = %v, want gold", entries[0].Fields["sla_tier"])
+	}
+	if _, ok := entries[1].Fields["sla_tier"]; ok {
+		t.Errorf("sla_tier logged without tier: %v", entries[1].Fields["sla_tier"])
+	}
+}
+
+func TestTierFromContext(t *testing.T) {
+	ctx := ContextWithTier(context.Background(), "silver")
+
+	if got := TierFromContext(ctx); got != "silver" {
+		t.Errorf("TierFromContext = %q", got)
+	}
+	if got := TierFromContext(context.Background()); got != "" {
+		t.Errorf("TierFromContext(empty) = %q", got)
+	}
+	if got := NewSystemError(nil).WithTierCtx(ctx).Tier; got != "silver" {
+		t.Errorf("WithTierCtx = %q", got)
+	}
+	// Context không có tier: giữ tier hiện tại
+	if got := NewSystemError(nil).WithTier("gold").WithTierCtx(context.Background()).Tier; got != "gold" {
+		t.Errorf("WithTierCtx without tier = %q, want gold kept", got)
+	}
+
+	for name, appErr := range map[string]*AppError{
+		"WrapCtx":             WrapCtx(ctx, errors.New("timeout")),
+		"WrapWithMessageCtx":  WrapWithMessageCtx(ctx, errors.New("timeout"), "Failed to save order"),
+		"NewBusinessErrorCtx": NewBusinessErrorCtx(ctx, 409, "Order already paid"),
+	} {
+		if appErr.Tier != "silver" {
+			t.Errorf("%s: Tier = %q, want silver from context", name, appErr.Tier)
+		}
+	}
+}
+
+func TestWrapKeepsInnerTier(t *testing.T) {
+	inner := NewBusinessError(409, "Order already paid").WithTier("gold")
+
+	for name, appErr := range map[string]*AppError{
+		"Wrap":               Wrap(inner),
+		"WrapWithMessage":    WrapWithMessage(inner, "Checkout failed"),
+		"WrapCtx":            WrapCtx(context.Background(), inner),
+		"WrapWithMessageCtx": WrapWithMessageCtx(context.Background(), inner, "Checkout failed"),
+	} {
+		if appErr.Tier != "gold" {
+			t.Errorf("%s: Tier = %q, want inner tier gold", name, appErr.Tier)
+		}
+	}
+
+	// Tier của context thắng tier bên trong
+	ctx := ContextWithTier(context.Background(), "bronze")
+	if got := WrapCtx(ctx, inner).Tier; got != "bronze" {
+		t.Errorf("WrapCtx with context tier = %q, want bronze", got)
+	}
+}
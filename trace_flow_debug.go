@@ -0,0 +1,67 @@
+//go:build debug
+// +build debug
+
+package goerrorkit
+
+import "time"
+
+// TraceFlow gom các bước của một flow phức tạp và emit MỘT dòng trace summary khi kết thúc
+// CHỈ hoạt động khi build với -tags=debug, production build là no-op
+type TraceFlow struct {
+	name   string
+	fields map[string]interface{}
+	start  time.Time
+	last   time.Time
+	steps  []map[string]interface{}
+}
+
+// StartTraceFlow bắt đầu một traced flow
+//
+// Example:
+//
+//	flow := goerrorkit.StartTraceFlow("Order Processing", map[string]interface{}{"order_id": id})
+//	validateOrder()
+//	flow.Step("Validating order", nil)
+//	chargePayment()
+//	flow.Step("Processing payment", map[string]interface{}{"gateway": "stripe"})
+//	flow.End(map[string]interface{}{"order_status": "confirmed"})
+func StartTraceFlow(name string, fields map[string]interface{}) *TraceFlow {
+	now := time.Now()
+	return &TraceFlow{
+		name:   name,
+		fields: fields,
+		start:  now,
+		last:   now,
+	}
+}
+
+// Step ghi nhận một bước đã hoàn thành, duration tính từ bước trước (hoặc từ lúc start)
+func (f *TraceFlow) Step(name string, data map[string]interface{}) {
+	now := time.Now()
+	step := map[string]interface{}{
+		"name":        name,
+		"duration_ms": now.Sub(f.last).Milliseconds(),
+	}
+	if len(data) > 0 {
+		step["data"] = data
+	}
+	f.steps = append(f.steps, step)
+	f.last = now
+}
+
+// End emit một dòng trace summary gồm total duration và breakdown từng bước
+func (f *TraceFlow) End(fields map[string]interface{}) {
+	summary := make(map[string]interface{}, len(f.fields)+len(fields)+4)
+	for k, v := range f.fields {
+		summary[k] = v
+	}
+	for k, v := range fields {
+		summary[k] = v
+	}
+	summary["flow"] = f.name
+	summary["total_duration_ms"] = time.Since(f.start).Milliseconds()
+	summary["step_count"] = len(f.steps)
+	summary["steps"] = f.steps
+
+	Trace(f.name+" completed", summary)
+}
@@ -0,0 +1,73 @@
+//go:build debug
+// +build debug
+
+package goerrorkit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTraceFlowSummary(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	flow := StartTraceFlow("Order Processing", map[string]interface{}{"order_id": 42, "status": "pending"})
+	time.Sleep(2 * time.Millisecond)
+	flow.Step("Validating order", nil)
+	flow.Step("Processing payment", map[string]interface{}{"gateway": "stripe"})
+	flow.End(map[string]interface{}{"status": "confirmed"})
+
+	entries := mem.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want a single summary: %v", len(entries), entries)
+	}
+	summary := entries[0]
+	if summary.Level != "trace" || summary.Message != "Order Processing completed" {
+		t.Errorf("entry = %s %q", summary.Level, summary.Message)
+	}
+
+	f := summary.Fields
+	if f["flow"] != "Order Processing" || f["order_id"] != 42 || f["step_count"] != 2 {
+		t.Errorf("fields = %v", f)
+	}
+	if f["status"] != "confirmed" {
+		t.Errorf("status = %v, want End fields to override start fields", f["status"])
+	}
+
+	steps, ok := f["steps"].([]map[string]interface{})
+	if !ok || len(steps) != 2 {
+		t.Fatalf("steps = %#v", f["steps"])
+	}
+	if steps[0]["name"] != "Validating order" || steps[1]["name"] != "Processing payment" {
+		t.Errorf("step names = %v, %v", steps[0]["name"], steps[1]["name"])
+	}
+	if _, ok := steps[0]["data"]; ok {
+		t.Errorf("step without data has data: %v", steps[0])
+	}
+	if data, _ := steps[1]["data"].(map[string]interface{}); data["gateway"] != "stripe" {
+		t.Errorf("step data = %v", steps[1]["data"])
+	}
+
+	first, _ := steps[0]["duration_ms"].(int64)
+	second, _ := steps[1]["duration_ms"].(int64)
+	total, _ := f["total_duration_ms"].(int64)
+	if first < 2 || second < 0 || total < first+second {
+		t.Errorf("durations: steps %d + %d, total %d", first, second, total)
+	}
+}
+
+func TestTraceFlowWithoutSteps(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	StartTraceFlow("Noop", nil).End(nil)
+
+	entry, ok := mem.Find("trace", "Noop completed")
+	if !ok {
+		t.Fatalf("summary not emitted: %v", mem.Entries())
+	}
+	if entry.Fields["step_count"] != 0 {
+		t.Errorf("step_count = %v, want 0", entry.Fields["step_count"])
+	}
+}
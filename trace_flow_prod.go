@@ -0,0 +1,23 @@
+//go:build !debug
+// +build !debug
+
+package goerrorkit
+
+// TraceFlow - PRODUCTION MODE: No-op
+// Không ghi nhận gì cả trong production build để tối ưu performance
+type TraceFlow struct{}
+
+// StartTraceFlow - PRODUCTION MODE: No-op
+func StartTraceFlow(name string, fields map[string]interface{}) *TraceFlow {
+	return &TraceFlow{}
+}
+
+// Step - PRODUCTION MODE: No-op
+func (f *TraceFlow) Step(name string, data map[string]interface{}) {
+	// No-op: Hoàn toàn không trace trong production
+}
+
+// End - PRODUCTION MODE: No-op
+func (f *TraceFlow) End(fields map[string]interface{}) {
+	// No-op: Hoàn toàn không trace trong production
+}
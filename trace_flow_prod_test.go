@@ -0,0 +1,19 @@
+//go:build !debug
+// +build !debug
+
+package goerrorkit
+
+import "testing"
+
+func TestTraceFlowNoOpInProduction(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	flow := StartTraceFlow("Order Processing", map[string]interface{}{"order_id": 42})
+	flow.Step("Validating order", nil)
+	flow.End(nil)
+
+	if entries := mem.Entries(); len(entries) != 0 {
+		t.Errorf("TraceFlow logged %v in production build", entries)
+	}
+}
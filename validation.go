@@ -0,0 +1,109 @@
+package goerrorkit
+
+import (
+	"fmt"
+	"time"
+)
+
+// FieldError mô tả lỗi validation của một field trong request
+type FieldError struct {
+	Field   string                 `json:"field"`
+	Message string                 `json:"message"`
+	Meta    map[string]interface{} `json:"meta,omitempty"`
+}
+
+// NewValidationErrors tạo ValidationError rỗng để tích lũy lỗi của nhiều field bằng AddFieldError
+// Response có thêm mảng "fields", log có data.fields
+//
+// Example:
+//
+//	verr := goerrorkit.NewValidationErrors("Dữ liệu không hợp lệ")
+//	if req.Email == "" {
+//	    verr.AddFieldError("email", "Email là bắt buộc", nil)
+//	}
+//	if req.Age < 18 {
+//	    verr.AddFieldError("age", "Tuổi phải từ 18", map[string]interface{}{"min": 18, "received": req.Age})
+//	}
+//	if verr.HasFieldErrors() {
+//	    return verr
+//	}
+func NewValidationErrors(msg string) *AppError {
+	file, line, function := getCallerInfo(1)
+	return &AppError{
+		Type:      ValidationError,
+		Code:      ValidationError.DefaultHTTPStatus(),
+		Message:   msg,
+		CreatedAt: time.Now(),
+		Details: map[string]interface{}{
+			"function": function,
+			"file":     fmt.Sprintf("%s:%d", file, line),
+		},
+	}
+}
+
+// AddFieldError thêm lỗi của một field (meta có thể nil)
+// Trả về chính AppError để chain nhiều field
+func (e *AppError) AddFieldError(field, message string, meta map[string]interface{}) *AppError {
+	e.FieldErrors = append(e.FieldErrors, FieldError{
+		Field:   field,
+		Message: message,
+		Meta:    meta,
+	})
+	return e
+}
+
+// HasFieldErrors kiểm tra error đã có lỗi field nào chưa
+func (e *AppError) HasFieldErrors() bool {
+	return len(e.FieldErrors) > 0
+}
+
+// fieldErrorMaps chuyển FieldErrors sang []map để redactor xử lý được meta nhạy cảm
+func fieldErrorMaps(fieldErrors []FieldError) []map[string]interface{} {
+	out := make([]map[string]interface{}, len(fieldErrors))
+	for i, fe := range fieldErrors {
+		m := map[string]interface{}{
+			"field":   fe.Field,
+			"message": fe.Message,
+		}
+		if len(fe.Meta) > 0 {
+			m["meta"] = fe.Meta
+		}
+		out[i] = m
+	}
+	return out
+}
+
+// CollectAppErrors trả về mọi AppError trong cây error của err
+// Hỗ trợ cả Unwrap() error (fmt.Errorf %w, AppError.Cause) và Unwrap() []error (errors.Join)
+// Thứ tự: duyệt theo chiều sâu, trái sang phải
+//
+// Example:
+//
+//	err := errors.Join(validateName(req), validateEmail(req))
+//	for _, appErr := range goerrorkit.CollectAppErrors(err) {
+//	    verr.AddFieldError(appErr.Data["field"].(string), appErr.Message, nil)
+//	}
+func CollectAppErrors(err error) []*AppError {
+	var result []*AppError
+	collectAppErrors(err, &result)
+	return result
+}
+
+// collectAppErrors duyệt đệ quy cây error
+func collectAppErrors(err error, result *[]*AppError) {
+	if err == nil {
+		return
+	}
+	if appErr, ok := err.(*AppError); ok && appErr != nil {
+		*result = append(*result, appErr)
+	}
+
+	switch u := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, inner := range u.Unwrap() {
+			collectAppErrors(inner, result)
+		}
+	case interface{ Unwrap() error }:
+		collectAppErrors(u.Unwrap(), result)
+	}
+}
@@ -0,0 +1,136 @@
+package goerrorkit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func newSignupValidationError() *AppError {
+	return NewValidationErrors("Dữ liệu không hợp lệ").
+		AddFieldError("email", "Email là bắt buộc", nil).
+		AddFieldError("age", "Tuổi phải từ 18", map[string]interface{}{"min": 18, "received": 16}).
+		AddFieldError("password", "Mật khẩu quá ngắn", map[string]interface{}{"min": 8, "password": "abc"})
+}
+
+func TestValidationErrorsResponseShape(t *testing.T) {
+	appErr := newSignupValidationError()
+	if appErr.Type != ValidationError || appErr.Code != 400 || !appErr.HasFieldErrors() {
+		t.Fatalf("got type %s code %d fields %d", appErr.Type, appErr.Code, len(appErr.FieldErrors))
+	}
+
+	raw, err := json.Marshal(FormatErrorResponse(appErr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body struct {
+		Error  string `json:"error"`
+		Type   string `json:"type"`
+		Fields []struct {
+			Field   string                 `json:"field"`
+			Message string                 `json:"message"`
+			Meta    map[string]interface{} `json:"meta"`
+		} `json:"fields"`
+	}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		t.Fatal(err)
+	}
+
+	if body.Error != "Dữ liệu không hợp lệ" || body.Type != "VALIDATION" {
+		t.Errorf("body = %s", raw)
+	}
+	if len(body.Fields) != 3 {
+		t.Fatalf("fields = %d, want 3: %s", len(body.Fields), raw)
+	}
+	gotFields := []string{body.Fields[0].Field, body.Fields[1].Field, body.Fields[2].Field}
+	if !reflect.DeepEqual(gotFields, []string{"email", "age", "password"}) {
+		t.Errorf("field order = %v", gotFields)
+	}
+	if body.Fields[0].Meta != nil {
+		t.Errorf("email meta = %v, want omitted", body.Fields[0].Meta)
+	}
+	if body.Fields[1].Message != "Tuổi phải từ 18" || body.Fields[1].Meta["min"] != float64(18) {
+		t.Errorf("age field = %+v", body.Fields[1])
+	}
+}
+
+func TestValidationErrorsWithoutFieldsOmitsFields(t *testing.T) {
+	response := FormatErrorResponse(NewValidationErrors("Dữ liệu không hợp lệ"))
+	if _, ok := response["fields"]; ok {
+		t.Errorf("response = %v, want no fields key", response)
+	}
+}
+
+func TestValidationErrorsLoggedUnderData(t *testing.T) {
+	mem := UseMemoryLogger()
+	defer SetLogger(nil)
+
+	appErr := newSignupValidationError().WithData(map[string]interface{}{"form": "signup"})
+	LogError(appErr, "POST /signup")
+
+	entry, ok := mem.Find("warn", "Dữ liệu không hợp lệ")
+	if !ok {
+		t.Fatalf("entry not logged: %+v", mem.Entries())
+	}
+	data, _ := entry.Fields["data"].(map[string]interface{})
+	if data["form"] != "signup" {
+		t.Errorf("data = %v, want existing Data kept", data)
+	}
+	fields, _ := data["fields"].([]map[string]interface{})
+	if len(fields) != 3 {
+		t.Fatalf("data.fields = %#v, want 3 entries", data["fields"])
+	}
+
+	// Meta nhạy cảm được che trong log
+	meta, _ := fields[2]["meta"].(map[string]interface{})
+	if meta["password"] != redactedValue || meta["min"] != 8 {
+		t.Errorf("password meta = %v, want password redacted", meta)
+	}
+
+	// AppError gốc không bị thay đổi bởi redaction
+	if appErr.FieldErrors[2].Meta["password"] != "abc" {
+		t.Errorf("FieldErrors mutated: %v", appErr.FieldErrors[2].Meta)
+	}
+}
+
+func TestCollectAppErrorsJoin(t *testing.T) {
+	nameErr := NewValidationError("Tên là bắt buộc", map[string]interface{}{"field": "name"})
+	emailErr := NewValidationError("Email không hợp lệ", map[string]interface{}{"field": "email"})
+	dbErr := NewSystemError(errors.New("db down"))
+
+	err := errors.Join(
+		nameErr,
+		errors.New("plain error"),
+		fmt.Errorf("check email: %w", emailErr),
+		errors.Join(dbErr, nil),
+	)
+
+	got := CollectAppErrors(err)
+	want := []*AppError{nameErr, emailErr, dbErr}
+	if len(got) != len(want) {
+		t.Fatalf("CollectAppErrors = %d errors, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got[%d] = %q, want %q", i, got[i].Message, want[i].Message)
+		}
+	}
+}
+
+func TestCollectAppErrorsWrappedCause(t *testing.T) {
+	inner := NewBusinessError(404, "Order not found")
+	outer := WrapWithMessage(inner, "Checkout failed")
+
+	got := CollectAppErrors(outer)
+	if len(got) != 2 || got[0] != outer || got[1] != inner {
+		t.Errorf("CollectAppErrors = %v, want [outer inner]", got)
+	}
+	if got := CollectAppErrors(nil); got != nil {
+		t.Errorf("CollectAppErrors(nil) = %v, want nil", got)
+	}
+	if got := CollectAppErrors(errors.New("plain")); len(got) != 0 {
+		t.Errorf("CollectAppErrors(plain) = %v, want empty", got)
+	}
+}